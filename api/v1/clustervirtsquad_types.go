@@ -0,0 +1,149 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterVirtSquadLabel is set on the VirtSquads materialized from a ClusterVirtSquad to its name
+const ClusterVirtSquadLabel = "virtsquad.mshort55.io/cluster-squad"
+
+// ClusterVirtSquadSpec defines the squads materialized from a ClusterVirtSquad
+// +kubebuilder:validation:XValidation:rule="[has(self.targetNamespace), has(self.namespaceSelector), has(self.provisionNamespace)].filter(x, x).size() == 1",message="exactly one of targetNamespace, namespaceSelector and provisionNamespace must be set"
+type ClusterVirtSquadSpec struct {
+	// TargetNamespace is the namespace the squad is created in
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+
+	// NamespaceSelector selects the namespaces the squad is created in. Namespaces that stop
+	// matching lose their squad.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// ProvisionNamespace has the operator create a dedicated namespace for the squad and own it.
+	// The namespace, and everything in it, is deleted along with the ClusterVirtSquad.
+	// +optional
+	ProvisionNamespace *ProvisionNamespaceSpec `json:"provisionNamespace,omitempty"`
+
+	// Template is the spec of the VirtSquad created in each target namespace. The squads are
+	// named after the ClusterVirtSquad and overwritten when edited directly.
+	// +required
+	Template VirtSquadSpec `json:"template"`
+}
+
+// ProvisionNamespaceSpec defines the namespace created for a ClusterVirtSquad
+type ProvisionNamespaceSpec struct {
+	// Name is the name of the namespace. Defaults to the name of the ClusterVirtSquad. An existing
+	// namespace the operator did not create is never taken over.
+	// +optional
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="name is immutable"
+	Name string `json:"name,omitempty"`
+
+	// Labels are set on the namespace, such as pod security admission labels
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are set on the namespace
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ClusterVirtSquadNamespaceStatus reports the squad materialized in one target namespace
+type ClusterVirtSquadNamespaceStatus struct {
+	// Namespace is the target namespace
+	Namespace string `json:"namespace"`
+
+	// ReadyPods is the number of ready pods of the namespace's squad
+	// +optional
+	ReadyPods int32 `json:"readyPods"`
+
+	// DesiredPods is the number of pods the namespace's squad should run
+	// +optional
+	DesiredPods int32 `json:"desiredPods"`
+
+	// Ready reports whether the namespace's squad is Ready
+	// +optional
+	Ready bool `json:"ready"`
+}
+
+// ClusterVirtSquadStatus defines the observed state of ClusterVirtSquad
+type ClusterVirtSquadStatus struct {
+	// ObservedGeneration is the most recent generation observed by the controller
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Namespaces reports the squad of each target namespace
+	// +optional
+	// +listType=map
+	// +listMapKey=namespace
+	Namespaces []ClusterVirtSquadNamespaceStatus `json:"namespaces,omitempty"`
+
+	// ReadyNamespaces is the number of target namespaces whose squad is Ready
+	// +optional
+	ReadyNamespaces int32 `json:"readyNamespaces"`
+
+	// TargetNamespaces is the number of namespaces the squad is materialized in
+	// +optional
+	TargetNamespaces int32 `json:"targetNamespaces"`
+
+	// Conditions represent the latest available observations of the ClusterVirtSquad's state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=cvsq
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=`.status.readyNamespaces`,description="Number of target namespaces whose squad is ready"
+// +kubebuilder:printcolumn:name="Namespaces",type=integer,JSONPath=`.status.targetNamespaces`,description="Number of target namespaces"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterVirtSquad is the Schema for the clustervirtsquads API
+type ClusterVirtSquad struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the desired state of ClusterVirtSquad
+	// +required
+	Spec ClusterVirtSquadSpec `json:"spec"`
+
+	// status defines the observed state of ClusterVirtSquad
+	// +optional
+	Status ClusterVirtSquadStatus `json:"status,omitempty,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterVirtSquadList contains a list of ClusterVirtSquad
+type ClusterVirtSquadList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterVirtSquad `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterVirtSquad{}, &ClusterVirtSquadList{})
+}
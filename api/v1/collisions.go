@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// namedMember pairs a team member's spec with the member's name
+// +kubebuilder:object:generate=false
+type namedMember struct {
+	name string
+	spec *TeamMemberSpec
+}
+
+// definedMembers returns the team members the spec defines
+func (s *VirtSquadSpec) definedMembers() []namedMember {
+	var members []namedMember
+	for _, member := range []namedMember{{"oksana", s.Oksana}, {"kurtis", s.Kurtis}, {"matt", s.Matt}, {"kike", s.Kike}} {
+		if member.spec != nil && member.spec.Name != nil {
+			members = append(members, member)
+		}
+	}
+	return members
+}
+
+// Collisions describes how the pods of the spec's team members would collide with those of
+// another squad in the same namespace: members naming their pods alike compete for the same pod
+// names, and members whose pod labels select the other's pods make Services and other label
+// selectors pick up both squads' pods
+func (s *VirtSquadSpec) Collisions(other *VirtSquadSpec) []string {
+	var collisions []string
+	for _, mine := range s.definedMembers() {
+		for _, theirs := range other.definedMembers() {
+			if *mine.spec.Name == *theirs.spec.Name {
+				collisions = append(collisions, fmt.Sprintf("%s and its %s both name their pods %s", mine.name, theirs.name, *mine.spec.Name))
+				continue
+			}
+			if len(mine.spec.PodLabels) > 0 && labels.SelectorFromSet(mine.spec.PodLabels).Matches(labels.Set(theirs.spec.PodLabels)) {
+				collisions = append(collisions, fmt.Sprintf("the podLabels of %s also select the pods of its %s", mine.name, theirs.name))
+				continue
+			}
+			if len(theirs.spec.PodLabels) > 0 && labels.SelectorFromSet(theirs.spec.PodLabels).Matches(labels.Set(mine.spec.PodLabels)) {
+				collisions = append(collisions, fmt.Sprintf("the podLabels of its %s also select the pods of %s", theirs.name, mine.name))
+			}
+		}
+	}
+	return collisions
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SquadFleetLabel is set on the VirtSquads created for a SquadFleet to the fleet's name
+const SquadFleetLabel = "virtsquad.mshort55.io/fleet"
+
+// SquadFleetSpec defines the squads composed into a SquadFleet
+type SquadFleetSpec struct {
+	// Defaults are merged into the spec of every inline squad; fields the squad sets take
+	// precedence, and lists the squad sets replace the defaults' lists.
+	// Defaults and inline specs are partial, so they are stored schemaless: neither the
+	// VirtSquad's validation rules nor its defaults apply to them before the merge. The VirtSquad
+	// created from the merged spec is validated in full, and the fleet reports those it rejects.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Defaults *VirtSquadSpec `json:"defaults,omitempty"`
+
+	// Squads are the squads making up the fleet
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=50
+	// +listType=map
+	// +listMapKey=name
+	Squads []FleetSquad `json:"squads"`
+}
+
+// FleetSquad is one squad of a SquadFleet, either inline or referenced
+type FleetSquad struct {
+	// Name identifies the squad within the fleet. An inline squad is created as the VirtSquad
+	// <fleet>-<name>; without a spec, Name references an existing VirtSquad in the fleet's
+	// namespace whose status the fleet reports but whose lifecycle it leaves alone.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Spec is the spec of an inline squad, which the fleet creates, updates and deletes. It is
+	// merged over the fleet's defaults and stored schemaless like them.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Spec *VirtSquadSpec `json:"spec,omitempty"`
+}
+
+// FleetSquadStatus reports one squad of a SquadFleet
+type FleetSquadStatus struct {
+	// Name is the squad's name within the fleet
+	Name string `json:"name"`
+
+	// VirtSquad is the name of the VirtSquad backing the squad
+	// +optional
+	VirtSquad string `json:"virtSquad,omitempty"`
+
+	// ReadyPods is the number of ready pods of the squad
+	// +optional
+	ReadyPods int32 `json:"readyPods"`
+
+	// DesiredPods is the number of pods the squad's team members want to run
+	// +optional
+	DesiredPods int32 `json:"desiredPods"`
+
+	// Ready reports whether the squad's Ready condition is true
+	// +optional
+	Ready bool `json:"ready"`
+}
+
+// SquadFleetStatus defines the observed state of SquadFleet
+type SquadFleetStatus struct {
+	// ObservedGeneration is the most recent generation observed by the controller
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Squads reports each squad of the fleet
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Squads []FleetSquadStatus `json:"squads,omitempty"`
+
+	// ReadySquads is the number of the fleet's squads that are Ready
+	// +optional
+	ReadySquads int32 `json:"readySquads"`
+
+	// TotalSquads is the number of squads in the fleet
+	// +optional
+	TotalSquads int32 `json:"totalSquads"`
+
+	// ReadyPods is the number of ready pods across the fleet's squads
+	// +optional
+	ReadyPods int32 `json:"readyPods"`
+
+	// DesiredPods is the number of pods the fleet's squads want to run
+	// +optional
+	DesiredPods int32 `json:"desiredPods"`
+
+	// Conditions represent the latest available observations of the SquadFleet's state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=sqf
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=`.status.readySquads`,description="Number of ready squads"
+// +kubebuilder:printcolumn:name="Squads",type=integer,JSONPath=`.status.totalSquads`,description="Number of squads"
+// +kubebuilder:printcolumn:name="Pods",type=integer,JSONPath=`.status.readyPods`,description="Number of ready pods across the squads",priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// SquadFleet is the Schema for the squadfleets API
+type SquadFleet struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the desired state of SquadFleet
+	// +required
+	Spec SquadFleetSpec `json:"spec"`
+
+	// status defines the observed state of SquadFleet
+	// +optional
+	Status SquadFleetStatus `json:"status,omitempty,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// SquadFleetList contains a list of SquadFleet
+type SquadFleetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SquadFleet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SquadFleet{}, &SquadFleetList{})
+}
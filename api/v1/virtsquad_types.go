@@ -17,31 +17,475 @@ limitations under the License.
 package v1
 
 import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// DefaultMemberImage is the image run by team members that do not set one
+const DefaultMemberImage = "nginx:latest"
+
 // TeamMemberSpec defines the configuration for a team member
+// +kubebuilder:validation:XValidation:rule="!has(self.os) || self.os != 'windows' || (has(self.image) && size(self.image) > 0 && self.image != 'nginx:latest')",message="image must be set to a Windows image for Windows team members, since the default image only runs on Linux"
+// +kubebuilder:validation:XValidation:rule="!has(self.windowsOptions) || (has(self.os) && self.os == 'windows')",message="windowsOptions requires os to be windows"
 type TeamMemberSpec struct {
-	// Name specifies the name for the team member's pod
+	// Name specifies the name for the team member's pod. The validating webhook rejects changes
+	// to it once set unless the update carries the ForceRenameAnnotation, in which case pods
+	// created under the previous name are replaced according to the rollout strategy.
 	// +optional
 	Name *string `json:"name,omitempty"`
 
-	// Replicas specifies the number of pods for this team member
+	// Replicas specifies the number of pods for this team member, one when unset
 	// +optional
-	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:XValidation:rule="self <= 100",message="replicas must not exceed 100"
 	Replicas *int32 `json:"replicas,omitempty"`
+
+	// StandbyReplicas is the number of extra pods kept running but excluded from the member's
+	// Service. When the member scales up, ready standby pods are promoted into the Service
+	// at once and replaced by new standby pods.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:XValidation:rule="self <= 100",message="standbyReplicas must not exceed 100"
+	StandbyReplicas *int32 `json:"standbyReplicas,omitempty"`
+
+	// Image is the container image run by the team member's pods, nginx:latest when unset.
+	// Changing it rolls the member's pods according to the rollout strategy.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Command overrides the entrypoint of the member's image. It is not run in a shell; the
+	// image's ENTRYPOINT is used when unset. Changing it rolls the member's pods.
+	// +optional
+	// +listType=atomic
+	Command []string `json:"command,omitempty"`
+
+	// Args overrides the arguments passed to the entrypoint, replacing the image's CMD. They
+	// may use the same placeholders as env values. Changing them rolls the member's pods.
+	// +optional
+	// +listType=atomic
+	Args []string `json:"args,omitempty"`
+
+	// Env sets environment variables in the member's container. Values may use the Go template
+	// placeholders {{ .Squad.Name }}, {{ .Squad.Namespace }}, {{ .Member.Name }} and
+	// {{ .Ordinal }}, which are resolved for each pod. Changing it rolls the member's pods.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=100
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// InjectMetadata sets the VIRTSQUAD_NAME, MEMBER_NAME, REPLICA_ORDINAL and POD_IP
+	// environment variables in the member's container through the Downward API, so its
+	// processes know their identity. Changing it rolls the member's pods.
+	// +optional
+	InjectMetadata *bool `json:"injectMetadata,omitempty"`
+
+	// Resources are the compute resources of the team member's container. Changing them rolls
+	// the member's pods, unless the operator resizes them in place.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// PodLabels are added to the labels of the member's new pods, e.g. so that existing Services
+	// select them. The labels the operator sets itself take precedence.
+	// +optional
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// PodAnnotations are added to the annotations of the member's new pods. Values may use the
+	// same placeholders as env values. The annotations the operator sets itself take precedence.
+	// +optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// Rollout controls how the member's pods are replaced when their spec changes
+	// +optional
+	Rollout *RolloutSpec `json:"rollout,omitempty"`
+
+	// RolloutPaused pauses an in-flight rollout of the member's pods. Pods that were already
+	// replaced keep running the new spec; the remaining pods are replaced once it is unset.
+	// +optional
+	RolloutPaused *bool `json:"rolloutPaused,omitempty"`
+
+	// RollbackTo restores the member's pods to a previous revision, as listed by the
+	// member's ControllerRevisions and status.members[].currentRevisionNumber. While set, pods
+	// are rendered from that revision instead of the spec; unset it to return to the spec.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	RollbackTo *int64 `json:"rollbackTo,omitempty"`
+
+	// FailurePolicy limits how often the controller recreates pods that keep failing
+	// +optional
+	FailurePolicy *FailurePolicySpec `json:"failurePolicy,omitempty"`
+
+	// Failover runs the member's pods in active/passive mode: a single pod is selected by the
+	// member's Service, and a ready passive pod is promoted in its place when it fails
+	// +optional
+	Failover *FailoverSpec `json:"failover,omitempty"`
+
+	// LeaderElection labels one ready pod of the member as its leader and elects another one
+	// when the leader fails. Clients reach the leader through the <squad>-<member>-leader Service.
+	// +optional
+	LeaderElection *LeaderElectionSpec `json:"leaderElection,omitempty"`
+
+	// PreStartJob is a Job that must complete successfully before the team member's pods are
+	// created, e.g. to run schema migrations or warm caches. The Job is re-run when its template changes
+	// and deleted when the field is removed.
+	// The template is stored schemaless to keep the CRD within the API server's size limits.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	PreStartJob *batchv1.JobTemplateSpec `json:"preStartJob,omitempty"`
+
+	// Service exposes the team member's pods through a Service named <squad>-<member>
+	// +optional
+	Service *MemberServiceSpec `json:"service,omitempty"`
+
+	// Overrides lets VirtSquadMemberOverrides in the squad's namespace adjust the member's
+	// replicas and image within the given bounds. Overrides are ignored when unset.
+	// +optional
+	Overrides *OverridePolicy `json:"overrides,omitempty"`
+
+	// DependsOn names the team members whose pods must all be ready before this member's
+	// pods are created, e.g. a frontend waiting for its backend. Pods that already run are
+	// kept when a dependency becomes unready.
+	// +optional
+	// +kubebuilder:validation:MaxItems=3
+	// +kubebuilder:validation:items:Enum=oksana;kurtis;matt;kike
+	// +listType=set
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// Zones restricts the member's pods to nodes in the listed topology.kubernetes.io/zone
+	// values, spreading new pods round-robin across them
+	// +optional
+	// +kubebuilder:validation:MaxItems=10
+	// +kubebuilder:validation:items:MinLength=1
+	Zones []string `json:"zones,omitempty"`
+
+	// Architecture restricts the member's pods to nodes of the CPU architecture through the
+	// kubernetes.io/arch node label
+	// +optional
+	// +kubebuilder:validation:Enum=amd64;arm64
+	Architecture string `json:"architecture,omitempty"`
+
+	// Performance tunes the member's pods for latency-sensitive workloads through CPU pinning
+	// and NUMA alignment
+	// +optional
+	Performance *PerformanceSpec `json:"performance,omitempty"`
+
+	// RuntimeClassName is the RuntimeClass the member's pods run with, such as a sandboxed or
+	// VM-based runtime. The overhead the RuntimeClass declares is included in the member's cost.
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+
+	// OS is the operating system the member's pods run on. Windows pods are scheduled onto
+	// Windows nodes through the kubernetes.io/os node label and skip Linux-only pod settings.
+	// Windows members must set an image other than the default one alongside it.
+	// +optional
+	// +kubebuilder:validation:Enum=linux;windows
+	OS string `json:"os,omitempty"`
+
+	// WindowsOptions are the Windows-specific security settings of the member's pods, such as
+	// the user to run as or whether to run as a HostProcess container
+	// +optional
+	WindowsOptions *corev1.WindowsSecurityContextOptions `json:"windowsOptions,omitempty"`
+
+	// ValidateArchitecture checks that the member's image is built for its architecture before
+	// pods are created, by reading the image's manifest list from its registry. Only public
+	// images can be checked; lookups that fail do not hold pods back.
+	// +optional
+	ValidateArchitecture *bool `json:"validateArchitecture,omitempty"`
+
+	// CapacityPolicy runs part of the member's replicas on spot or preemptible nodes and the
+	// rest on on-demand nodes
+	// +optional
+	CapacityPolicy *CapacityPolicySpec `json:"capacityPolicy,omitempty"`
+
+	// OOMPolicy raises the member's memory after its containers were OOMKilled repeatedly
+	// +optional
+	OOMPolicy *OOMPolicySpec `json:"oomPolicy,omitempty"`
+
+	// Eviction sets the annotations that tell the cluster autoscaler and the descheduler
+	// whether they may evict the member's pods
+	// +optional
+	Eviction *EvictionSpec `json:"eviction,omitempty"`
+
+	// SmokeTest is run by the operator against each of the member's pods once it first becomes
+	// ready. The pod only counts toward the squad's ready pods after the test passed, and is
+	// recreated when it fails.
+	// +optional
+	SmokeTest *SmokeTestSpec `json:"smokeTest,omitempty"`
+}
+
+// SmokeTestSpec describes a check run against a team member's new pods
+// +kubebuilder:validation:XValidation:rule="has(self.httpGet) != has(self.exec)",message="exactly one of httpGet and exec must be set"
+type SmokeTestSpec struct {
+	// HTTPGet sends a GET request to the pod's IP, which must answer with a 2xx or 3xx status.
+	// The host may not be set. As for HTTP probes, redirects are not followed and the
+	// certificate of HTTPS pods is not verified.
+	// +optional
+	HTTPGet *corev1.HTTPGetAction `json:"httpGet,omitempty"`
+
+	// Exec runs a command in the member's container, which must exit with status zero
+	// +optional
+	Exec *corev1.ExecAction `json:"exec,omitempty"`
+
+	// TimeoutSeconds is how long the test may take
+	// +optional
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=60
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// OverridePolicy bounds the changes VirtSquadMemberOverrides may make to a team member
+type OverridePolicy struct {
+	// MinReplicas is the fewest replicas an override may set
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the most replicas an override may set; there is no upper bound when unset
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+
+	// AllowedImages are the glob patterns, such as "registry.example.com/team/*", an override's
+	// image must match. Patterns are matched as file paths, so * never matches a /:
+	// "registry.example.com/team/*" allows "registry.example.com/team/app:1.0" but not
+	// "registry.example.com/team/sub/app", which needs a pattern of its own such as
+	// "registry.example.com/team/*/*". Overrides may not change the image when empty.
+	// +optional
+	// +kubebuilder:validation:MaxItems=20
+	AllowedImages []string `json:"allowedImages,omitempty"`
+}
+
+// MemberServiceSpec defines the Service generated for a team member
+type MemberServiceSpec struct {
+	// Type is the type of the generated Service
+	// +optional
+	// +kubebuilder:default=ClusterIP
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	Type corev1.ServiceType `json:"type,omitempty"`
+
+	// ExternalDNS publishes a DNS record for the Service through external-dns
+	// +optional
+	ExternalDNS *ExternalDNSSpec `json:"externalDNS,omitempty"`
+}
+
+// ExternalDNSSpec defines the external-dns annotations set on a member's Service
+type ExternalDNSSpec struct {
+	// Hostname is the DNS name external-dns manages for the Service
+	// +kubebuilder:validation:MinLength=1
+	Hostname string `json:"hostname"`
+
+	// TTL is the DNS record TTL in seconds
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TTL *int32 `json:"ttl,omitempty"`
+}
+
+// RolloutStrategyType is the strategy used to replace a member's outdated pods
+// +kubebuilder:validation:Enum=RollingUpdate;Canary
+type RolloutStrategyType string
+
+const (
+	// RollingUpdateStrategy replaces outdated pods one at a time, waiting for each replacement to become ready
+	RollingUpdateStrategy RolloutStrategyType = "RollingUpdate"
+	// CanaryStrategy moves a growing percentage of the member's pods to the new spec in timed steps
+	CanaryStrategy RolloutStrategyType = "Canary"
+)
+
+// RolloutSpec defines how a member's pods are replaced when their spec changes
+type RolloutSpec struct {
+	// Strategy is the rollout strategy
+	// +optional
+	// +kubebuilder:default=RollingUpdate
+	Strategy RolloutStrategyType `json:"strategy,omitempty"`
+
+	// Canary configures the Canary strategy
+	// +optional
+	Canary *CanarySpec `json:"canary,omitempty"`
+
+	// Partition restricts updates to pods whose ordinal is greater than or equal to the
+	// partition, StatefulSet-style, so updates can be staged replica by replica.
+	// Lower ordinals keep running their previous spec until the partition is lowered.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Partition *int32 `json:"partition,omitempty"`
+
+	// ProgressDeadlineSeconds is how long replaced pods may take to become ready before the
+	// rollout is considered stalled. A stalled rollout reports Progressing=False with reason
+	// ProgressDeadlineExceeded and replaces no further pods until the spec changes.
+	// +optional
+	// +kubebuilder:default=600
+	// +kubebuilder:validation:Minimum=1
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+}
+
+// CanarySpec defines the steps of a canary rollout
+type CanarySpec struct {
+	// Steps are the percentages of replicas running the new spec at each step, e.g. [10, 50, 100].
+	// A final 100% step is implied when the last step is lower.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Minimum=1
+	// +kubebuilder:validation:items:Maximum=100
+	Steps []int32 `json:"steps"`
+
+	// StepIntervalSeconds is how long the canary pods of a step must stay ready before the next step.
+	// The rollout is aborted if they are not all ready by then.
+	// +optional
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=0
+	StepIntervalSeconds *int32 `json:"stepIntervalSeconds,omitempty"`
+}
+
+// FailurePolicySpec defines how the controller reacts to pods that keep failing
+type FailurePolicySpec struct {
+	// MaxRecreateAttempts is the number of times failing pods are recreated before the member is marked Failed
+	// +optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=0
+	MaxRecreateAttempts *int32 `json:"maxRecreateAttempts,omitempty"`
+
+	// BackoffSeconds is the delay between recreation attempts; it doubles with each further attempt
+	// +optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=0
+	BackoffSeconds *int32 `json:"backoffSeconds,omitempty"`
+}
+
+// CapacityPolicySpec splits a team member's pods between spot and on-demand nodes
+type CapacityPolicySpec struct {
+	// SpotPercent is the share of the member's replicas placed on spot nodes, rounded down
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	SpotPercent int32 `json:"spotPercent"`
+
+	// SpotNodeSelector selects the spot nodes, such as karpenter.sh/capacity-type: spot
+	// +kubebuilder:validation:MinProperties=1
+	SpotNodeSelector map[string]string `json:"spotNodeSelector"`
+
+	// OnDemandNodeSelector selects the on-demand nodes, such as karpenter.sh/capacity-type: on-demand
+	// +kubebuilder:validation:MinProperties=1
+	OnDemandNodeSelector map[string]string `json:"onDemandNodeSelector"`
+
+	// SpotTolerations are added to the pods placed on spot nodes, matching the taints of those nodes
+	// +optional
+	SpotTolerations []corev1.Toleration `json:"spotTolerations,omitempty"`
+
+	// BackfillSeconds is how long after a spot interruption new pods are placed on on-demand
+	// nodes only. Pods backfilled on on-demand nodes stay there until they are next replaced.
+	// +optional
+	// +kubebuilder:default=600
+	// +kubebuilder:validation:Minimum=0
+	BackfillSeconds *int32 `json:"backfillSeconds,omitempty"`
+}
+
+// PerformanceSpec pins a team member's pods to dedicated CPUs and aligns them on NUMA nodes
+// +kubebuilder:validation:XValidation:rule="!has(self.numaNodeSelector) || self.dedicatedCPUs",message="numaNodeSelector requires dedicatedCPUs, since the topology manager only aligns Guaranteed pods"
+type PerformanceSpec struct {
+	// DedicatedCPUs runs the member's pods in the Guaranteed QoS class, so that a kubelet with
+	// the static CPU manager policy pins them to exclusive cores. The container's limits are set
+	// to its requests, which must ask for a whole number of CPUs and for memory.
+	// +optional
+	DedicatedCPUs *bool `json:"dedicatedCPUs,omitempty"`
+
+	// NUMANodeSelector selects the nodes whose kubelet topology manager aligns CPUs and memory
+	// on a single NUMA node, as labeled by the cluster administrator
+	// +optional
+	// +kubebuilder:validation:MinProperties=1
+	NUMANodeSelector map[string]string `json:"numaNodeSelector,omitempty"`
+}
+
+// OOMPolicySpec raises a team member's memory in steps when its containers keep being OOMKilled.
+// The increase is recorded in status.members[].memoryAdjustment and applied to the member's
+// memory request and limit, rolling its pods; it is kept until the policy is removed.
+type OOMPolicySpec struct {
+	// OOMKills is how many OOMKills of the member's containers trigger the next increase
+	// +optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	OOMKills *int32 `json:"oomKills,omitempty"`
+
+	// Step is how much memory each increase adds
+	Step resource.Quantity `json:"step"`
+
+	// Max caps the member's memory limit, or its request when it sets no limit
+	Max resource.Quantity `json:"max"`
+}
+
+// DeschedulerEviction names whether the descheduler may evict a team member's pods
+// +kubebuilder:validation:Enum=Evict;Prevent
+type DeschedulerEviction string
+
+const (
+	// DeschedulerEvictionEvict lets the descheduler evict pods it would otherwise skip
+	DeschedulerEvictionEvict DeschedulerEviction = "Evict"
+	// DeschedulerEvictionPrevent keeps the descheduler from evicting the pods
+	DeschedulerEvictionPrevent DeschedulerEviction = "Prevent"
+)
+
+// EvictionSpec controls the eviction annotations of a team member's pods. Annotations whose
+// field is unset are left as they are, and changes apply to running pods in place.
+type EvictionSpec struct {
+	// SafeToEvict sets the cluster-autoscaler.kubernetes.io/safe-to-evict annotation, allowing
+	// or preventing the cluster autoscaler to remove the pods' nodes
+	// +optional
+	SafeToEvict *bool `json:"safeToEvict,omitempty"`
+
+	// Descheduler sets the descheduler.alpha.kubernetes.io/evict annotation for Evict, or the
+	// descheduler.alpha.kubernetes.io/prevent-eviction annotation for Prevent
+	// +optional
+	Descheduler DeschedulerEviction `json:"descheduler,omitempty"`
+}
+
+// FailoverMode names how a team member's pods share the member's traffic
+// +kubebuilder:validation:Enum=ActivePassive
+type FailoverMode string
+
+const (
+	// FailoverModeActivePassive sends traffic to one active pod while the others wait as passive pods
+	FailoverModeActivePassive FailoverMode = "ActivePassive"
+)
+
+// FailoverSpec defines how traffic fails over between a team member's pods
+type FailoverSpec struct {
+	// Mode is the failover mode; replicas counts the active pod along with the passive pods
+	// +optional
+	// +kubebuilder:default=ActivePassive
+	Mode FailoverMode `json:"mode,omitempty"`
+}
+
+// LeaderElectionSpec defines the Service through which clients reach a member's leader
+type LeaderElectionSpec struct {
+	// ServiceType is the type of the Service selecting the leader pod
+	// +optional
+	// +kubebuilder:default=ClusterIP
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
 }
 
 // VirtSquadSpec defines the desired state of VirtSquad
+// +kubebuilder:validation:XValidation:rule="has(self.templateRef) || has(self.backup) || has(self.oksana) || has(self.kurtis) || has(self.matt) || has(self.kike)",message="at least one team member must be defined, unless they come from a template or a backup"
+// +kubebuilder:validation:XValidation:rule="!has(self.isolation) || self.isolation != 'strict' || has(self.dedicatedNodes)",message="strict isolation requires dedicatedNodes"
 type VirtSquadSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 	// The following markers will use OpenAPI v3 schema to validate the value
 	// More info: https://book.kubebuilder.io/reference/markers/crd-validation.html
 
+	// TemplateRef names a VirtSquadTemplate in the squad's namespace whose team members the
+	// squad starts from, so a squad with a template need not set any members itself. Members and
+	// fields set in this spec take precedence over the template's.
+	// A squad that is invalid with the template's members reports InvalidTemplate and is not
+	// reconciled.
+	// +optional
+	TemplateRef *TemplateReference `json:"templateRef,omitempty"`
+
 	// Oksana defines configuration for Oksana's pods
 	// +optional
 	Oksana *TeamMemberSpec `json:"oksana,omitempty"`
@@ -57,6 +501,858 @@ type VirtSquadSpec struct {
 	// Kike defines configuration for Kike's pods
 	// +optional
 	Kike *TeamMemberSpec `json:"kike,omitempty"`
+
+	// TerminatedPodRetention is the number of Succeeded or Failed pods kept per team member
+	// for debugging; older terminated pods are deleted once replacements have been created
+	// +optional
+	// +kubebuilder:default=0
+	// +kubebuilder:validation:Minimum=0
+	TerminatedPodRetention *int32 `json:"terminatedPodRetention,omitempty"`
+
+	// FinalizeJob is a Job run when the VirtSquad is deleted, before its pods are removed,
+	// e.g. to drain queues or deregister the squad from external systems
+	// +optional
+	FinalizeJob *FinalizeJobSpec `json:"finalizeJob,omitempty"`
+
+	// Schedules scale team members at the times given by cron expressions, e.g. up for business
+	// hours and down overnight. A member runs the replicas of its schedule that fired last, or
+	// its own replicas when none of its schedules fired within the past year.
+	// +optional
+	// +kubebuilder:validation:MaxItems=20
+	Schedules []ScheduleSpec `json:"schedules,omitempty"`
+
+	// Rotation keeps a single team member on call: only the on-call member runs pods, and the
+	// operator hands over to the next member whenever the rotation schedule fires
+	// +optional
+	Rotation *RotationSpec `json:"rotation,omitempty"`
+
+	// Hibernated scales every team member to zero. The replicas each member ran before are kept
+	// in status; when hibernation is turned off again, members resume at the replicas they ask
+	// for at that point, within the replica ceilings and the squad's pod budget.
+	// +optional
+	Hibernated *bool `json:"hibernated,omitempty"`
+
+	// MaxTotalPods is the most pods the squad's team members may run together, standby pods
+	// included. Members beyond the budget are scaled down to fit and the squad reports
+	// QuotaExceeded. The operator's own per-squad limit applies when it is lower or this is unset.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxTotalPods *int32 `json:"maxTotalPods,omitempty"`
+
+	// Hibernation scales every team member to zero during recurring windows, e.g. weekends,
+	// and back to their usual replicas afterward. It takes precedence over Schedules.
+	// +optional
+	Hibernation *HibernationSpec `json:"hibernation,omitempty"`
+
+	// Hooks configures HTTP callbacks invoked around pod lifecycle actions
+	// +optional
+	Hooks *HooksSpec `json:"hooks,omitempty"`
+
+	// Notifications configures where the operator reports notable squad events
+	// +optional
+	Notifications *NotificationsSpec `json:"notifications,omitempty"`
+
+	// TargetNamespaces are further namespaces the squad is replicated into. Each one runs a
+	// copy of this squad under the same name, which the operator keeps in sync with this spec
+	// and deletes along with this squad. Whoever creates or updates the squad must be allowed
+	// to create VirtSquads in each of them.
+	// +optional
+	// +kubebuilder:validation:MaxItems=100
+	// +listType=set
+	TargetNamespaces []string `json:"targetNamespaces,omitempty"`
+
+	// NamespaceSelector replicates the squad into every namespace whose labels match, in
+	// addition to TargetNamespaces. Whoever creates or updates the squad must be allowed to
+	// create VirtSquads in each namespace matching at that time.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Backup periodically exports the squad and the resources generated for it to object storage.
+	// A squad created with nothing but its backup and the RestoreFromAnnotation is recreated
+	// from the backup the annotation names.
+	// +optional
+	Backup *BackupSpec `json:"backup,omitempty"`
+
+	// Placement keeps the pods of different team members together or apart
+	// +optional
+	Placement *PlacementSpec `json:"placement,omitempty"`
+
+	// Chaos periodically deletes one of the squad's ready pods, so teams continuously verify
+	// that the squad heals itself
+	// +optional
+	Chaos *ChaosSpec `json:"chaos,omitempty"`
+
+	// DedicatedNodes claims a node pool for the squad's pods: the operator labels and taints the
+	// selected nodes and the pods tolerate the taint and run only on those nodes
+	// +optional
+	DedicatedNodes *DedicatedNodesSpec `json:"dedicatedNodes,omitempty"`
+
+	// Isolation set to strict isolates the squad from other tenants: a NetworkPolicy denies all
+	// traffic except between the squad's own pods and to DNS, the pods run under a dedicated
+	// ServiceAccount without a mounted token and with the restricted Pod Security settings, and
+	// they are kept to the squad's dedicated nodes. The members' images must run as a non-root user,
+	// so members must set one: the default image runs as root.
+	// +optional
+	// +kubebuilder:validation:Enum=shared;strict
+	Isolation IsolationMode `json:"isolation,omitempty"`
+
+	// Dashboard renders a Grafana dashboard showing the replicas and health of the squad's team
+	// members into a ConfigMap, for the Grafana sidecar to load
+	// +optional
+	Dashboard *DashboardSpec `json:"dashboard,omitempty"`
+
+	// NamespaceQuota generates a ResourceQuota sized from the CPU and memory the team members'
+	// pods request, plus headroom, and a LimitRange giving containers without requests a default.
+	// Every ResourceQuota of a namespace applies to all of its pods, so they are only generated
+	// while the squad has its namespace to itself; squads of a ClusterVirtSquad that provisions
+	// its namespace get them by default.
+	// +optional
+	NamespaceQuota *NamespaceQuotaSpec `json:"namespaceQuota,omitempty"`
+
+	// ConfirmChanges holds every spec change until it is approved. The operator publishes the
+	// pods each member would create, delete and replace in status.pendingChanges and only
+	// acts once the ApproveGenerationAnnotation is set to the squad's new generation.
+	// +optional
+	ConfirmChanges *bool `json:"confirmChanges,omitempty"`
+}
+
+// DashboardSpec configures the ConfigMap holding the squad's Grafana dashboard
+type DashboardSpec struct {
+	// Labels are set on the ConfigMap so the Grafana sidecar picks it up. Defaults to
+	// grafana_dashboard: "1", the label the sidecar watches by default.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Folder is the Grafana folder the dashboard is placed in, set as the grafana_folder
+	// annotation of the ConfigMap
+	// +optional
+	Folder string `json:"folder,omitempty"`
+}
+
+// NamespaceQuotaSpec configures the ResourceQuota and LimitRange generated for a squad
+type NamespaceQuotaSpec struct {
+	// HeadroomPercent is added on top of what the members' pods request, leaving room for
+	// Jobs, debug containers and other pods of the namespace
+	// +optional
+	// +kubebuilder:default=20
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000
+	HeadroomPercent *int32 `json:"headroomPercent,omitempty"`
+
+	// DefaultRequests are the CPU and memory requested by containers that set none, which the
+	// quota requires every pod to request. Defaults to 100m CPU and 128Mi memory.
+	// +optional
+	DefaultRequests corev1.ResourceList `json:"defaultRequests,omitempty"`
+
+	// DefaultLimits are the CPU and memory limits of containers that set none. No default limits
+	// are applied when unset.
+	// +optional
+	DefaultLimits corev1.ResourceList `json:"defaultLimits,omitempty"`
+}
+
+// BackupProvider names an object storage service
+// +kubebuilder:validation:Enum=S3;GCS
+type BackupProvider string
+
+const (
+	// BackupProviderS3 stores backups in Amazon S3 or another S3-compatible service
+	BackupProviderS3 BackupProvider = "S3"
+	// BackupProviderGCS stores backups in Google Cloud Storage through its XML API
+	BackupProviderGCS BackupProvider = "GCS"
+)
+
+// BackupSpec defines where and how often a squad is backed up. Each backup is a List manifest
+// holding the VirtSquad followed by its Services and pods.
+type BackupSpec struct {
+	// Provider is the object storage service backups are written to
+	// +optional
+	// +kubebuilder:default=S3
+	Provider BackupProvider `json:"provider,omitempty"`
+
+	// Bucket is the bucket backups are written to
+	// +kubebuilder:validation:MinLength=1
+	Bucket string `json:"bucket"`
+
+	// Endpoint overrides the provider's endpoint, e.g. for MinIO or a regional S3 endpoint
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Region is the region requests are signed for
+	// +optional
+	// +kubebuilder:default=us-east-1
+	Region string `json:"region,omitempty"`
+
+	// Prefix is prepended to backup keys, which default to <namespace>/<name>/<timestamp>.json
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// CredentialsSecretRef names a Secret in the squad's namespace holding the accessKeyID and
+	// secretAccessKey of the bucket; for GCS these are HMAC keys
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// Interval is how often the squad is backed up
+	// +optional
+	// +kubebuilder:default="1h"
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// IsolationMode selects how strongly a squad is isolated from other tenants
+type IsolationMode string
+
+const (
+	// IsolationShared leaves the squad's pods on the cluster's default network, identity and nodes
+	IsolationShared IsolationMode = "shared"
+	// IsolationStrict isolates the squad's network, identity, privileges and nodes
+	IsolationStrict IsolationMode = "strict"
+)
+
+// DedicatedNodesSpec selects the node pool claimed for a squad
+type DedicatedNodesSpec struct {
+	// NodeSelector selects the nodes to claim among those an administrator labeled
+	// virtsquad.mshort55.io/dedicatable=true, and only if the operator allows dedicated nodes.
+	// Nodes already claimed by another squad are skipped, and nodes no longer selected are released. The NoSchedule taint keeps new pods of other
+	// workloads off the nodes but does not evict the ones already running there.
+	// +kubebuilder:validation:MinProperties=1
+	NodeSelector map[string]string `json:"nodeSelector"`
+}
+
+// PlacementSpec configures how the pods of different team members are placed relative to each other
+// +kubebuilder:validation:XValidation:rule="!(self.colocateMembers && self.spreadMembers)",message="colocateMembers and spreadMembers are mutually exclusive"
+type PlacementSpec struct {
+	// ColocateMembers prefers scheduling the squad's pods into the same topology domain, such
+	// as the same node, for low latency between members
+	// +optional
+	ColocateMembers *bool `json:"colocateMembers,omitempty"`
+
+	// SpreadMembers requires the pods of different team members to run in different topology
+	// domains, so that losing one domain takes down at most one member
+	// +optional
+	SpreadMembers *bool `json:"spreadMembers,omitempty"`
+
+	// AvoidSquads names other VirtSquads in the same namespace whose pods must not share a
+	// topology domain with this squad's pods, for redundant squad pairs
+	// +optional
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=set
+	AvoidSquads []string `json:"avoidSquads,omitempty"`
+
+	// TopologyKey is the node label defining the topology domains, such as
+	// topology.kubernetes.io/zone to place by zone
+	// +optional
+	// +kubebuilder:default="kubernetes.io/hostname"
+	TopologyKey string `json:"topologyKey,omitempty"`
+}
+
+// ChaosSpec configures the random deletion of squad pods for resilience testing
+type ChaosSpec struct {
+	// Interval is how often a pod is deleted
+	// +optional
+	// +kubebuilder:default="1h"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1m')",message="interval must be at least 1m"
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// MaxDisruptions is the most of the squad's desired pods that may be unavailable when chaos
+	// strikes, its own deletion included. No pod is deleted while more are already missing or
+	// not ready.
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	MaxDisruptions *int32 `json:"maxDisruptions,omitempty"`
+}
+
+// TemplateReference names a VirtSquadTemplate
+type TemplateReference struct {
+	// Name is the name of the VirtSquadTemplate
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// NotificationsSpec defines the sinks told when a squad becomes degraded, completes a rollout
+// or is deleted
+type NotificationsSpec struct {
+	// Slack posts notifications to a Slack incoming webhook. When unset, the operator's
+	// default Slack webhook is used, if one is configured.
+	// +optional
+	Slack *SlackNotificationSpec `json:"slack,omitempty"`
+
+	// Webhook posts a JSON payload to an HTTP endpoint whenever one of the squad's conditions
+	// changes status
+	// +optional
+	Webhook *WebhookNotificationSpec `json:"webhook,omitempty"`
+
+	// Alerting opens an incident when the squad stays degraded and resolves it once the squad
+	// recovers
+	// +optional
+	Alerting *AlertingSpec `json:"alerting,omitempty"`
+}
+
+// AlertProvider names an incident management service
+// +kubebuilder:validation:Enum=PagerDuty;Opsgenie
+type AlertProvider string
+
+const (
+	// AlertProviderPagerDuty opens incidents through the PagerDuty Events API v2
+	AlertProviderPagerDuty AlertProvider = "PagerDuty"
+	// AlertProviderOpsgenie opens alerts through the Opsgenie Alert API
+	AlertProviderOpsgenie AlertProvider = "Opsgenie"
+)
+
+// AlertingSpec defines the incident management service alerted about degraded squads
+type AlertingSpec struct {
+	// Provider is the incident management service to alert
+	Provider AlertProvider `json:"provider"`
+
+	// CredentialSecretRef selects a key of a Secret in the squad's namespace holding the
+	// PagerDuty integration key or the Opsgenie API key
+	CredentialSecretRef corev1.SecretKeySelector `json:"credentialSecretRef"`
+
+	// DegradedFor is how long the squad must stay degraded before an incident is opened
+	// +optional
+	// +kubebuilder:default="5m"
+	DegradedFor *metav1.Duration `json:"degradedFor,omitempty"`
+}
+
+// WebhookNotificationSpec defines an HTTP endpoint condition transitions are posted to
+type WebhookNotificationSpec struct {
+	// URL is the endpoint the payload is posted to
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// HeadersSecretRef names a Secret in the squad's namespace whose keys and values are sent
+	// as HTTP headers, e.g. Authorization
+	// +optional
+	HeadersSecretRef *corev1.LocalObjectReference `json:"headersSecretRef,omitempty"`
+
+	// Conditions limits the payloads to transitions of these condition types, such as Ready or
+	// Degraded. All squad conditions are reported when empty.
+	// +optional
+	Conditions []string `json:"conditions,omitempty"`
+}
+
+// SlackNotificationSpec defines a Slack incoming webhook notifications are posted to
+type SlackNotificationSpec struct {
+	// WebhookURLSecretRef selects a key of a Secret in the squad's namespace holding the
+	// incoming webhook URL
+	WebhookURLSecretRef corev1.SecretKeySelector `json:"webhookURLSecretRef"`
+}
+
+// ScheduleSpec defines a scheduled replica count for a team member
+type ScheduleSpec struct {
+	// Cron is a standard five-field cron expression, or a descriptor such as @daily, evaluated in
+	// UTC unless prefixed with CRON_TZ=<zone>
+	// +kubebuilder:validation:MinLength=1
+	Cron string `json:"cron"`
+
+	// Member is the team member scaled by the schedule
+	// +kubebuilder:validation:Enum=oksana;kurtis;matt;kike
+	Member string `json:"member"`
+
+	// Replicas is the number of pods the member runs once the schedule fires
+	// +kubebuilder:validation:Minimum=0
+	Replicas int32 `json:"replicas"`
+}
+
+// RotationSpec defines an on-call rotation among team members
+type RotationSpec struct {
+	// Schedule is the five-field cron expression, optionally prefixed with CRON_TZ=<zone>, at
+	// which the next member takes over
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// Members are the team members taking turns, in rotation order. They must be configured in
+	// the squad or its template. Members not listed are not affected by the rotation.
+	// +listType=set
+	// +kubebuilder:validation:MinItems=2
+	// +kubebuilder:validation:items:Enum=oksana;kurtis;matt;kike
+	Members []string `json:"members"`
+}
+
+// HibernationSpec defines the recurring windows during which a squad runs no pods
+type HibernationSpec struct {
+	// TimeZone is the IANA time zone, such as Europe/Berlin, the windows are evaluated in
+	// +optional
+	// +kubebuilder:default=UTC
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Windows are the recurring hibernation windows
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	Windows []HibernationWindow `json:"windows"`
+}
+
+// HibernationWindow defines a recurring window by the cron expressions that open and close it,
+// e.g. "0 20 * * 5" to "0 6 * * 1" for weekends
+type HibernationWindow struct {
+	// Start is the five-field cron expression at which the window opens
+	// +kubebuilder:validation:MinLength=1
+	Start string `json:"start"`
+
+	// End is the five-field cron expression at which the window closes
+	// +kubebuilder:validation:MinLength=1
+	End string `json:"end"`
+}
+
+// HooksSpec defines the HTTP callbacks invoked by the operator
+type HooksSpec struct {
+	// PreDelete is called with the pod's metadata before the operator deletes a pod, so external
+	// load balancers and inventories can deregister it
+	// +optional
+	PreDelete *HTTPHookSpec `json:"preDelete,omitempty"`
+}
+
+// HookFailurePolicy describes what happens when a hook call fails
+// +kubebuilder:validation:Enum=Ignore;Fail
+type HookFailurePolicy string
+
+const (
+	// HookFailurePolicyIgnore proceeds with the action when the hook fails
+	HookFailurePolicyIgnore HookFailurePolicy = "Ignore"
+	// HookFailurePolicyFail postpones the action until the hook succeeds
+	HookFailurePolicyFail HookFailurePolicy = "Fail"
+)
+
+// HTTPHookSpec defines an HTTP endpoint the operator posts JSON payloads to
+type HTTPHookSpec struct {
+	// URL is the endpoint the payload is posted to
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// AuthSecretRef selects a key of a Secret in the squad's namespace holding a bearer token
+	// +optional
+	AuthSecretRef *corev1.SecretKeySelector `json:"authSecretRef,omitempty"`
+
+	// TimeoutSeconds bounds how long the operator waits for the endpoint to answer
+	// +optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// FailurePolicy decides whether the pod is deleted anyway when the hook fails
+	// +optional
+	// +kubebuilder:default=Ignore
+	FailurePolicy HookFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// FinalizeJobFailurePolicy describes what happens when the finalize Job fails or times out
+// +kubebuilder:validation:Enum=Ignore;Fail
+type FinalizeJobFailurePolicy string
+
+const (
+	// FinalizeJobFailurePolicyIgnore continues deleting the squad when the finalize Job fails
+	FinalizeJobFailurePolicyIgnore FinalizeJobFailurePolicy = "Ignore"
+	// FinalizeJobFailurePolicyFail blocks deletion of the squad until the finalize Job succeeds
+	FinalizeJobFailurePolicyFail FinalizeJobFailurePolicy = "Fail"
+)
+
+// FinalizeJobSpec defines the teardown Job run during finalization
+type FinalizeJobSpec struct {
+	// Template is the Job to run. It is stored schemaless to keep the CRD within the API server's size limits.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Template batchv1.JobTemplateSpec `json:"template"`
+
+	// TimeoutSeconds bounds how long finalization waits for the Job to complete
+	// +optional
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// FailurePolicy decides whether deletion continues when the Job fails or times out
+	// +optional
+	// +kubebuilder:default=Ignore
+	FailurePolicy FinalizeJobFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// Condition types reported on VirtSquad and member status.
+const (
+	// ConditionDegraded indicates that pods are failing to start or keep running
+	ConditionDegraded = "Degraded"
+
+	// ConditionFailed indicates that a team member exhausted its failure policy and is no longer recreated
+	ConditionFailed = "Failed"
+
+	// ConditionProgressing indicates whether a team member's pods are being replaced with a new spec
+	ConditionProgressing = "Progressing"
+
+	// ConditionPreStartJobSucceeded indicates whether the team member's pre-start Job has completed
+	ConditionPreStartJobSucceeded = "PreStartJobSucceeded"
+
+	// ConditionPaused indicates that the operator is paused and makes no changes to the squad
+	ConditionPaused = "Paused"
+
+	// ConditionReconcileBlocked indicates that reconciles keep failing and the squad is backed off
+	ConditionReconcileBlocked = "ReconcileBlocked"
+
+	// ConditionReady indicates that every desired pod is running the current spec and is ready
+	ConditionReady = "Ready"
+
+	// ConditionReconciling indicates that the controller is still working toward the desired state,
+	// following the kstatus convention used by Flux and Argo CD health checks
+	ConditionReconciling = "Reconciling"
+
+	// ConditionStalled indicates that the controller cannot make progress without user intervention,
+	// following the kstatus convention used by Flux and Argo CD health checks
+	ConditionStalled = "Stalled"
+
+	// ConditionHibernated indicates that all team members are scaled to zero for hibernation
+	ConditionHibernated = "Hibernated"
+
+	// ConditionQuotaExceeded indicates that team members request more pods than the squad's pod
+	// budget or the operator's per-member replica ceiling allows
+	ConditionQuotaExceeded = "QuotaExceeded"
+
+	// ConditionInvalidSchedule indicates that some of the squad's schedules, its rotation schedule
+	// or hibernation windows cannot be parsed and are ignored
+	ConditionInvalidSchedule = "InvalidSchedule"
+
+	// ConditionInvalidTemplate indicates that the squad's spec is invalid once the team members
+	// of its VirtSquadTemplate are merged in, and the squad is not reconciled
+	ConditionInvalidTemplate = "InvalidTemplate"
+
+	// ConditionDependenciesReady indicates whether the team members a member depends on are ready
+	ConditionDependenciesReady = "DependenciesReady"
+
+	// ConditionArchitectureSupported indicates whether a team member's image is built for the
+	// member's architecture
+	ConditionArchitectureSupported = "ArchitectureSupported"
+
+	// ConditionChangesPending indicates that a spec change is held until it is approved
+	ConditionChangesPending = "ChangesPending"
+
+	// ConditionConflict indicates that the squad's pods collide with those of another squad in
+	// the namespace; only the squad created first is reconciled
+	ConditionConflict = "Conflict"
+
+	// ConditionNamespaceQuota indicates whether the ResourceQuota and LimitRange requested by
+	// namespaceQuota are in place
+	ConditionNamespaceQuota = "NamespaceQuota"
+
+	// ConditionRestoreFailed indicates that the squad could not be restored from the backup the
+	// RestoreFromAnnotation named, and the annotation was removed
+	ConditionRestoreFailed = "RestoreFailed"
+)
+
+// ResetFailuresAnnotation, when set on a VirtSquad, clears the recreate attempts of all
+// members so that members marked Failed are recreated again
+const ResetFailuresAnnotation = "virtsquad.mshort55.io/reset-failures"
+
+// QuarantineAnnotation, when set to "true" on a member pod, detaches the pod from its
+// team member so it keeps running for debugging while a replacement is created
+const QuarantineAnnotation = "virtsquad.mshort55.io/quarantine"
+
+// SmokeTestAnnotation is set on member pods to SmokeTestPassed once their member's smoke test passed
+const SmokeTestAnnotation = "virtsquad.mshort55.io/smoke-test"
+
+// SmokeTestPassed is the SmokeTestAnnotation value of pods that passed their smoke test
+const SmokeTestPassed = "Passed"
+
+// ShardLabel, when set on a VirtSquad to a shard number, pins the squad to the operator replica
+// running that shard in sharded mode instead of assigning it by a hash of its name
+const ShardLabel = "virtsquad.mshort55.io/shard"
+
+// ReplicaOfLabel is set on the copies of a squad replicated into other namespaces to the
+// namespace of the squad they were copied from, which has the same name
+const ReplicaOfLabel = "virtsquad.mshort55.io/replica-of"
+
+// ImportAnnotation, when set on a VirtSquad to a comma-separated list of member=Kind/name
+// entries such as "oksana=Deployment/web,kurtis=Pod/worker", makes the operator take over
+// the listed Deployments and unmanaged pods as team members. A member that is not configured
+// yet is generated from the workload's pod template; once the member's pods are ready, the
+// Deployment is scaled to zero or the pod deleted, and the entry is removed. Entries naming a
+// member that is already configured are dropped without touching the workload, and workloads
+// with sidecars, volumes, probes or ports other than the member port are refused.
+const ImportAnnotation = "virtsquad.mshort55.io/import"
+
+// ImportGeneratedAnnotation is set by the operator to the comma-separated team members it
+// generated from the ImportAnnotation, so only their workloads are retired
+const ImportGeneratedAnnotation = "virtsquad.mshort55.io/import-generated"
+
+// ImportedByAnnotation is set on the Deployments taken over through the ImportAnnotation to
+// the name of the importing squad
+const ImportedByAnnotation = "virtsquad.mshort55.io/imported-by"
+
+// RestoreFromAnnotation, when set on a VirtSquad with a backup configured to the key of one of
+// its backups, replaces the squad's spec with the backed up spec, keeping the squad's backup
+// settings. Setting it on a new squad that only configures its backup recreates a deleted squad.
+// The annotation is removed once the squad has been restored, or once the backup turns out not
+// to exist or not to hold a squad, in which case the RestoreFailed condition is reported.
+const RestoreFromAnnotation = "virtsquad.mshort55.io/restore-from"
+
+// ScaleRequestAnnotation, when set on a VirtSquad to a JSON-encoded ScaleRequest, asks the
+// operator to change the replicas of one team member. The operator applies the request to the
+// spec, records it in status and removes the annotation. It is set by the status API's scale
+// endpoint so that external systems can scale members without access to the Kubernetes API.
+const ScaleRequestAnnotation = "virtsquad.mshort55.io/scale-request"
+
+// ApproveGenerationAnnotation, when set on a VirtSquad that confirms changes to the squad's
+// current metadata.generation, approves the pending spec change so the operator applies it.
+// A value naming an older generation approves nothing.
+const ApproveGenerationAnnotation = "virtsquad.mshort55.io/approve-generation"
+
+// ResyncIntervalAnnotation, when set on a VirtSquad to a duration such as "5m", makes the
+// controller reconcile the squad at least that often to correct drift
+const ResyncIntervalAnnotation = "virtsquad.mshort55.io/resync-interval"
+
+// ForceRenameAnnotation, when set to "true" on a VirtSquad, lets an update change the pod name
+// of an existing team member. Pods created under the previous name are replaced by pods under
+// the new name like outdated pods, following the member's rollout strategy.
+const ForceRenameAnnotation = "virtsquad.mshort55.io/force-rename"
+
+// ReconcileIDAnnotation is set by the controller on the events and pods it creates to the ID of
+// the reconcile that created them, which matches the reconcileID field of the controller's logs
+const ReconcileIDAnnotation = "virtsquad.mshort55.io/reconcile-id"
+
+// Annotations used to request an ephemeral debug container in one of the squad's pods
+const (
+	// DebugPodAnnotation names the squad pod the debug container is injected into
+	DebugPodAnnotation = "virtsquad.mshort55.io/debug-pod"
+	// DebugImageAnnotation optionally overrides the operator's default debug image
+	DebugImageAnnotation = "virtsquad.mshort55.io/debug-image"
+)
+
+// MemberStatus defines the observed state of a single team member
+type MemberStatus struct {
+	// Name is the team member this status belongs to (e.g. oksana)
+	Name string `json:"name"`
+
+	// FirstReadyTime is when all of the member's desired pods were first ready
+	// +optional
+	FirstReadyTime *metav1.Time `json:"firstReadyTime,omitempty"`
+
+	// RecreateAttempts counts how often failing pods were recreated under the failure policy
+	// +optional
+	RecreateAttempts int32 `json:"recreateAttempts,omitempty"`
+
+	// LastRecreateTime is when failing pods were last recreated
+	// +optional
+	LastRecreateTime *metav1.Time `json:"lastRecreateTime,omitempty"`
+
+	// ObservedGeneration is the VirtSquad generation the recreate attempts were counted against
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// CurrentRevision is the template hash of the pods from before the latest rollout
+	// +optional
+	CurrentRevision string `json:"currentRevision,omitempty"`
+
+	// UpdateRevision is the template hash the member's pods are being updated to, rendered from
+	// the current spec or restored from the rollbackTo revision
+	// +optional
+	UpdateRevision string `json:"updateRevision,omitempty"`
+
+	// CurrentRevisionNumber is the number of the ControllerRevision holding CurrentRevision
+	// +optional
+	CurrentRevisionNumber int64 `json:"currentRevisionNumber,omitempty"`
+
+	// UpdateRevisionNumber is the number of the ControllerRevision holding UpdateRevision
+	// +optional
+	UpdateRevisionNumber int64 `json:"updateRevisionNumber,omitempty"`
+
+	// UpdatedReplicas is the number of pods running the update revision
+	// +optional
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+
+	// RolloutStartTime is when the member's rollout to the update revision began
+	// +optional
+	RolloutStartTime *metav1.Time `json:"rolloutStartTime,omitempty"`
+
+	// CanaryStep is the index of the current canary step
+	// +optional
+	CanaryStep int32 `json:"canaryStep,omitempty"`
+
+	// RolloutStepStartTime is when the current rollout step started
+	// +optional
+	RolloutStepStartTime *metav1.Time `json:"rolloutStepStartTime,omitempty"`
+
+	// ReplicasBeforeHibernation is the number of pods the member ran when the squad was
+	// hibernated. It is cleared after resuming once the member runs that many pods again, or
+	// as many as it now asks for when its desired replicas were lowered meanwhile.
+	// +optional
+	ReplicasBeforeHibernation *int32 `json:"replicasBeforeHibernation,omitempty"`
+
+	// ActivePod is the pod selected by the member's Service in active/passive mode
+	// +optional
+	ActivePod string `json:"activePod,omitempty"`
+
+	// LastFailoverTime is when a passive pod was last promoted after the active pod failed
+	// +optional
+	LastFailoverTime *metav1.Time `json:"lastFailoverTime,omitempty"`
+
+	// Leader is the pod currently elected as the member's leader
+	// +optional
+	Leader string `json:"leader,omitempty"`
+
+	// Restarts is the number of times the containers of the member's current pods restarted
+	// +optional
+	Restarts int32 `json:"restarts,omitempty"`
+
+	// OOMKilledContainers is the number of the member's containers whose last termination was
+	// an OOMKill, a sign that the member needs more memory
+	// +optional
+	OOMKilledContainers int32 `json:"oomKilledContainers,omitempty"`
+
+	// LastTerminationReason is why a container of the member's pods last terminated, such as
+	// OOMKilled or Error
+	// +optional
+	LastTerminationReason string `json:"lastTerminationReason,omitempty"`
+
+	// LastTerminationTime is when a container of the member's pods last terminated
+	// +optional
+	LastTerminationTime *metav1.Time `json:"lastTerminationTime,omitempty"`
+
+	// OOMKills counts the OOMKills of the member's containers since its memory was last adjusted
+	// +optional
+	OOMKills int32 `json:"oomKills,omitempty"`
+
+	// LastOOMKillTime is when a container of the member was last OOMKilled
+	// +optional
+	LastOOMKillTime *metav1.Time `json:"lastOOMKillTime,omitempty"`
+
+	// MemoryAdjustment is the memory the OOM policy added to the member's request and limit
+	// +optional
+	MemoryAdjustment *resource.Quantity `json:"memoryAdjustment,omitempty"`
+
+	// LastMemoryAdjustmentTime is when the OOM policy last raised the member's memory
+	// +optional
+	LastMemoryAdjustmentTime *metav1.Time `json:"lastMemoryAdjustmentTime,omitempty"`
+
+	// SpotInterruptions counts the member's spot pods that were interrupted by their node
+	// +optional
+	SpotInterruptions int32 `json:"spotInterruptions,omitempty"`
+
+	// LastSpotInterruptionTime is when a spot pod of the member was last interrupted
+	// +optional
+	LastSpotInterruptionTime *metav1.Time `json:"lastSpotInterruptionTime,omitempty"`
+
+	// Usage is the CPU and memory currently used by the member's pods, as reported by the
+	// metrics API
+	// +optional
+	Usage corev1.ResourceList `json:"usage,omitempty"`
+
+	// PodOverhead is the overhead the member's RuntimeClass adds to each of its pods on top of
+	// the containers' requests
+	// +optional
+	PodOverhead corev1.ResourceList `json:"podOverhead,omitempty"`
+
+	// EstimatedMonthlyCost is what the member's desired pods cost per month according to the
+	// operator's price table, such as "42.50 USD"
+	// +optional
+	EstimatedMonthlyCost string `json:"estimatedMonthlyCost,omitempty"`
+
+	// Conditions represent the latest available observations of the team member's state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ScaleRequest asks for a team member to run a number of replicas
+type ScaleRequest struct {
+	// Member is the team member to scale (e.g. oksana)
+	Member string `json:"member"`
+
+	// Replicas is the number of pods the member should run
+	Replicas int32 `json:"replicas"`
+
+	// Requester identifies the system or person that asked for the change, such as a CI job
+	// +optional
+	Requester string `json:"requester,omitempty"`
+
+	// Reason explains why the change was requested
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// ScaleRequestResult is the outcome of a scale request
+type ScaleRequestResult string
+
+const (
+	// ScaleRequestApplied means the member's replicas were changed as requested
+	ScaleRequestApplied ScaleRequestResult = "Applied"
+	// ScaleRequestRejected means the request could not be applied
+	ScaleRequestRejected ScaleRequestResult = "Rejected"
+)
+
+// ScaleRequestStatus records a scale request handled by the operator
+type ScaleRequestStatus struct {
+	ScaleRequest `json:",inline"`
+
+	// PreviousReplicas is the number of replicas the member was configured with before the request
+	// +optional
+	PreviousReplicas *int32 `json:"previousReplicas,omitempty"`
+
+	// Time is when the operator handled the request
+	Time metav1.Time `json:"time"`
+
+	// Result is whether the request was applied or rejected
+	Result ScaleRequestResult `json:"result"`
+
+	// Message explains why a request was rejected
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// AvailabilityBucket accumulates how many pods were ready and desired during a slice of the
+// availability window
+type AvailabilityBucket struct {
+	// StartTime is when the slice of the window began
+	StartTime metav1.Time `json:"startTime"`
+
+	// ReadyPodSeconds is the sum over the slice of the seconds each desired pod was ready
+	ReadyPodSeconds int64 `json:"readyPodSeconds"`
+
+	// DesiredPodSeconds is the sum over the slice of the seconds each pod was desired
+	DesiredPodSeconds int64 `json:"desiredPodSeconds"`
+}
+
+// MemberChangePreview summarizes what a pending spec change does to a team member's pods
+type MemberChangePreview struct {
+	// Name is the team member the preview belongs to (e.g. oksana)
+	Name string `json:"name"`
+
+	// Create is the number of pods that would be created
+	// +optional
+	Create int32 `json:"create"`
+
+	// Delete is the number of pods that would be deleted
+	// +optional
+	Delete int32 `json:"delete"`
+
+	// Replace is the number of pods that would be replaced to run the new spec
+	// +optional
+	Replace int32 `json:"replace"`
+}
+
+// PendingChanges previews a spec change held until it is approved
+type PendingChanges struct {
+	// Generation is the squad generation awaiting approval
+	Generation int64 `json:"generation"`
+
+	// Members previews the change for each team member whose pods it affects
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Members []MemberChangePreview `json:"members,omitempty"`
+}
+
+// ReplicaNamespaceStatus reports the copy of a squad replicated into one namespace
+type ReplicaNamespaceStatus struct {
+	// Namespace is the namespace the squad is replicated into
+	Namespace string `json:"namespace"`
+
+	// ReadyPods is the number of ready pods of the copy
+	// +optional
+	ReadyPods int32 `json:"readyPods"`
+
+	// DesiredPods is the number of pods the copy's team members want to run
+	// +optional
+	DesiredPods int32 `json:"desiredPods"`
+
+	// Ready reports whether the copy's Ready condition is true
+	// +optional
+	Ready bool `json:"ready"`
 }
 
 // VirtSquadStatus defines the observed state of VirtSquad.
@@ -80,17 +1376,148 @@ type VirtSquadStatus struct {
 	// +optional
 	KikePods []string `json:"kikePods,omitempty"`
 
+	// NextScheduledChange is when the next of the squad's schedules fires, its rotation hands
+	// over or a hibernation window opens or closes
+	// +optional
+	NextScheduledChange *metav1.Time `json:"nextScheduledChange,omitempty"`
+
+	// OnCallMember is the team member currently on call in the squad's rotation
+	// +optional
+	OnCallMember string `json:"onCallMember,omitempty"`
+
+	// LastRotationTime is when the on-call member last took over
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+
+	// LastUsageTime is when the members' resource usage was last read from the metrics API
+	// +optional
+	LastUsageTime *metav1.Time `json:"lastUsageTime,omitempty"`
+
+	// EstimatedMonthlyCost is the sum of the members' estimated monthly costs
+	// +optional
+	EstimatedMonthlyCost string `json:"estimatedMonthlyCost,omitempty"`
+
+	// LastBackupTime is when the squad was last backed up successfully
+	// +optional
+	LastBackupTime *metav1.Time `json:"lastBackupTime,omitempty"`
+
+	// LastBackupKey is the object key of the squad's latest backup
+	// +optional
+	LastBackupKey string `json:"lastBackupKey,omitempty"`
+
+	// LastChaosTime is when chaos last deleted one of the squad's pods
+	// +optional
+	LastChaosTime *metav1.Time `json:"lastChaosTime,omitempty"`
+
+	// LastChaosPod is the pod chaos deleted last
+	// +optional
+	LastChaosPod string `json:"lastChaosPod,omitempty"`
+
+	// DedicatedNodes lists the nodes claimed for the squad's pods
+	// +optional
+	DedicatedNodes []string `json:"dedicatedNodes,omitempty"`
+
+	// ReplicaNamespaces reports the copy of the squad in each namespace it is replicated into
+	// +optional
+	// +listType=map
+	// +listMapKey=namespace
+	ReplicaNamespaces []ReplicaNamespaceStatus `json:"replicaNamespaces,omitempty"`
+
+	// ReadyReplicaNamespaces is the number of replica namespaces whose squad is Ready
+	// +optional
+	ReadyReplicaNamespaces int32 `json:"readyReplicaNamespaces,omitempty"`
+
+	// StartTime is when the operator first reconciled the squad
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Availability is the percentage of desired pod-seconds during which the pods were ready
+	// over the operator's availability window, such as "99.95%"
+	// +optional
+	Availability string `json:"availability,omitempty"`
+
+	// AvailabilityBuckets hold the ready and desired pod-seconds the availability is computed
+	// from, oldest first
+	// +optional
+	AvailabilityBuckets []AvailabilityBucket `json:"availabilityBuckets,omitempty"`
+
+	// LastAvailabilitySampleTime is when the pod-seconds were last added to the buckets
+	// +optional
+	LastAvailabilitySampleTime *metav1.Time `json:"lastAvailabilitySampleTime,omitempty"`
+
 	// ReadyPods tracks the total number of ready pods
 	// +optional
-	ReadyPods int32 `json:"readyPods,omitempty"`
+	ReadyPods int32 `json:"readyPods"`
 
 	// TotalPods tracks the total number of pods
 	// +optional
-	TotalPods int32 `json:"totalPods,omitempty"`
+	TotalPods int32 `json:"totalPods"`
+
+	// DesiredPods tracks the total number of pods the team members should run
+	// +optional
+	DesiredPods int32 `json:"desiredPods"`
+
+	// MemberCount tracks the number of configured team members
+	// +optional
+	MemberCount int32 `json:"memberCount"`
+
+	// ObservedGeneration is the most recent generation observed by the controller
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ReconcileFailures counts the consecutive reconciles of the squad that failed, as of when
+	// the squad was blocked
+	// +optional
+	ReconcileFailures int32 `json:"reconcileFailures,omitempty"`
+
+	// LastReconcileFailureTime is when the failed reconcile that blocked the squad happened
+	// +optional
+	LastReconcileFailureTime *metav1.Time `json:"lastReconcileFailureTime,omitempty"`
+
+	// AlertOpenedTime is when an incident was opened with the alerting provider for the squad
+	// staying degraded; it is cleared once the incident is resolved
+	// +optional
+	AlertOpenedTime *metav1.Time `json:"alertOpenedTime,omitempty"`
+
+	// QuarantinedPods tracks the names of pods detached from their team member for debugging
+	// +optional
+	QuarantinedPods []string `json:"quarantinedPods,omitempty"`
+
+	// DebugContainers lists the most recent ephemeral debug containers injected by the operator, as pod/container
+	// +optional
+	DebugContainers []string `json:"debugContainers,omitempty"`
+
+	// PendingChanges previews the spec change awaiting approval when the squad confirms changes
+	// +optional
+	PendingChanges *PendingChanges `json:"pendingChanges,omitempty"`
+
+	// ScaleRequests lists the most recent scale requests handled by the operator, oldest first
+	// +optional
+	ScaleRequests []ScaleRequestStatus `json:"scaleRequests,omitempty"`
+
+	// Members tracks the observed state of each configured team member
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Members []MemberStatus `json:"members,omitempty"`
+
+	// Conditions represent the latest available observations of the VirtSquad's state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName={vsq,squad},categories=all
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=`.status.readyPods`,description="Number of ready pods"
+// +kubebuilder:printcolumn:name="Total",type=integer,JSONPath=`.status.totalPods`,description="Number of pods"
+// +kubebuilder:printcolumn:name="Members",type=integer,JSONPath=`.status.memberCount`,description="Number of configured team members"
+// +kubebuilder:printcolumn:name="Availability",type=string,JSONPath=`.status.availability`,description="Share of desired pod-seconds the pods were ready over the availability window",priority=1
+// +kubebuilder:printcolumn:name="On-Call",type=string,JSONPath=`.status.onCallMember`,description="Team member on call in the rotation",priority=1
+// +kubebuilder:printcolumn:name="Cost",type=string,JSONPath=`.status.estimatedMonthlyCost`,description="Estimated monthly cost",priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // VirtSquad is the Schema for the virtsquads API
 type VirtSquad struct {
@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/mshort55/virtsquad-operator/test/crd"
+)
+
+var _ = Describe("VirtSquad schema", func() {
+	var schema *crd.Schema
+
+	BeforeEach(func() {
+		var err error
+		schema, err = crd.Load("virtsquads")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	// admit defaults the squad and returns the schema's validation errors, as creating it would
+	admit := func(spec VirtSquadSpec) []string {
+		virtSquad := &VirtSquad{
+			TypeMeta:   metav1.TypeMeta{APIVersion: GroupVersion.String(), Kind: "VirtSquad"},
+			ObjectMeta: metav1.ObjectMeta{Name: "squad", Namespace: "default"},
+			Spec:       spec,
+		}
+		Expect(schema.Default(virtSquad)).To(Succeed())
+		errs, err := schema.Validate(virtSquad)
+		Expect(err).NotTo(HaveOccurred())
+		var messages []string
+		for _, err := range errs {
+			messages = append(messages, err.Error())
+		}
+		return messages
+	}
+
+	It("should require a team member", func() {
+		Expect(admit(VirtSquadSpec{})).To(ConsistOf(ContainSubstring("at least one team member must be defined")))
+		Expect(admit(VirtSquadSpec{Oksana: &TeamMemberSpec{Name: ptr.To("oksana")}})).To(BeEmpty())
+	})
+
+	It("should admit a squad taking all its team members from a template", func() {
+		Expect(admit(VirtSquadSpec{TemplateRef: &TemplateReference{Name: "web"}})).To(BeEmpty())
+	})
+
+	It("should admit a squad to be recreated from a backup", func() {
+		Expect(admit(VirtSquadSpec{Backup: &BackupSpec{
+			Bucket:               "squads",
+			CredentialsSecretRef: corev1.LocalObjectReference{Name: "backup-credentials"},
+		}})).To(BeEmpty())
+	})
+
+	It("should require Windows team members to set a Windows image", func() {
+		Expect(admit(VirtSquadSpec{
+			Oksana: &TeamMemberSpec{Name: ptr.To("oksana"), OS: "windows"},
+		})).To(ConsistOf(ContainSubstring("image must be set to a Windows image")))
+		Expect(admit(VirtSquadSpec{
+			Oksana: &TeamMemberSpec{Name: ptr.To("oksana"), OS: "windows", Image: "nginx:latest"},
+		})).To(ConsistOf(ContainSubstring("image must be set to a Windows image")))
+
+		Expect(admit(VirtSquadSpec{
+			Oksana: &TeamMemberSpec{Name: ptr.To("oksana"), OS: "windows", Image: "mcr.microsoft.com/windows/nanoserver:ltsc2022"},
+		})).To(BeEmpty())
+		Expect(admit(VirtSquadSpec{Oksana: &TeamMemberSpec{Name: ptr.To("oksana")}})).To(BeEmpty())
+	})
+})
@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionApplied reports whether a VirtSquadMemberOverride is applied to its team member
+const ConditionApplied = "Applied"
+
+// VirtSquadMemberOverrideSpec defines the changes an override makes to one team member of a
+// VirtSquad in the same namespace
+// +kubebuilder:validation:XValidation:rule="has(self.replicas) || has(self.image)",message="at least one of replicas and image must be set"
+type VirtSquadMemberOverrideSpec struct {
+	// SquadName is the name of the VirtSquad whose member is overridden
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	SquadName string `json:"squadName"`
+
+	// Member is the team member that is overridden
+	// +required
+	// +kubebuilder:validation:Enum=oksana;kurtis;matt;kike
+	Member string `json:"member"`
+
+	// Replicas replaces the member's replicas. Schedules, rotation and hibernation still take
+	// precedence over it.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Image replaces the member's container image
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Image string `json:"image,omitempty"`
+}
+
+// VirtSquadMemberOverrideStatus defines the observed state of VirtSquadMemberOverride
+type VirtSquadMemberOverrideStatus struct {
+	// ObservedGeneration is the most recent generation observed by the controller
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the override's state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=vsqo
+// +kubebuilder:printcolumn:name="Squad",type=string,JSONPath=`.spec.squadName`,description="Overridden VirtSquad"
+// +kubebuilder:printcolumn:name="Member",type=string,JSONPath=`.spec.member`,description="Overridden team member"
+// +kubebuilder:printcolumn:name="Applied",type=string,JSONPath=`.status.conditions[?(@.type=="Applied")].status`,description="Whether the override is applied"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// VirtSquadMemberOverride is the Schema for the virtsquadmemberoverrides API. It lets the team
+// running a member adjust it without write access to the VirtSquad. When several overrides
+// target the same member, the oldest one applies.
+type VirtSquadMemberOverride struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the changes made to the team member
+	// +required
+	Spec VirtSquadMemberOverrideSpec `json:"spec"`
+
+	// status defines the observed state of VirtSquadMemberOverride
+	// +optional
+	Status VirtSquadMemberOverrideStatus `json:"status,omitempty,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// VirtSquadMemberOverrideList contains a list of VirtSquadMemberOverride
+type VirtSquadMemberOverrideList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtSquadMemberOverride `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VirtSquadMemberOverride{}, &VirtSquadMemberOverrideList{})
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VirtSquadQuotaSpec defines the limits enforced on the VirtSquads of a namespace. When several
+// quotas exist in a namespace, the strictest limit of each kind applies.
+type VirtSquadQuotaSpec struct {
+	// MaxSquads is the most VirtSquads the namespace may hold. Squads created beyond it while the
+	// webhook was bypassed run no pods.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxSquads *int32 `json:"maxSquads,omitempty"`
+
+	// MaxPods is the most pods the namespace's VirtSquads may run together, standby pods included
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxPods *int32 `json:"maxPods,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Max Squads",type=integer,JSONPath=`.spec.maxSquads`,description="Most VirtSquads the namespace may hold"
+// +kubebuilder:printcolumn:name="Max Pods",type=integer,JSONPath=`.spec.maxPods`,description="Most pods the namespace's VirtSquads may run"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// VirtSquadQuota is the Schema for the virtsquadquotas API
+type VirtSquadQuota struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the limits of the namespace's VirtSquads
+	// +required
+	Spec VirtSquadQuotaSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// VirtSquadQuotaList contains a list of VirtSquadQuota
+type VirtSquadQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtSquadQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VirtSquadQuota{}, &VirtSquadQuotaList{})
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VirtSquadTemplateSpec defines team member definitions shared by the VirtSquads referencing
+// the template
+type VirtSquadTemplateSpec struct {
+	// Oksana defines the template's configuration for Oksana's pods
+	// +optional
+	Oksana *TeamMemberSpec `json:"oksana,omitempty"`
+
+	// Kurtis defines the template's configuration for Kurtis's pods
+	// +optional
+	Kurtis *TeamMemberSpec `json:"kurtis,omitempty"`
+
+	// Matt defines the template's configuration for Matt's pods
+	// +optional
+	Matt *TeamMemberSpec `json:"matt,omitempty"`
+
+	// Kike defines the template's configuration for Kike's pods
+	// +optional
+	Kike *TeamMemberSpec `json:"kike,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=vsqt
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// VirtSquadTemplate is the Schema for the virtsquadtemplates API
+type VirtSquadTemplate struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the template's team members
+	// +required
+	Spec VirtSquadTemplateSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// VirtSquadTemplateList contains a list of VirtSquadTemplate
+type VirtSquadTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtSquadTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VirtSquadTemplate{}, &VirtSquadTemplateList{})
+}
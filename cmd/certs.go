@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mshort55/virtsquad-operator/internal/certs"
+)
+
+// serviceAccountNamespaceFile holds the namespace of the pod's service account
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// operatorNamespace returns the namespace the operator runs in, from the POD_NAMESPACE
+// environment variable or the pod's service account
+func operatorNamespace() string {
+	if namespace := os.Getenv("POD_NAMESPACE"); namespace != "" {
+		return namespace
+	}
+	data, err := os.ReadFile(serviceAccountNamespaceFile)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// setupCertRotator issues the webhook certificate into certDir, so the webhook server can start
+// with it, and returns the rotator that keeps it current afterwards
+func setupCertRotator(ctx context.Context, config *rest.Config, certDir, secretName, serviceName, webhookConfiguration string) (*certs.Rotator, error) {
+	c, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+	namespace := operatorNamespace()
+	rotator := &certs.Rotator{
+		Client:                c,
+		Secret:                types.NamespacedName{Namespace: namespace, Name: secretName},
+		Service:               types.NamespacedName{Namespace: namespace, Name: serviceName},
+		WebhookConfigurations: []string{webhookConfiguration},
+		CertDir:               certDir,
+	}
+
+	// Retry for a while so a replica starting during an API server hiccup does not crash-loop
+	var ensureErr error
+	for range 5 {
+		if ensureErr = rotator.Ensure(ctx); ensureErr == nil {
+			return rotator, nil
+		}
+		setupLog.Error(ensureErr, "Failed to issue webhook certificate, retrying")
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(2 * time.Second):
+		}
+	}
+	return nil, ensureErr
+}
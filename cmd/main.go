@@ -17,28 +17,50 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	// Embed the time zone database so hibernation windows work on images without one
+	_ "time/tzdata"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"golang.org/x/time/rate"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+	"github.com/mshort55/virtsquad-operator/internal/certs"
 	"github.com/mshort55/virtsquad-operator/internal/controller"
+	"github.com/mshort55/virtsquad-operator/internal/hooks"
+	"github.com/mshort55/virtsquad-operator/internal/notifications"
+	"github.com/mshort55/virtsquad-operator/internal/registry"
+	"github.com/mshort55/virtsquad-operator/internal/smoketest"
+	"github.com/mshort55/virtsquad-operator/internal/statusapi"
+	webhookappsv1 "github.com/mshort55/virtsquad-operator/internal/webhook/v1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -50,37 +72,169 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
+	utilruntime.Must(metricsv1beta1.AddToScheme(scheme))
+
 	utilruntime.Must(appsv1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
 // nolint:gocyclo
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "render" {
+		if err := runRender(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	var metricsAddr string
 	var metricsCertPath, metricsCertName, metricsCertKey string
 	var webhookCertPath, webhookCertName, webhookCertKey string
 	var enableLeaderElection bool
+	var leaseDuration, renewDeadline, retryPeriod time.Duration
+	var leaderElectionNamespace string
 	var probeAddr string
+	var pprofAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var debugImage string
+	var maxReplicasPerMember, maxPodsPerSquad int
+	var paused bool
+	var rateLimiterBaseDelay, rateLimiterMaxDelay time.Duration
+	var kubeAPIQPS float64
+	var kubeAPIBurst int
+	var shardCount, shardID int
+	var tracingEndpoint string
+	var tracingInsecure bool
+	var slackWebhookURL string
+	var inPlacePodResize bool
+	var usageInterval time.Duration
+	var sweepInterval time.Duration
+	var podListPageSize int64
+	var syncPeriod time.Duration
+	var syncPeriodJitter float64
+	var priorityQueue bool
+	var allowDedicatedNodes bool
+	var availabilityWindow time.Duration
+	var priceTable string
+	var statusAPIAddr, statusAPIToken, statusAPIScaleToken string
+	var statusAPICertPath, statusAPICertName, statusAPICertKey string
+	var statusAPINamespaces, statusAPIScaleNamespaces stringList
+	var webhookCertRotation bool
+	var webhookCertSecret, webhookServiceName, webhookConfigurationName string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.StringVar(&pprofAddr, "pprof-bind-address", "0", "The address the pprof endpoint binds to, "+
+		"for example :8082. Leave as 0 to disable profiling.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.DurationVar(&leaseDuration, "leader-elect-lease-duration", 15*time.Second,
+		"The duration non-leader candidates wait after observing a leadership renewal before trying to acquire leadership.")
+	flag.DurationVar(&renewDeadline, "leader-elect-renew-deadline", 10*time.Second,
+		"The duration the acting leader retries refreshing leadership before giving it up.")
+	flag.DurationVar(&retryPeriod, "leader-elect-retry-period", 2*time.Second,
+		"The duration leader election clients wait between attempts to acquire or renew leadership.")
+	flag.StringVar(&leaderElectionNamespace, "leader-elect-namespace", "",
+		"The namespace the leader election lease is created in. Defaults to the namespace the manager runs in.")
 	flag.BoolVar(&secureMetrics, "metrics-secure", true,
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.StringVar(&webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
 	flag.StringVar(&webhookCertName, "webhook-cert-name", "tls.crt", "The name of the webhook certificate file.")
 	flag.StringVar(&webhookCertKey, "webhook-cert-key", "tls.key", "The name of the webhook key file.")
+	flag.BoolVar(&webhookCertRotation, "webhook-cert-rotation", false,
+		"If set, the operator issues its own self-signed webhook certificate, keeps it in the webhook-cert-secret Secret, "+
+			"rotates it before it expires and injects its CA into the webhook configuration. "+
+			"The certificate is written to webhook-cert-path, which must be writable.")
+	flag.StringVar(&webhookCertSecret, "webhook-cert-secret", "virtsquad-operator-webhook-server-cert",
+		"The Secret in the operator's namespace the self-managed webhook certificate is kept in.")
+	flag.StringVar(&webhookServiceName, "webhook-service-name", "virtsquad-operator-webhook-service",
+		"The Service in the operator's namespace the self-managed webhook certificate is issued for.")
+	flag.StringVar(&webhookConfigurationName, "webhook-configuration-name", "virtsquad-operator-validating-webhook-configuration",
+		"The ValidatingWebhookConfiguration the CA of the self-managed webhook certificate is injected into.")
 	flag.StringVar(&metricsCertPath, "metrics-cert-path", "",
 		"The directory that contains the metrics server certificate.")
 	flag.StringVar(&metricsCertName, "metrics-cert-name", "tls.crt", "The name of the metrics server certificate file.")
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&debugImage, "debug-image", "busybox:latest",
+		"The default image for ephemeral debug containers requested via the debug-pod annotation.")
+	flag.IntVar(&maxReplicasPerMember, "max-replicas-per-member", 50,
+		"The highest replica count a team member may request. Set to 0 to disable the limit.")
+	flag.IntVar(&maxPodsPerSquad, "max-pods-per-squad", 100,
+		"The highest total pod count across a squad's team members. Set to 0 to disable the limit.")
+	flag.BoolVar(&paused, "paused", false,
+		"If set, the controller only refreshes squad status and makes no other changes, as an emergency brake.")
+	flag.DurationVar(&rateLimiterBaseDelay, "rate-limiter-base-delay", 5*time.Millisecond,
+		"The initial delay before a failed reconcile is retried; it doubles with every consecutive failure.")
+	flag.DurationVar(&rateLimiterMaxDelay, "rate-limiter-max-delay", 1000*time.Second,
+		"The longest delay between retries of a failed reconcile.")
+	flag.Float64Var(&kubeAPIQPS, "kube-api-qps", 20,
+		"The maximum queries per second from the manager to the Kubernetes API server.")
+	flag.IntVar(&kubeAPIBurst, "kube-api-burst", 30,
+		"The maximum burst of queries from the manager to the Kubernetes API server.")
+	flag.IntVar(&shardCount, "shard-count", 0,
+		"The number of shards VirtSquads are split across. Run one operator deployment per shard; 0 disables sharding.")
+	flag.IntVar(&shardID, "shard-id", 0,
+		"The shard this operator deployment is responsible for, from 0 to shard-count - 1.")
+	flag.StringVar(&tracingEndpoint, "tracing-endpoint", "",
+		"The OTLP/gRPC endpoint, such as otel-collector:4317, that reconcile traces are exported to. "+
+			"Leave empty to disable tracing.")
+	flag.BoolVar(&tracingInsecure, "tracing-insecure", false,
+		"If set, traces are exported to the tracing endpoint without TLS.")
+	flag.StringVar(&slackWebhookURL, "slack-webhook-url", os.Getenv("SLACK_WEBHOOK_URL"),
+		"The Slack incoming webhook notified about squads that configure no Slack webhook of their own. "+
+			"Defaults to the SLACK_WEBHOOK_URL environment variable, so it can be read from a Secret.")
+	flag.BoolVar(&inPlacePodResize, "in-place-pod-resize", false,
+		"If set, pods whose spec only changed in container resources are resized in place instead of recreated. "+
+			"Requires the InPlacePodVerticalScaling feature on the cluster.")
+	flag.DurationVar(&usageInterval, "usage-interval", time.Minute,
+		"How often team members' CPU and memory usage is read from the metrics API into squad status. "+
+			"Set to 0 to disable usage reporting.")
+	flag.DurationVar(&availabilityWindow, "availability-window", 24*time.Hour,
+		"The rolling window squad availability (ready pod-seconds over desired pod-seconds) is computed over. "+
+			"Set to 0 to disable availability tracking.")
+	flag.DurationVar(&sweepInterval, "orphan-sweep-interval", 10*time.Minute,
+		"How often pods left behind by deleted squads or removed team members, e.g. while the operator was down, "+
+			"are swept. Set to 0 to disable the sweep.")
+	flag.Int64Var(&podListPageSize, "pod-list-page-size", 0,
+		"If set, the orphan sweep lists squad pods from the API server in pages of this many pods instead of from "+
+			"the cache, bounding its memory in clusters with thousands of squad pods at the cost of more API requests.")
+	flag.DurationVar(&syncPeriod, "sync-period", 10*time.Hour,
+		"How often the cache replays every watched object to the controllers, so drift is corrected even without events.")
+	flag.Float64Var(&syncPeriodJitter, "sync-period-jitter", 0.1,
+		"The fraction of the sync period each controller spreads the resync of its objects over, so thousands of "+
+			"squads are not all reconciled at once. Set to 0 to resync all objects at the same time.")
+	flag.BoolVar(&allowDedicatedNodes, "allow-dedicated-nodes", false,
+		"If set, squads may claim dedicated nodes among those labeled virtsquad.mshort55.io/dedicatable=true, "+
+			"which the operator then taints so other workloads are kept off them.")
+	flag.BoolVar(&priorityQueue, "priority-queue", true,
+		"If set, newly created and deleted squads are reconciled ahead of other changes and of periodic resyncs, "+
+			"shortening the time to the first pods on a busy operator.")
+	flag.StringVar(&priceTable, "price-table", "",
+		"The namespace/name of a ConfigMap with monthly prices under the keys cpu (per core), memory (per GiB), "+
+			"pod and currency, used to estimate squad costs in status. Leave empty to disable cost estimation.")
+	flag.StringVar(&statusAPIAddr, "status-api-bind-address", "0",
+		"The address the squad status API binds to over HTTPS, such as :8090. Use \"0\" to disable it.")
+	flag.StringVar(&statusAPIToken, "status-api-token", os.Getenv("STATUS_API_TOKEN"),
+		"The bearer token clients of the status API must present. "+
+			"Defaults to the STATUS_API_TOKEN environment variable, so it can be read from a Secret.")
+	flag.Var(&statusAPINamespaces, "status-api-namespace",
+		"A namespace whose squads the status API token may read; may be repeated. "+
+			"Without it the token reads the squads of every namespace.")
+	flag.StringVar(&statusAPICertPath, "status-api-cert-path", "",
+		"The directory that contains the status API certificate, which the status API requires.")
+	flag.StringVar(&statusAPICertName, "status-api-cert-name", "tls.crt", "The name of the status API certificate file.")
+	flag.StringVar(&statusAPICertKey, "status-api-cert-key", "tls.key", "The name of the status API key file.")
+	flag.StringVar(&statusAPIScaleToken, "status-api-scale-token", os.Getenv("STATUS_API_SCALE_TOKEN"),
+		"The bearer token clients must present to request team member scale changes through the status API. "+
+			"Defaults to the STATUS_API_SCALE_TOKEN environment variable. Leave empty to disable scale requests.")
+	flag.Var(&statusAPIScaleNamespaces, "status-api-scale-namespace",
+		"A namespace whose squads the status API scale token may scale; may be repeated, and required with a scale token.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -89,6 +243,19 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	ctx := ctrl.SetupSignalHandler()
+
+	shutdownTracing := func(context.Context) error { return nil }
+	if tracingEndpoint != "" {
+		var err error
+		shutdownTracing, err = setupTracing(ctx, tracingEndpoint, tracingInsecure)
+		if err != nil {
+			setupLog.Error(err, "unable to set up tracing")
+			os.Exit(1)
+		}
+		setupLog.Info("Exporting traces", "endpoint", tracingEndpoint)
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -110,6 +277,24 @@ func main() {
 	// Initial webhook TLS options
 	webhookTLSOpts := tlsOpts
 
+	// The certificate has to be on disk before the watcher is created, so it is issued up front
+	var certRotator *certs.Rotator
+	if webhookCertRotation {
+		if len(webhookCertPath) == 0 {
+			webhookCertPath = filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs")
+		}
+		webhookCertName, webhookCertKey = "tls.crt", "tls.key"
+		setupLog.Info("Issuing self-managed webhook certificate", "webhook-cert-path", webhookCertPath, "secret", webhookCertSecret)
+
+		var err error
+		certRotator, err = setupCertRotator(ctx, ctrl.GetConfigOrDie(), webhookCertPath,
+			webhookCertSecret, webhookServiceName, webhookConfigurationName)
+		if err != nil {
+			setupLog.Error(err, "Failed to issue webhook certificate")
+			os.Exit(1)
+		}
+	}
+
 	if len(webhookCertPath) > 0 {
 		setupLog.Info("Initializing webhook certificate watcher using provided certificates",
 			"webhook-cert-path", webhookCertPath, "webhook-cert-name", webhookCertName, "webhook-cert-key", webhookCertKey)
@@ -178,13 +363,51 @@ func main() {
 		})
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme:                 scheme,
-		Metrics:                metricsServerOptions,
-		WebhookServer:          webhookServer,
-		HealthProbeBindAddress: probeAddr,
-		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "b98671e2.mshort55.io",
+	var priceTableName types.NamespacedName
+	if priceTable != "" {
+		namespace, name, ok := strings.Cut(priceTable, "/")
+		if !ok || namespace == "" || name == "" {
+			setupLog.Error(nil, "price-table must be given as namespace/name", "price-table", priceTable)
+			os.Exit(1)
+		}
+		priceTableName = types.NamespacedName{Namespace: namespace, Name: name}
+	}
+
+	// Each shard elects its own leader, so every shard has one active replica
+	leaderElectionID := "b98671e2.mshort55.io"
+	if shardCount > 1 {
+		if shardID < 0 || shardID >= shardCount {
+			setupLog.Error(nil, "shard-id must be between 0 and shard-count - 1", "shard-id", shardID, "shard-count", shardCount)
+			os.Exit(1)
+		}
+		leaderElectionID = fmt.Sprintf("shard-%d.%s", shardID, leaderElectionID)
+		setupLog.Info("Running in sharded mode", "shard-id", shardID, "shard-count", shardCount)
+	}
+
+	restConfig := ctrl.GetConfigOrDie()
+	restConfig.QPS = float32(kubeAPIQPS)
+	restConfig.Burst = kubeAPIBurst
+	if tracingEndpoint != "" {
+		// Trace API calls as children of the reconcile spans that issue them
+		restConfig.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+			return otelhttp.NewTransport(rt)
+		})
+	}
+
+	resyncJitter := time.Duration(float64(syncPeriod) * syncPeriodJitter)
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
+		Scheme:                  scheme,
+		Metrics:                 metricsServerOptions,
+		WebhookServer:           webhookServer,
+		HealthProbeBindAddress:  probeAddr,
+		PprofBindAddress:        pprofAddr,
+		LeaderElection:          enableLeaderElection,
+		LeaderElectionID:        leaderElectionID,
+		LeaderElectionNamespace: leaderElectionNamespace,
+		LeaseDuration:           &leaseDuration,
+		RenewDeadline:           &renewDeadline,
+		RetryPeriod:             &retryPeriod,
+		Cache:                   cache.Options{SyncPeriod: &syncPeriod},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -202,13 +425,81 @@ func main() {
 		os.Exit(1)
 	}
 
+	// The controller validates squads using templates as the webhook validates their own spec
+	validator := &webhookappsv1.VirtSquadCustomValidator{
+		MaxReplicasPerMember: int32(maxReplicasPerMember),
+		MaxPodsPerSquad:      int32(maxPodsPerSquad),
+		AllowDedicatedNodes:  allowDedicatedNodes,
+		Client:               mgr.GetClient(),
+		Reviewer:             mgr.GetClient(),
+	}
 	if err := (&controller.VirtSquadReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:               mgr.GetClient(),
+		Scheme:               mgr.GetScheme(),
+		Recorder:             mgr.GetEventRecorderFor("virtsquad-controller"),
+		DebugImage:           debugImage,
+		Hooks:                hooks.NewClient(),
+		Notifier:             notifications.NewClient(),
+		TemplateValidator:    validator,
+		SmokeTests:           smoketest.NewRunner(mgr.GetConfig()),
+		Registry:             registry.NewClient(),
+		SlackWebhookURL:      slackWebhookURL,
+		MaxReplicasPerMember: int32(maxReplicasPerMember),
+		MaxPodsPerSquad:      int32(maxPodsPerSquad),
+		Paused:               paused,
+		ShardCount:           shardCount,
+		ShardID:              shardID,
+		InPlacePodResize:     inPlacePodResize,
+		AllowDedicatedNodes:  allowDedicatedNodes,
+		// The metrics API cannot be watched, so pod metrics bypass the cache
+		MetricsReader:      mgr.GetAPIReader(),
+		UsageInterval:      usageInterval,
+		AvailabilityWindow: availabilityWindow,
+		SweepInterval:      sweepInterval,
+		ResyncJitter:       resyncJitter,
+		UsePriorityQueue:   priorityQueue,
+		APIReader:          mgr.GetAPIReader(),
+		PodListPageSize:    podListPageSize,
+		PriceTable:         priceTableName,
+		RateLimiter: workqueue.NewTypedMaxOfRateLimiter(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](rateLimiterBaseDelay, rateLimiterMaxDelay),
+			// Overall limit on requeues, matching controller-runtime's default
+			&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+		),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "VirtSquad")
 		os.Exit(1)
 	}
+	// ClusterVirtSquads and SquadFleets are not sharded, so only the first shard manages them
+	if shardCount <= 1 || shardID == 0 {
+		if err := (&controller.ClusterVirtSquadReconciler{
+			Client:       mgr.GetClient(),
+			Scheme:       mgr.GetScheme(),
+			Recorder:     mgr.GetEventRecorderFor("clustervirtsquad-controller"),
+			Paused:       paused,
+			ResyncJitter: resyncJitter,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ClusterVirtSquad")
+			os.Exit(1)
+		}
+		if err := (&controller.SquadFleetReconciler{
+			Client:       mgr.GetClient(),
+			Scheme:       mgr.GetScheme(),
+			Recorder:     mgr.GetEventRecorderFor("squadfleet-controller"),
+			Paused:       paused,
+			ResyncJitter: resyncJitter,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "SquadFleet")
+			os.Exit(1)
+		}
+	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err := webhookappsv1.SetupVirtSquadWebhookWithManager(mgr, validator); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "VirtSquad")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if metricsCertWatcher != nil {
@@ -219,6 +510,14 @@ func main() {
 		}
 	}
 
+	if certRotator != nil {
+		setupLog.Info("Adding webhook certificate rotator to manager")
+		if err := mgr.Add(certRotator); err != nil {
+			setupLog.Error(err, "unable to add webhook certificate rotator to manager")
+			os.Exit(1)
+		}
+	}
+
 	if webhookCertWatcher != nil {
 		setupLog.Info("Adding webhook certificate watcher to manager")
 		if err := mgr.Add(webhookCertWatcher); err != nil {
@@ -227,6 +526,54 @@ func main() {
 		}
 	}
 
+	if statusAPIAddr != "0" {
+		if statusAPIToken == "" {
+			setupLog.Error(nil, "the status API requires --status-api-token or STATUS_API_TOKEN")
+			os.Exit(1)
+		}
+		if statusAPIScaleToken != "" && len(statusAPIScaleNamespaces) == 0 {
+			setupLog.Error(nil, "the status API scale token requires at least one --status-api-scale-namespace")
+			os.Exit(1)
+		}
+		if statusAPICertPath == "" {
+			setupLog.Error(nil, "the status API requires --status-api-cert-path")
+			os.Exit(1)
+		}
+		setupLog.Info("Initializing status API certificate watcher using provided certificates",
+			"status-api-cert-path", statusAPICertPath, "status-api-cert-name", statusAPICertName, "status-api-cert-key", statusAPICertKey)
+		statusAPICertWatcher, err := certwatcher.New(
+			filepath.Join(statusAPICertPath, statusAPICertName),
+			filepath.Join(statusAPICertPath, statusAPICertKey),
+		)
+		if err != nil {
+			setupLog.Error(err, "Failed to initialize status API certificate watcher")
+			os.Exit(1)
+		}
+		if err := mgr.Add(statusAPICertWatcher); err != nil {
+			setupLog.Error(err, "unable to add status API certificate watcher to manager")
+			os.Exit(1)
+		}
+
+		setupLog.Info("Adding status API to manager")
+		if err := mgr.Add(&statusapi.Server{
+			Addr: statusAPIAddr,
+			TLSOpts: append(slices.Clone(tlsOpts), func(config *tls.Config) {
+				config.GetCertificate = statusAPICertWatcher.GetCertificate
+			}),
+			Token:           statusAPIToken,
+			Namespaces:      statusAPINamespaces,
+			ScaleToken:      statusAPIScaleToken,
+			ScaleNamespaces: statusAPIScaleNamespaces,
+			Reader:          mgr.GetClient(),
+			Writer:          mgr.GetClient(),
+			// Events are listed by field selector so the manager does not cache them
+			EventReader: mgr.GetAPIReader(),
+		}); err != nil {
+			setupLog.Error(err, "unable to add status API to manager")
+			os.Exit(1)
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -235,10 +582,43 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if err := mgr.AddReadyzCheck("informers", cacheSyncCheck(mgr.GetCache())); err != nil {
+		setupLog.Error(err, "unable to set up informer ready check")
+		os.Exit(1)
+	}
+	apiServerChecker, err := apiServerCheck(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create API server ready check")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("apiserver", apiServerChecker); err != nil {
+		setupLog.Error(err, "unable to set up API server ready check")
+		os.Exit(1)
+	}
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		certFile := filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs", "tls.crt")
+		if len(webhookCertPath) > 0 {
+			certFile = filepath.Join(webhookCertPath, webhookCertName)
+		}
+		if err := mgr.AddReadyzCheck("webhook-cert", certificateCheck(certFile)); err != nil {
+			setupLog.Error(err, "unable to set up webhook certificate ready check")
+			os.Exit(1)
+		}
+		if err := mgr.AddReadyzCheck("webhook", webhookServer.StartedChecker()); err != nil {
+			setupLog.Error(err, "unable to set up webhook server ready check")
+			os.Exit(1)
+		}
+	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		setupLog.Error(err, "unable to flush traces")
+	}
 }
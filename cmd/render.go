@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mshort55/virtsquad-operator/internal/controller"
+)
+
+// runRender implements the render subcommand, which prints the Pods and Services the operator
+// would create for the VirtSquads in the given manifests without applying them
+func runRender(args []string) error {
+	flags := flag.NewFlagSet("render", flag.ExitOnError)
+	var files stringList
+	flags.Var(&files, "f", "A manifest holding VirtSquads and the templates, overrides and quotas they use; "+
+		"may be repeated. Use - to read from standard input.")
+	maxReplicasPerMember := flags.Int("max-replicas-per-member", 0,
+		"The operator's limit on the replicas of a single team member. 0 means no limit.")
+	maxPodsPerSquad := flags.Int("max-pods-per-squad", 0,
+		"The operator's limit on the replicas across a squad's team members. 0 means no limit.")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s render -f squad.yaml [flags]\n\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+	if len(files) == 0 {
+		flags.Usage()
+		return errors.New("at least one manifest is required")
+	}
+
+	var objs []client.Object
+	for _, file := range files {
+		decoded, err := readManifest(file)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", file, err)
+		}
+		objs = append(objs, decoded...)
+	}
+
+	out, err := (&controller.VirtSquadReconciler{
+		Scheme:               scheme,
+		MaxReplicasPerMember: int32(*maxReplicasPerMember),
+		MaxPodsPerSquad:      int32(*maxPodsPerSquad),
+	}).Render(context.Background(), objs)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(out)
+	return err
+}
+
+// readManifest decodes the objects of a multi-document YAML or JSON manifest
+func readManifest(file string) ([]client.Object, error) {
+	in := os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = f.Close() }()
+		in = f
+	}
+
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(in))
+	var objs []client.Object
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return objs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		obj, _, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		clientObj, ok := obj.(client.Object)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a Kubernetes object", obj.GetObjectKind().GroupVersionKind())
+		}
+		objs = append(objs, clientObj)
+	}
+}
+
+// stringList is a flag that may be given several times
+type stringList []string
+
+func (l *stringList) String() string {
+	return fmt.Sprint(*l)
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
@@ -0,0 +1,332 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package certs generates and rotates the self-signed certificate the admission webhooks are
+// served with, and injects its CA into the webhook configurations.
+package certs
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// The Secret is only written in the operator's namespace, and the CA only injected into the
+// operator's own webhook configuration.
+// +kubebuilder:rbac:groups="",namespace=system,resources=secrets,verbs=get;create;update
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,resourceNames=virtsquad-operator-validating-webhook-configuration,verbs=get;patch
+
+const (
+	// caCertKey and caKeyKey are the Secret keys holding the CA, next to tls.crt and tls.key
+	caCertKey = "ca.crt"
+	caKeyKey  = "ca.key"
+
+	// caValidity is how long a generated CA is valid
+	caValidity = 10 * 365 * 24 * time.Hour
+	// certValidity is how long a generated serving certificate is valid
+	certValidity = 365 * 24 * time.Hour
+	// defaultCheckInterval is how often the certificate is checked for rotation
+	defaultCheckInterval = time.Hour
+)
+
+// Rotator keeps a self-signed serving certificate for the webhook Service in a Secret, writes
+// it to the webhook server's certificate directory and injects its CA into the webhook
+// configurations. Certificates are reissued once less than a third of their validity is left;
+// the CA is only replaced when it nears its own expiry, so rotating the serving certificate
+// never invalidates the caBundle other replicas are still served with.
+type Rotator struct {
+	// Client reads and writes the Secret and webhook configurations
+	Client client.Client
+
+	// Secret is the Secret the certificate is kept in, shared by all replicas
+	Secret types.NamespacedName
+
+	// Service is the webhook Service the certificate is issued for
+	Service types.NamespacedName
+
+	// WebhookConfigurations are the ValidatingWebhookConfigurations the CA is injected into
+	WebhookConfigurations []string
+
+	// CertDir is the directory tls.crt and tls.key are written to
+	CertDir string
+
+	// CheckInterval is how often the certificate is checked; defaults to an hour
+	CheckInterval time.Duration
+}
+
+// Start checks the certificate every CheckInterval until the context is cancelled. It
+// implements manager.Runnable.
+func (r *Rotator) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("cert-rotator")
+	interval := r.CheckInterval
+	if interval <= 0 {
+		interval = defaultCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := r.Ensure(ctx); err != nil {
+				log.Error(err, "Failed to rotate webhook certificate")
+			}
+		}
+	}
+}
+
+// NeedLeaderElection reports that every replica keeps its certificate files current, not just
+// the leader. It implements manager.LeaderElectionRunnable.
+func (r *Rotator) NeedLeaderElection() bool {
+	return false
+}
+
+// Ensure issues the certificate when the Secret lacks a valid one, writes it to CertDir and
+// injects the CA into the webhook configurations
+func (r *Rotator) Ensure(ctx context.Context) error {
+	secret, err := r.currentSecret(ctx)
+	if err != nil {
+		return err
+	}
+	if err := r.writeFiles(secret); err != nil {
+		return err
+	}
+	return r.injectCA(ctx, secret.Data[caCertKey])
+}
+
+// dnsNames returns the names the webhook Service is reached under
+func (r *Rotator) dnsNames() []string {
+	return []string{
+		fmt.Sprintf("%s.%s.svc", r.Service.Name, r.Service.Namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", r.Service.Name, r.Service.Namespace),
+	}
+}
+
+// currentSecret returns the Secret holding a certificate that needs no rotation, issuing one
+// when needed. When another replica updated the Secret first, its certificate is used instead.
+func (r *Rotator) currentSecret(ctx context.Context) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	err := r.Client.Get(ctx, r.Secret, secret)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	exists := err == nil
+	now := time.Now()
+	if exists && !needsRotation(secret.Data, r.dnsNames(), now) {
+		return secret, nil
+	}
+
+	data, err := issue(secret.Data, r.dnsNames(), now)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: r.Secret.Name, Namespace: r.Secret.Namespace},
+			Type:       corev1.SecretTypeTLS,
+		}
+		secret.Data = data
+		err = r.Client.Create(ctx, secret)
+	} else {
+		secret.Data = data
+		err = r.Client.Update(ctx, secret)
+	}
+	if errors.IsAlreadyExists(err) || errors.IsConflict(err) {
+		if err := r.Client.Get(ctx, r.Secret, secret); err != nil {
+			return nil, err
+		}
+		return secret, nil
+	}
+	return secret, err
+}
+
+// writeFiles writes the serving certificate and key to CertDir unless they are already current.
+// Each file is renamed into place so the certificate watcher never reads a partial file.
+func (r *Rotator) writeFiles(secret *corev1.Secret) error {
+	if err := os.MkdirAll(r.CertDir, 0o700); err != nil {
+		return err
+	}
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+		path := filepath.Join(r.CertDir, key)
+		if current, err := os.ReadFile(path); err == nil && bytes.Equal(current, secret.Data[key]) {
+			continue
+		}
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, secret.Data[key], 0o600); err != nil {
+			return err
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// injectCA sets the CA as the caBundle of every webhook in the webhook configurations
+func (r *Rotator) injectCA(ctx context.Context, caBundle []byte) error {
+	for _, name := range r.WebhookConfigurations {
+		configuration := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: name}, configuration); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		patch := client.MergeFromWithOptions(configuration.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		changed := false
+		for i := range configuration.Webhooks {
+			if !bytes.Equal(configuration.Webhooks[i].ClientConfig.CABundle, caBundle) {
+				configuration.Webhooks[i].ClientConfig.CABundle = caBundle
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+		if err := r.Client.Patch(ctx, configuration, patch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// needsRotation reports whether the Secret data lacks a CA or serving certificate that is valid
+// for the DNS names and has more than a third of its validity left
+func needsRotation(data map[string][]byte, dnsNames []string, now time.Time) bool {
+	ca, _, err := parsePair(data[caCertKey], data[caKeyKey])
+	if err != nil || expiring(ca, now) {
+		return true
+	}
+	cert, _, err := parsePair(data[corev1.TLSCertKey], data[corev1.TLSPrivateKeyKey])
+	if err != nil || expiring(cert, now) || !slices.Equal(cert.DNSNames, dnsNames) {
+		return true
+	}
+	return cert.CheckSignatureFrom(ca) != nil
+}
+
+// expiring reports whether less than a third of the certificate's validity is left
+func expiring(cert *x509.Certificate, now time.Time) bool {
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	return now.Before(cert.NotBefore) || now.After(cert.NotAfter.Add(-lifetime/3))
+}
+
+// issue returns Secret data with a new serving certificate for the DNS names, signed by the CA
+// in the existing data, or by a new CA when that one is missing or expiring
+func issue(existing map[string][]byte, dnsNames []string, now time.Time) (map[string][]byte, error) {
+	ca, caKey, err := parsePair(existing[caCertKey], existing[caKeyKey])
+	caPEM, caKeyPEM := existing[caCertKey], existing[caKeyKey]
+	if err != nil || expiring(ca, now) {
+		caKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		template := &x509.Certificate{
+			Subject:               pkix.Name{CommonName: "virtsquad-operator-webhook-ca"},
+			NotBefore:             now.Add(-time.Hour),
+			NotAfter:              now.Add(caValidity),
+			KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+			BasicConstraintsValid: true,
+			IsCA:                  true,
+		}
+		if caPEM, caKeyPEM, err = sign(template, template, &caKey.PublicKey, caKey, caKey); err != nil {
+			return nil, err
+		}
+		if ca, _, err = parsePair(caPEM, caKeyPEM); err != nil {
+			return nil, err
+		}
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:    dnsNames,
+		NotBefore:   now.Add(-time.Hour),
+		NotAfter:    now.Add(certValidity),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	certPEM, keyPEM, err := sign(template, ca, &key.PublicKey, key, caKey)
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{
+		corev1.TLSCertKey:       certPEM,
+		corev1.TLSPrivateKeyKey: keyPEM,
+		caCertKey:               caPEM,
+		caKeyKey:                caKeyPEM,
+	}, nil
+}
+
+// sign creates the certificate from the template, signed by the parent's key, and returns it
+// along with the certificate's own key in PEM
+func sign(template, parent *x509.Certificate, pub *ecdsa.PublicKey, key, parentKey *ecdsa.PrivateKey) ([]byte, []byte, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+	template.SerialNumber = serial
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, parentKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), nil
+}
+
+// parsePair parses a PEM certificate and its PEM EC private key
+func parsePair(certPEM, keyPEM []byte) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	certBlock, _ := pem.Decode(certPEM)
+	keyBlock, _ := pem.Decode(keyPEM)
+	if certBlock == nil || keyBlock == nil {
+		return nil, nil, fmt.Errorf("missing PEM certificate or key")
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cert, key, nil
+}
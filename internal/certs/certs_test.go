@@ -0,0 +1,91 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Rotator", func() {
+	var rotator *Rotator
+	ctx := context.Background()
+
+	BeforeEach(func() {
+		configuration := &admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "webhooks"},
+			Webhooks:   []admissionregistrationv1.ValidatingWebhook{{Name: "vvirtsquad-v1.kb.io"}},
+		}
+		rotator = &Rotator{
+			Client:                fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(configuration).Build(),
+			Secret:                types.NamespacedName{Namespace: "system", Name: "webhook-cert"},
+			Service:               types.NamespacedName{Namespace: "system", Name: "webhook-service"},
+			WebhookConfigurations: []string{"webhooks"},
+			CertDir:               GinkgoT().TempDir(),
+		}
+	})
+
+	It("should issue a certificate, write it and inject its CA", func() {
+		Expect(rotator.Ensure(ctx)).To(Succeed())
+
+		secret := &corev1.Secret{}
+		Expect(rotator.Client.Get(ctx, rotator.Secret, secret)).To(Succeed())
+		Expect(needsRotation(secret.Data, rotator.dnsNames(), time.Now())).To(BeFalse())
+
+		cert, err := os.ReadFile(filepath.Join(rotator.CertDir, corev1.TLSCertKey))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cert).To(Equal(secret.Data[corev1.TLSCertKey]))
+
+		configuration := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		Expect(rotator.Client.Get(ctx, types.NamespacedName{Name: "webhooks"}, configuration)).To(Succeed())
+		Expect(configuration.Webhooks[0].ClientConfig.CABundle).To(Equal(secret.Data[caCertKey]))
+
+		By("keeping a certificate that is still valid")
+		Expect(rotator.Ensure(ctx)).To(Succeed())
+		current := &corev1.Secret{}
+		Expect(rotator.Client.Get(ctx, rotator.Secret, current)).To(Succeed())
+		Expect(current.Data).To(Equal(secret.Data))
+	})
+
+	It("should reissue an expiring certificate with the same CA", func() {
+		data, err := issue(nil, rotator.dnsNames(), time.Now().Add(-300*24*time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(needsRotation(data, rotator.dnsNames(), time.Now())).To(BeTrue())
+		Expect(rotator.Client.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "system", Name: "webhook-cert"},
+			Data:       data,
+		})).To(Succeed())
+
+		Expect(rotator.Ensure(ctx)).To(Succeed())
+		secret := &corev1.Secret{}
+		Expect(rotator.Client.Get(ctx, rotator.Secret, secret)).To(Succeed())
+		Expect(secret.Data[corev1.TLSCertKey]).NotTo(Equal(data[corev1.TLSCertKey]))
+		Expect(secret.Data[caCertKey]).To(Equal(data[caCertKey]))
+		Expect(needsRotation(secret.Data, rotator.dnsNames(), time.Now())).To(BeFalse())
+	})
+})
@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+	"github.com/mshort55/virtsquad-operator/internal/notifications"
+)
+
+const (
+	// reasonAlertOpened is the event reason used when an incident is opened for a degraded squad
+	reasonAlertOpened = "AlertOpened"
+	// reasonAlertResolved is the event reason used when the squad's incident is resolved
+	reasonAlertResolved = "AlertResolved"
+
+	// defaultDegradedFor is how long a squad stays degraded before an incident is opened
+	defaultDegradedFor = 5 * time.Minute
+	// alertRetryInterval is how long to wait before retrying a failed alerting call
+	alertRetryInterval = time.Minute
+)
+
+// reconcileAlert opens an incident once the squad has been degraded for the configured duration
+// and resolves it when the squad recovers or is deleted. It returns how long to wait before the
+// alert needs to be checked again.
+func (r *VirtSquadReconciler) reconcileAlert(ctx context.Context, virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus) time.Duration {
+	log := logf.FromContext(ctx)
+
+	var spec *appsv1.AlertingSpec
+	if virtSquad.Spec.Notifications != nil {
+		spec = virtSquad.Spec.Notifications.Alerting
+	}
+	if spec == nil {
+		// Without credentials an incident that is still open can only be resolved by hand
+		status.AlertOpenedTime = nil
+		return 0
+	}
+
+	alert := notifications.Alert{Squad: virtSquad.Name, Namespace: virtSquad.Namespace}
+	degraded := meta.FindStatusCondition(status.Conditions, appsv1.ConditionDegraded)
+	if degraded != nil && degraded.Status == metav1.ConditionTrue && virtSquad.DeletionTimestamp == nil {
+		if status.AlertOpenedTime != nil {
+			return 0
+		}
+		degradedFor := defaultDegradedFor
+		if spec.DegradedFor != nil {
+			degradedFor = spec.DegradedFor.Duration
+		}
+		if wait := time.Until(degraded.LastTransitionTime.Add(degradedFor)); wait > 0 {
+			return wait
+		}
+
+		alert.Summary = fmt.Sprintf("VirtSquad %s/%s has been degraded since %s: %s", virtSquad.Namespace, virtSquad.Name,
+			degraded.LastTransitionTime.UTC().Format(time.RFC3339), degraded.Message)
+		if err := r.sendAlert(ctx, virtSquad, spec, alert, true); err != nil {
+			log.Error(err, "Failed to open incident", "provider", spec.Provider)
+			r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonNotificationFailed,
+				"Cannot open %s incident: %v", spec.Provider, err)
+			return alertRetryInterval
+		}
+		now := metav1.Now()
+		status.AlertOpenedTime = &now
+		r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonAlertOpened,
+			"Opened %s incident for the squad being degraded: %s", spec.Provider, degraded.Message)
+		return 0
+	}
+
+	if status.AlertOpenedTime == nil {
+		return 0
+	}
+	if err := r.sendAlert(ctx, virtSquad, spec, alert, false); err != nil {
+		log.Error(err, "Failed to resolve incident", "provider", spec.Provider)
+		r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonNotificationFailed,
+			"Cannot resolve %s incident: %v", spec.Provider, err)
+		return alertRetryInterval
+	}
+	status.AlertOpenedTime = nil
+	r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonAlertResolved, "Resolved %s incident", spec.Provider)
+	return 0
+}
+
+// sendAlert opens or resolves the squad's incident with the configured provider
+func (r *VirtSquadReconciler) sendAlert(ctx context.Context, virtSquad *appsv1.VirtSquad, spec *appsv1.AlertingSpec, alert notifications.Alert, open bool) error {
+	credential, err := r.secretValue(ctx, virtSquad, &spec.CredentialSecretRef)
+	if err != nil {
+		return fmt.Errorf("reading alerting credential: %w", err)
+	}
+
+	notifier := r.Notifier
+	if notifier == nil {
+		notifier = notifications.NewClient()
+	}
+
+	switch {
+	case spec.Provider == appsv1.AlertProviderPagerDuty && open:
+		return notifier.TriggerPagerDuty(ctx, credential, alert)
+	case spec.Provider == appsv1.AlertProviderPagerDuty:
+		return notifier.ResolvePagerDuty(ctx, credential, alert)
+	case spec.Provider == appsv1.AlertProviderOpsgenie && open:
+		return notifier.TriggerOpsgenie(ctx, credential, alert)
+	case spec.Provider == appsv1.AlertProviderOpsgenie:
+		return notifier.ResolveOpsgenie(ctx, credential, alert)
+	}
+	return fmt.Errorf("unknown alerting provider %q", spec.Provider)
+}
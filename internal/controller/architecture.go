@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// reasonArchitectureSupported is reported when the member's image is built for its architecture
+	reasonArchitectureSupported = "ArchitectureSupported"
+	// reasonArchitectureUnsupported is reported when the member's image is not built for its architecture
+	reasonArchitectureUnsupported = "ArchitectureUnsupported"
+	// reasonArchitectureLookupFailed is reported when the image's architectures cannot be looked up
+	reasonArchitectureLookupFailed = "ArchitectureLookupFailed"
+)
+
+// reconcileArchitecture checks that the member's image is built for the member's architecture
+// and records the outcome in the member's ArchitectureSupported condition. It returns false when
+// the image lacks the architecture, so that no pods are created that could never start. Lookups
+// that fail are reported without holding pods back.
+func (r *VirtSquadReconciler) reconcileArchitecture(ctx context.Context, virtSquad *appsv1.VirtSquad, member *appsv1.MemberStatus, spec *appsv1.TeamMemberSpec) bool {
+	if spec.Architecture == "" || !ptr.Deref(spec.ValidateArchitecture, false) || r.Registry == nil {
+		meta.RemoveStatusCondition(&member.Conditions, appsv1.ConditionArchitectureSupported)
+		return true
+	}
+
+	image := memberImage(spec)
+
+	condition := metav1.Condition{
+		Type:               appsv1.ConditionArchitectureSupported,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonArchitectureSupported,
+		Message:            fmt.Sprintf("Image %s is built for %s", image, spec.Architecture),
+		ObservedGeneration: virtSquad.Generation,
+	}
+	architectures, err := r.Registry.Architectures(ctx, image)
+	switch {
+	case err != nil:
+		logf.FromContext(ctx).Error(err, "Failed to look up image architectures", "image", image, "member", member.Name)
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = reasonArchitectureLookupFailed
+		condition.Message = fmt.Sprintf("Cannot look up the architectures of image %s: %v", image, err)
+	case !slices.Contains(architectures, spec.Architecture):
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonArchitectureUnsupported
+		condition.Message = fmt.Sprintf("Image %s is built for %s but not for %s",
+			image, strings.Join(architectures, ", "), spec.Architecture)
+	}
+	if meta.SetStatusCondition(&member.Conditions, condition) && condition.Status == metav1.ConditionFalse {
+		r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonArchitectureUnsupported,
+			"Not creating pods for team member %s: %s", member.Name, condition.Message)
+	}
+	return condition.Status != metav1.ConditionFalse
+}
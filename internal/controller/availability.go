@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+// availabilityBuckets is the number of slices the availability window is split into
+const availabilityBuckets = 24
+
+// updateMemberFirstReady records when all of the member's desired pods were first ready
+func updateMemberFirstReady(member *appsv1.MemberStatus, pods []corev1.Pod, desiredReplicas int32) {
+	if member.FirstReadyTime != nil || desiredReplicas == 0 {
+		return
+	}
+	ready := int32(0)
+	for i := range pods {
+		if isPodReady(&pods[i]) {
+			ready++
+		}
+	}
+	if ready >= desiredReplicas {
+		now := metav1.Now()
+		member.FirstReadyTime = &now
+	}
+}
+
+// updateAvailability records the squad's start time and adds the pod-seconds since the last
+// sample to the availability buckets. The pod counts observed by the previous reconcile held
+// until now, so they are the ones credited. Buckets that fell out of the window are dropped and
+// the availability is recomputed from the rest; a zero window disables availability tracking.
+func updateAvailability(virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus, window time.Duration, now time.Time) {
+	if status.StartTime == nil {
+		status.StartTime = &metav1.Time{Time: now}
+	}
+	if window <= 0 {
+		status.Availability = ""
+		status.AvailabilityBuckets = nil
+		status.LastAvailabilitySampleTime = nil
+		return
+	}
+	bucketLength := window / availabilityBuckets
+
+	if status.LastAvailabilitySampleTime == nil {
+		status.LastAvailabilitySampleTime = &metav1.Time{Time: now}
+		return
+	}
+	// Only whole seconds are credited; the remainder is carried over to the next sample
+	last := status.LastAvailabilitySampleTime.Time
+	elapsed := now.Sub(last).Truncate(time.Second)
+	if elapsed <= 0 {
+		return
+	}
+	end := last.Add(elapsed)
+	status.LastAvailabilitySampleTime = &metav1.Time{Time: end}
+
+	desired := int64(virtSquad.Status.DesiredPods)
+	ready := min(int64(virtSquad.Status.ReadyPods), desired)
+	start := last
+	if windowStart := end.Add(-window); start.Before(windowStart) {
+		start = windowStart
+	}
+	for start.Before(end) {
+		bucketStart := start.Truncate(bucketLength)
+		sliceEnd := bucketStart.Add(bucketLength)
+		if sliceEnd.After(end) {
+			sliceEnd = end
+		}
+		seconds := int64(sliceEnd.Sub(start) / time.Second)
+		bucket := availabilityBucket(status, bucketStart)
+		bucket.ReadyPodSeconds += ready * seconds
+		bucket.DesiredPodSeconds += desired * seconds
+		start = sliceEnd
+	}
+
+	kept := status.AvailabilityBuckets[:0]
+	var readySeconds, desiredSeconds int64
+	for _, bucket := range status.AvailabilityBuckets {
+		if !bucket.StartTime.Add(bucketLength).After(end.Add(-window)) {
+			continue
+		}
+		kept = append(kept, bucket)
+		readySeconds += bucket.ReadyPodSeconds
+		desiredSeconds += bucket.DesiredPodSeconds
+	}
+	status.AvailabilityBuckets = kept
+
+	status.Availability = ""
+	if desiredSeconds > 0 {
+		status.Availability = fmt.Sprintf("%.2f%%", 100*float64(readySeconds)/float64(desiredSeconds))
+	}
+}
+
+// availabilityBucket returns the bucket starting at the given time, appending it if needed
+func availabilityBucket(status *appsv1.VirtSquadStatus, start time.Time) *appsv1.AvailabilityBucket {
+	if n := len(status.AvailabilityBuckets); n > 0 && status.AvailabilityBuckets[n-1].StartTime.Time.Equal(start) {
+		return &status.AvailabilityBuckets[n-1]
+	}
+	status.AvailabilityBuckets = append(status.AvailabilityBuckets, appsv1.AvailabilityBucket{StartTime: metav1.Time{Time: start}})
+	return &status.AvailabilityBuckets[len(status.AvailabilityBuckets)-1]
+}
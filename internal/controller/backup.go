@@ -0,0 +1,274 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+	"github.com/mshort55/virtsquad-operator/internal/backup"
+)
+
+const (
+	// reasonBackedUp is the event reason used when a squad has been backed up
+	reasonBackedUp = "BackedUp"
+	// reasonBackupFailed is the event reason used when a squad cannot be backed up
+	reasonBackupFailed = "BackupFailed"
+	// reasonRestored is the event reason used when a squad's spec has been restored from a backup
+	reasonRestored = "Restored"
+	// reasonRestoreFailed is the event reason used when a squad cannot be restored from a backup
+	reasonRestoreFailed = "RestoreFailed"
+
+	// backupRetryInterval is how long a failed backup waits before it is retried
+	backupRetryInterval = time.Minute
+)
+
+// backupClient returns a client for the squad's backup bucket, reading its credentials from
+// the squad's credentials Secret
+func (r *VirtSquadReconciler) backupClient(ctx context.Context, virtSquad *appsv1.VirtSquad) (*backup.Client, error) {
+	spec := virtSquad.Spec.Backup
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: virtSquad.Namespace, Name: spec.CredentialsSecretRef.Name}
+	if err := r.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf("reading secret %s: %w", key.Name, err)
+	}
+	credentials := backup.Credentials{
+		AccessKeyID:     string(secret.Data["accessKeyID"]),
+		SecretAccessKey: string(secret.Data["secretAccessKey"]),
+	}
+	if credentials.AccessKeyID == "" || credentials.SecretAccessKey == "" {
+		return nil, fmt.Errorf("secret %s must hold accessKeyID and secretAccessKey", key.Name)
+	}
+
+	endpoint := spec.Endpoint
+	if endpoint == "" {
+		endpoint = backup.DefaultS3Endpoint
+		if spec.Provider == appsv1.BackupProviderGCS {
+			endpoint = backup.DefaultGCSEndpoint
+		}
+	}
+	return backup.NewClient(endpoint, spec.Region, spec.Bucket, credentials), nil
+}
+
+// backupKey returns the object key of a squad backup taken at the given time
+func backupKey(virtSquad *appsv1.VirtSquad, now time.Time) string {
+	return path.Join(virtSquad.Spec.Backup.Prefix, virtSquad.Namespace, virtSquad.Name,
+		now.UTC().Format("20060102T150405Z")+".json")
+}
+
+// exportObject returns a copy of obj without the fields the API server assigns, tagged with
+// its kind so it can be applied again as part of a List
+func (r *VirtSquadReconciler) exportObject(obj client.Object) (runtime.RawExtension, error) {
+	gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+	if err != nil {
+		return runtime.RawExtension{}, err
+	}
+	exported := obj.DeepCopyObject().(client.Object)
+	exported.GetObjectKind().SetGroupVersionKind(gvk)
+	exported.SetResourceVersion("")
+	exported.SetUID("")
+	exported.SetGeneration(0)
+	exported.SetCreationTimestamp(metav1.Time{})
+	exported.SetManagedFields(nil)
+	exported.SetOwnerReferences(nil)
+	exported.SetFinalizers(nil)
+
+	raw, err := json.Marshal(exported)
+	if err != nil {
+		return runtime.RawExtension{}, err
+	}
+	return runtime.RawExtension{Raw: raw}, nil
+}
+
+// exportSquad serializes the squad's spec along with its Services and pods as a List manifest
+func (r *VirtSquadReconciler) exportSquad(ctx context.Context, virtSquad *appsv1.VirtSquad) ([]byte, error) {
+	squad := &appsv1.VirtSquad{ObjectMeta: *virtSquad.ObjectMeta.DeepCopy(), Spec: *virtSquad.Spec.DeepCopy()}
+	objects := []client.Object{squad}
+
+	services := &corev1.ServiceList{}
+	if err := r.List(ctx, services, client.InNamespace(virtSquad.Namespace)); err != nil {
+		return nil, err
+	}
+	for i := range services.Items {
+		if metav1.IsControlledBy(&services.Items[i], virtSquad) {
+			services.Items[i].Status = corev1.ServiceStatus{}
+			objects = append(objects, &services.Items[i])
+		}
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(virtSquad.Namespace), client.MatchingLabels{squadLabel: virtSquad.Name}); err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		pods.Items[i].Status = corev1.PodStatus{}
+		objects = append(objects, &pods.Items[i])
+	}
+
+	list := &corev1.List{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "List"}}
+	for _, obj := range objects {
+		item, err := r.exportObject(obj)
+		if err != nil {
+			return nil, err
+		}
+		list.Items = append(list.Items, item)
+	}
+	return json.MarshalIndent(list, "", "  ")
+}
+
+// reconcileBackup backs the squad up once its backup interval has passed since the last backup,
+// and returns when the next backup is due. Failed backups are reported and retried but never
+// fail the reconcile.
+func (r *VirtSquadReconciler) reconcileBackup(ctx context.Context, virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus) time.Duration {
+	log := logf.FromContext(ctx)
+
+	if virtSquad.Spec.Backup == nil {
+		return 0
+	}
+	interval := time.Hour
+	if virtSquad.Spec.Backup.Interval != nil && virtSquad.Spec.Backup.Interval.Duration > 0 {
+		interval = virtSquad.Spec.Backup.Interval.Duration
+	}
+	if status.LastBackupTime != nil {
+		if wait := time.Until(status.LastBackupTime.Add(interval)); wait > 0 {
+			return wait
+		}
+	}
+
+	now := time.Now()
+	key := backupKey(virtSquad, now)
+	err := func() error {
+		store, err := r.backupClient(ctx, virtSquad)
+		if err != nil {
+			return err
+		}
+		manifest, err := r.exportSquad(ctx, virtSquad)
+		if err != nil {
+			return fmt.Errorf("exporting squad: %w", err)
+		}
+		return store.Put(ctx, key, manifest)
+	}()
+	if err != nil {
+		log.Error(err, "Failed to back up squad")
+		r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonBackupFailed, "Cannot back up squad: %v", err)
+		return backupRetryInterval
+	}
+
+	status.LastBackupTime = &metav1.Time{Time: now}
+	status.LastBackupKey = key
+	r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonBackedUp, "Backed up squad to %s", key)
+	return interval
+}
+
+// restoreError reports a backup the squad cannot be restored from, however often it is retried
+type restoreError string
+
+// Error implements error
+func (e restoreError) Error() string {
+	return string(e)
+}
+
+// restoreFromBackup replaces the squad's spec with the spec of the backup named by the
+// restore-from annotation and removes the annotation. The squad keeps its own backup settings,
+// which were just used to read the backup, so a squad created with nothing but them is recreated
+// from the backup. When the backup does not exist or holds no squad, the RestoreFailed condition
+// is reported and the annotation removed; other failures are retried. It returns true when the
+// squad was updated, in which case the reconcile should stop and pick up the change next time.
+func (r *VirtSquadReconciler) restoreFromBackup(ctx context.Context, virtSquad *appsv1.VirtSquad) (bool, error) {
+	key, ok := virtSquad.Annotations[appsv1.RestoreFromAnnotation]
+	if !ok {
+		return false, nil
+	}
+
+	spec, err := func() (*appsv1.VirtSquadSpec, error) {
+		if virtSquad.Spec.Backup == nil {
+			return nil, restoreError("the squad has no backup configured")
+		}
+		store, err := r.backupClient(ctx, virtSquad)
+		if err != nil {
+			return nil, err
+		}
+		manifest, err := store.Get(ctx, key)
+		if errors.Is(err, backup.ErrNotFound) {
+			return nil, restoreError(fmt.Sprintf("backup %s does not exist", key))
+		}
+		if err != nil {
+			return nil, err
+		}
+		list := &corev1.List{}
+		if err := json.Unmarshal(manifest, list); err != nil {
+			return nil, restoreError(fmt.Sprintf("parsing backup %s: %v", key, err))
+		}
+		for _, item := range list.Items {
+			squad := &appsv1.VirtSquad{}
+			if err := json.Unmarshal(item.Raw, squad); err == nil && squad.Kind == "VirtSquad" {
+				return &squad.Spec, nil
+			}
+		}
+		return nil, restoreError(fmt.Sprintf("backup %s holds no VirtSquad", key))
+	}()
+	var statusChanged bool
+	if err != nil {
+		r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonRestoreFailed, "Cannot restore squad from %s: %v", key, err)
+		if _, permanent := err.(restoreError); !permanent {
+			return false, err
+		}
+		statusChanged = meta.SetStatusCondition(&virtSquad.Status.Conditions, metav1.Condition{
+			Type:               appsv1.ConditionRestoreFailed,
+			Status:             metav1.ConditionTrue,
+			Reason:             reasonRestoreFailed,
+			Message:            fmt.Sprintf("Cannot restore squad from %s: %v", key, err),
+			ObservedGeneration: virtSquad.Generation,
+		})
+	} else {
+		statusChanged = meta.RemoveStatusCondition(&virtSquad.Status.Conditions, appsv1.ConditionRestoreFailed)
+	}
+	// The status is written first, as updating it resets the spec to the stored one
+	if statusChanged {
+		if err := r.Status().Update(ctx, virtSquad); err != nil {
+			return false, err
+		}
+	}
+
+	if spec != nil {
+		backupSpec := virtSquad.Spec.Backup
+		virtSquad.Spec = *spec
+		virtSquad.Spec.Backup = backupSpec
+	}
+	delete(virtSquad.Annotations, appsv1.RestoreFromAnnotation)
+	if err := r.Update(ctx, virtSquad); err != nil {
+		return false, err
+	}
+	if spec != nil {
+		r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonRestored, "Restored squad from %s", key)
+	}
+	return true, nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+var _ = Describe("Restoring from a backup", func() {
+	const key = "default/web/20260101T000000Z.json"
+
+	var (
+		ctx       context.Context
+		server    *httptest.Server
+		virtSquad *appsv1.VirtSquad
+		secret    *corev1.Secret
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		backedUp := &appsv1.VirtSquad{
+			TypeMeta:   metav1.TypeMeta{APIVersion: appsv1.GroupVersion.String(), Kind: "VirtSquad"},
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec: appsv1.VirtSquadSpec{
+				Oksana: &appsv1.TeamMemberSpec{Name: ptr.To("oksana-pod"), Replicas: ptr.To(int32(2))},
+				Backup: &appsv1.BackupSpec{Bucket: "old-squads", CredentialsSecretRef: corev1.LocalObjectReference{Name: "old-credentials"}},
+			},
+		}
+		raw, err := json.Marshal(backedUp)
+		Expect(err).NotTo(HaveOccurred())
+		manifest, err := json.Marshal(&corev1.List{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "List"},
+			Items:    []runtime.RawExtension{{Raw: raw}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != "/squads/"+key {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(manifest)
+		}))
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-credentials", Namespace: "default"},
+			Data:       map[string][]byte{"accessKeyID": []byte("id"), "secretAccessKey": []byte("secret")},
+		}
+		// A deleted squad is recreated by a squad holding only its backup settings
+		virtSquad = &appsv1.VirtSquad{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "web",
+				Namespace:   "default",
+				Annotations: map[string]string{appsv1.RestoreFromAnnotation: key},
+			},
+			Spec: appsv1.VirtSquadSpec{Backup: &appsv1.BackupSpec{
+				Bucket:               "squads",
+				Endpoint:             server.URL,
+				CredentialsSecretRef: corev1.LocalObjectReference{Name: "backup-credentials"},
+			}},
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should recreate a squad from its backup, keeping the squad's backup settings", func() {
+		virtSquad.Status.Conditions = []metav1.Condition{{
+			Type: appsv1.ConditionRestoreFailed, Status: metav1.ConditionTrue, Reason: reasonRestoreFailed,
+		}}
+		r, c := newFakeReconciler(secret, virtSquad)
+
+		Expect(r.restoreFromBackup(ctx, virtSquad)).To(BeTrue())
+		restored := &appsv1.VirtSquad{}
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(virtSquad), restored)).To(Succeed())
+		Expect(restored.Annotations).NotTo(HaveKey(appsv1.RestoreFromAnnotation))
+		Expect(*restored.Spec.Oksana.Name).To(Equal("oksana-pod"))
+		Expect(*restored.Spec.Oksana.Replicas).To(Equal(int32(2)))
+		Expect(restored.Spec.Backup.Bucket).To(Equal("squads"))
+		Expect(restored.Spec.Backup.CredentialsSecretRef.Name).To(Equal("backup-credentials"))
+		Expect(meta.FindStatusCondition(restored.Status.Conditions, appsv1.ConditionRestoreFailed)).To(BeNil())
+		Expect(r.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("Restored squad from " + key)))
+	})
+
+	It("should give up on a backup that does not exist and report it", func() {
+		virtSquad.Annotations[appsv1.RestoreFromAnnotation] = "default/web/missing.json"
+		r, c := newFakeReconciler(secret, virtSquad)
+
+		Expect(r.restoreFromBackup(ctx, virtSquad)).To(BeTrue())
+		failed := &appsv1.VirtSquad{}
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(virtSquad), failed)).To(Succeed())
+		Expect(failed.Annotations).NotTo(HaveKey(appsv1.RestoreFromAnnotation))
+		Expect(failed.Spec.Oksana).To(BeNil())
+		condition := meta.FindStatusCondition(failed.Status.Conditions, appsv1.ConditionRestoreFailed)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Message).To(ContainSubstring("backup default/web/missing.json does not exist"))
+
+		// Nothing is left to restore the next time round
+		Expect(r.restoreFromBackup(ctx, failed)).To(BeFalse())
+	})
+
+	It("should retry failures that may go away", func() {
+		r, _ := newFakeReconciler(virtSquad)
+
+		_, err := r.restoreFromBackup(ctx, virtSquad)
+		Expect(err).To(MatchError(ContainSubstring("reading secret backup-credentials")))
+		Expect(virtSquad.Annotations).To(HaveKey(appsv1.RestoreFromAnnotation))
+		Expect(meta.FindStatusCondition(virtSquad.Status.Conditions, appsv1.ConditionRestoreFailed)).To(BeNil())
+	})
+})
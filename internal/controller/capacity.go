@@ -0,0 +1,153 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// capacityLabel records whether a pod was placed on spot or on-demand nodes
+	capacityLabel = "virtsquad.mshort55.io/capacity"
+	// capacitySpot marks pods placed on spot nodes
+	capacitySpot = "spot"
+	// capacityOnDemand marks pods placed on on-demand nodes
+	capacityOnDemand = "on-demand"
+
+	// reasonSpotInterrupted is the event reason used when a spot pod is interrupted by its node
+	reasonSpotInterrupted = "SpotInterrupted"
+)
+
+// spotInterrupted reports whether a spot pod is being disrupted, e.g. because its node is
+// reclaimed or shut down
+func spotInterrupted(pod *corev1.Pod) bool {
+	if pod.Labels[capacityLabel] != capacitySpot {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.DisruptionTarget && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// backfillSpotInterruptions splits off the member's interrupted spot pods so that replacements
+// are created right away instead of once the pods are gone, and deletes the ones that already
+// exited. Interruptions are recorded in the member's status. It returns the remaining pods.
+func (r *VirtSquadReconciler) backfillSpotInterruptions(ctx context.Context, virtSquad *appsv1.VirtSquad, member *appsv1.MemberStatus, policy *appsv1.CapacityPolicySpec, pods []corev1.Pod) ([]corev1.Pod, error) {
+	log := logf.FromContext(ctx)
+
+	if policy == nil {
+		return pods, nil
+	}
+
+	remaining := make([]corev1.Pod, 0, len(pods))
+	for i := range pods {
+		pod := &pods[i]
+		if !spotInterrupted(pod) {
+			remaining = append(remaining, *pod)
+			continue
+		}
+
+		// The condition's transition time tells a new interruption from one already recorded
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type != corev1.DisruptionTarget {
+				continue
+			}
+			if member.LastSpotInterruptionTime == nil || member.LastSpotInterruptionTime.Before(&condition.LastTransitionTime) {
+				member.SpotInterruptions++
+				transitionTime := condition.LastTransitionTime
+				if transitionTime.IsZero() {
+					transitionTime = metav1.Now()
+				}
+				member.LastSpotInterruptionTime = &transitionTime
+				r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonSpotInterrupted,
+					"Spot pod %s of team member %s was interrupted (%s); backfilling on on-demand nodes",
+					pod.Name, member.Name, condition.Reason)
+			}
+		}
+
+		if isPodTerminated(pod) {
+			log.Info("Deleting interrupted spot pod", "pod", pod.Name, "member", member.Name)
+			if err := r.deletePod(ctx, virtSquad, pod, deleteReasonSpotInterrupted); err != nil {
+				log.Error(err, "Failed to delete interrupted spot pod", "pod", pod.Name)
+				return remaining, err
+			}
+		}
+	}
+	return remaining, nil
+}
+
+// podsPerCapacity counts the pods placed on each capacity type
+func podsPerCapacity(pods []corev1.Pod) map[string]int {
+	counts := map[string]int{}
+	for _, pod := range pods {
+		if capacity := pod.Labels[capacityLabel]; capacity != "" {
+			counts[capacity]++
+		}
+	}
+	return counts
+}
+
+// nextCapacity returns the capacity type for a new pod of the member and counts the pod toward
+// it. New pods go to spot nodes until spotPercent of the replicas run there, except shortly after
+// a spot interruption. It returns an empty string when the member has no capacity policy.
+func nextCapacity(member *appsv1.MemberStatus, policy *appsv1.CapacityPolicySpec, totalReplicas int32, counts map[string]int) string {
+	if policy == nil {
+		return ""
+	}
+
+	capacity := capacityOnDemand
+	backfill := 600 * time.Second
+	if policy.BackfillSeconds != nil {
+		backfill = time.Duration(*policy.BackfillSeconds) * time.Second
+	}
+	backfilling := member.LastSpotInterruptionTime != nil && time.Since(member.LastSpotInterruptionTime.Time) < backfill
+	if !backfilling && int32(counts[capacitySpot]) < totalReplicas*policy.SpotPercent/100 {
+		capacity = capacitySpot
+	}
+	counts[capacity]++
+	return capacity
+}
+
+// pinToCapacity returns a copy of the pod spec that only schedules onto nodes of the capacity
+// type, or the pod spec itself when the capacity type is empty
+func pinToCapacity(podSpec corev1.PodSpec, policy *appsv1.CapacityPolicySpec, capacity string) corev1.PodSpec {
+	if policy == nil || capacity == "" {
+		return podSpec
+	}
+	pinned := *podSpec.DeepCopy()
+	if pinned.NodeSelector == nil {
+		pinned.NodeSelector = map[string]string{}
+	}
+	if capacity == capacitySpot {
+		maps.Copy(pinned.NodeSelector, policy.SpotNodeSelector)
+		pinned.Tolerations = append(pinned.Tolerations, policy.SpotTolerations...)
+	} else {
+		maps.Copy(pinned.NodeSelector, policy.OnDemandNodeSelector)
+	}
+	return pinned
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// chaosRetryInterval is how long chaos waits for a disrupted squad to recover before trying again
+	chaosRetryInterval = time.Minute
+	// reasonChaosPodDeleted is the event reason used when chaos deleted a pod
+	reasonChaosPodDeleted = "ChaosPodDeleted"
+)
+
+// reconcileChaos deletes a random ready pod of the squad once the chaos interval has passed
+// since the last deletion, and returns when chaos strikes next. The deletion is put off while
+// the squad already has MaxDisruptions pods missing or not ready.
+func (r *VirtSquadReconciler) reconcileChaos(ctx context.Context, virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus) (time.Duration, error) {
+	log := logf.FromContext(ctx)
+
+	chaos := virtSquad.Spec.Chaos
+	if chaos == nil {
+		status.LastChaosTime = nil
+		status.LastChaosPod = ""
+		return 0, nil
+	}
+	interval := time.Hour
+	if chaos.Interval != nil && chaos.Interval.Duration > 0 {
+		interval = chaos.Interval.Duration
+	}
+	if status.LastChaosTime == nil {
+		// Give a new squad, or one that just turned chaos on, a full interval to settle
+		now := metav1.Now()
+		status.LastChaosTime = &now
+		return interval, nil
+	}
+	if wait := time.Until(status.LastChaosTime.Add(interval)); wait > 0 {
+		return wait, nil
+	}
+
+	maxDisruptions := int32(1)
+	if chaos.MaxDisruptions != nil {
+		maxDisruptions = *chaos.MaxDisruptions
+	}
+	if status.DesiredPods-status.ReadyPods+1 > maxDisruptions {
+		return chaosRetryInterval, nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods,
+		client.InNamespace(virtSquad.Namespace),
+		client.MatchingLabels{appLabel: appLabelValue, squadLabel: virtSquad.Name},
+	); err != nil {
+		log.Error(err, "Failed to list pods for chaos")
+		return 0, err
+	}
+	var candidates []corev1.Pod
+	for _, pod := range pods.Items {
+		// Quarantined pods no longer belong to a team member and are left alone
+		if pod.DeletionTimestamp == nil && pod.Labels[memberLabel] != "" && isPodReady(&pod) {
+			candidates = append(candidates, pod)
+		}
+	}
+	if len(candidates) == 0 {
+		return chaosRetryInterval, nil
+	}
+
+	victim := &candidates[rand.IntN(len(candidates))]
+	log.Info("Deleting pod for chaos", "pod", victim.Name)
+	if err := r.deletePod(ctx, virtSquad, victim, deleteReasonChaos); err != nil {
+		log.Error(err, "Failed to delete pod for chaos", "pod", victim.Name)
+		return 0, err
+	}
+	now := metav1.Now()
+	status.LastChaosTime = &now
+	status.LastChaosPod = victim.Name
+	r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonChaosPodDeleted,
+		"Deleted pod %s of team member %s for chaos testing", victim.Name, victim.Labels[memberLabel])
+	return interval, nil
+}
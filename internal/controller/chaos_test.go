@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+var _ = Describe("Chaos", func() {
+	ctx := context.Background()
+
+	newSquad := func() *appsv1.VirtSquad {
+		return &appsv1.VirtSquad{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "squad"},
+			Spec: appsv1.VirtSquadSpec{
+				Oksana: &appsv1.TeamMemberSpec{Name: ptr.To("oksana"), Replicas: ptr.To(int32(3))},
+				Chaos:  &appsv1.ChaosSpec{Interval: &metav1.Duration{Duration: time.Minute}},
+			},
+		}
+	}
+	newPod := func(name, member string, ready bool) *corev1.Pod {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default", Name: name,
+			Labels: map[string]string{appLabel: appLabelValue, squadLabel: "squad"},
+		}}
+		if member != "" {
+			pod.Labels[memberLabel] = member
+		}
+		if ready {
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		}
+		return pod
+	}
+	// dueStatus returns a status whose last chaos deletion is an interval ago
+	dueStatus := func(desired, ready int32) *appsv1.VirtSquadStatus {
+		return &appsv1.VirtSquadStatus{
+			DesiredPods:   desired,
+			ReadyPods:     ready,
+			LastChaosTime: ptr.To(metav1.NewTime(time.Now().Add(-2 * time.Minute))),
+		}
+	}
+	remainingPods := func(c client.Client) []string {
+		pods := &corev1.PodList{}
+		Expect(c.List(ctx, pods)).To(Succeed())
+		var names []string
+		for _, pod := range pods.Items {
+			names = append(names, pod.Name)
+		}
+		return names
+	}
+
+	It("should give a squad a full interval before the first deletion", func() {
+		r, c := newFakeReconciler(newPod("ready", "oksana", true))
+		status := &appsv1.VirtSquadStatus{DesiredPods: 1, ReadyPods: 1}
+
+		wait, err := r.reconcileChaos(ctx, newSquad(), status)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wait).To(Equal(time.Minute))
+		Expect(status.LastChaosTime).NotTo(BeNil())
+		Expect(remainingPods(c)).To(ConsistOf("ready"))
+	})
+
+	It("should delete one ready pod of a team member once the interval passed", func() {
+		r, c := newFakeReconciler(
+			newPod("ready", "oksana", true),
+			newPod("starting", "oksana", false),
+			newPod("quarantined", "", true),
+		)
+		status := dueStatus(2, 2)
+
+		wait, err := r.reconcileChaos(ctx, newSquad(), status)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wait).To(Equal(time.Minute))
+		Expect(status.LastChaosPod).To(Equal("ready"))
+		Expect(remainingPods(c)).To(ConsistOf("starting", "quarantined"))
+		Expect(r.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring(reasonChaosPodDeleted)))
+	})
+
+	It("should put the deletion off while the squad already has MaxDisruptions pods down", func() {
+		r, c := newFakeReconciler(newPod("first", "oksana", true), newPod("second", "oksana", true))
+		virtSquad := newSquad()
+		status := dueStatus(3, 2)
+
+		wait, err := r.reconcileChaos(ctx, virtSquad, status)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wait).To(Equal(chaosRetryInterval))
+		Expect(status.LastChaosPod).To(BeEmpty())
+		Expect(remainingPods(c)).To(ConsistOf("first", "second"))
+
+		// One more disruption is allowed once MaxDisruptions leaves room for it
+		virtSquad.Spec.Chaos.MaxDisruptions = ptr.To(int32(2))
+		_, err = r.reconcileChaos(ctx, virtSquad, status)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(remainingPods(c)).To(HaveLen(1))
+	})
+
+	It("should forget its state when chaos is turned off", func() {
+		r, _ := newFakeReconciler()
+		virtSquad := newSquad()
+		virtSquad.Spec.Chaos = nil
+		status := dueStatus(1, 1)
+		status.LastChaosPod = "gone"
+
+		wait, err := r.reconcileChaos(ctx, virtSquad, status)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wait).To(BeZero())
+		Expect(status.LastChaosTime).To(BeNil())
+		Expect(status.LastChaosPod).To(BeEmpty())
+	})
+})
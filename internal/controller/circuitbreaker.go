@@ -0,0 +1,186 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// circuitBreakerThreshold is the number of consecutive failed reconciles that blocks a squad
+	circuitBreakerThreshold = 3
+	// circuitBreakerBaseDelay is the delay before retrying a squad that just got blocked
+	circuitBreakerBaseDelay = 5 * time.Second
+	// circuitBreakerMaxDelay caps the delay between retries of a blocked squad
+	circuitBreakerMaxDelay = 10 * time.Minute
+
+	// reasonReconcileFailing is reported when reconciles keep failing and the squad is backed off
+	reasonReconcileFailing = "ReconcileFailing"
+)
+
+// reconcileStreak is the run of consecutive failed reconciles of one squad
+type reconcileStreak struct {
+	failures int32
+	last     time.Time
+	// generation is the squad generation the last failure happened at
+	generation int64
+}
+
+// reconcileStreaks tracks the failure streaks of squads in memory. Recording them on the squad's
+// status would trigger a watch-driven reconcile right away and defeat the back-off.
+type reconcileStreaks struct {
+	mu      sync.Mutex
+	streaks map[types.NamespacedName]reconcileStreak
+}
+
+// fail counts a failed reconcile of the squad and returns its streak
+func (s *reconcileStreaks) fail(key types.NamespacedName, generation int64, now time.Time) reconcileStreak {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.streaks == nil {
+		s.streaks = map[types.NamespacedName]reconcileStreak{}
+	}
+	streak := s.streaks[key]
+	streak.failures++
+	streak.last = now
+	streak.generation = generation
+	s.streaks[key] = streak
+	return streak
+}
+
+// reset ends the squad's streak after a successful reconcile. It returns whether the squad had
+// been blocked.
+func (s *reconcileStreaks) reset(key types.NamespacedName) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	streak, ok := s.streaks[key]
+	delete(s.streaks, key)
+	return ok && streak.failures >= circuitBreakerThreshold
+}
+
+// wait returns how long a blocked squad must wait before it is reconciled again.
+// A spec change lets the squad through at once, so a fix is picked up without waiting.
+func (s *reconcileStreaks) wait(virtSquad *appsv1.VirtSquad, now time.Time) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	streak, ok := s.streaks[types.NamespacedName{Namespace: virtSquad.Namespace, Name: virtSquad.Name}]
+	if !ok || streak.failures < circuitBreakerThreshold || streak.generation != virtSquad.Generation {
+		return 0
+	}
+	return streak.last.Add(circuitBreakerDelay(streak.failures)).Sub(now)
+}
+
+// circuitBreakerDelay returns the back-off for a squad after the given number of consecutive failures
+func circuitBreakerDelay(failures int32) time.Duration {
+	delay := circuitBreakerBaseDelay
+	for i := int32(circuitBreakerThreshold); i < failures && delay < circuitBreakerMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, circuitBreakerMaxDelay)
+}
+
+// recordReconcileFailure counts a failed reconcile of the squad and blocks the squad once the
+// failures reach the threshold. Blocked squads are requeued after an exponentially increasing
+// delay rather than through the controller's rate limiter, so they cannot starve other squads.
+// The squad's status is only written when it becomes blocked.
+func (r *VirtSquadReconciler) recordReconcileFailure(ctx context.Context, req ctrl.Request, reconcileErr error) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	// Conflicts resolve themselves on the next attempt and do not count as failures
+	if errors.IsConflict(reconcileErr) {
+		return ctrl.Result{}, reconcileErr
+	}
+
+	virtSquad := &appsv1.VirtSquad{}
+	if err := r.Get(ctx, req.NamespacedName, virtSquad); err != nil {
+		return ctrl.Result{}, reconcileErr
+	}
+
+	streak := r.reconcileStreaks.fail(req.NamespacedName, virtSquad.Generation, time.Now())
+	if streak.failures < circuitBreakerThreshold {
+		return ctrl.Result{}, reconcileErr
+	}
+
+	delay := circuitBreakerDelay(streak.failures)
+	log.Error(reconcileErr, "Reconcile failed, backing off", "failures", streak.failures, "retryAfter", delay)
+
+	blocked := meta.FindStatusCondition(virtSquad.Status.Conditions, appsv1.ConditionReconcileBlocked)
+	if blocked != nil && blocked.Status == metav1.ConditionTrue && blocked.ObservedGeneration == virtSquad.Generation {
+		return ctrl.Result{RequeueAfter: delay}, nil
+	}
+
+	condition := metav1.Condition{
+		Type:               appsv1.ConditionReconcileBlocked,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonReconcileFailing,
+		Message:            fmt.Sprintf("%d consecutive reconciles failed, retrying with back-off: %v", streak.failures, reconcileErr),
+		ObservedGeneration: virtSquad.Generation,
+	}
+	meta.SetStatusCondition(&virtSquad.Status.Conditions, condition)
+	virtSquad.Status.ReconcileFailures = streak.failures
+	virtSquad.Status.LastReconcileFailureTime = &metav1.Time{Time: streak.last}
+	r.event(ctx, virtSquad, corev1.EventTypeWarning, reasonReconcileFailing, condition.Message)
+	if err := r.Status().Update(ctx, virtSquad); err != nil {
+		log.Error(err, "Failed to record reconcile failure")
+	}
+	return ctrl.Result{RequeueAfter: delay}, nil
+}
+
+// resetReconcileFailures ends the squad's failure streak after a successful reconcile, and clears
+// the block from its status when a reconcile that returned early left it behind
+func (r *VirtSquadReconciler) resetReconcileFailures(ctx context.Context, req ctrl.Request) {
+	if !r.reconcileStreaks.reset(req.NamespacedName) {
+		return
+	}
+
+	virtSquad := &appsv1.VirtSquad{}
+	if err := r.Get(ctx, req.NamespacedName, virtSquad); err != nil {
+		return
+	}
+	if clearReconcileBlocked(&virtSquad.Status) {
+		// A conflict means the reconcile already wrote the squad's status, clearing the block
+		if err := r.Status().Update(ctx, virtSquad); err != nil && !errors.IsConflict(err) {
+			logf.FromContext(ctx).Error(err, "Failed to clear reconcile failures")
+		}
+	}
+}
+
+// clearReconcileBlocked removes the block and failure count from a squad's status. It reports
+// whether there was anything to remove.
+func clearReconcileBlocked(status *appsv1.VirtSquadStatus) bool {
+	if meta.FindStatusCondition(status.Conditions, appsv1.ConditionReconcileBlocked) == nil &&
+		status.ReconcileFailures == 0 && status.LastReconcileFailureTime == nil {
+		return false
+	}
+	meta.RemoveStatusCondition(&status.Conditions, appsv1.ConditionReconcileBlocked)
+	status.ReconcileFailures = 0
+	status.LastReconcileFailureTime = nil
+	return true
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+var _ = Describe("Circuit breaker", func() {
+	key := types.NamespacedName{Namespace: "default", Name: "squad"}
+	squad := func(generation int64) *appsv1.VirtSquad {
+		virtSquad := &appsv1.VirtSquad{}
+		virtSquad.Namespace, virtSquad.Name, virtSquad.Generation = key.Namespace, key.Name, generation
+		return virtSquad
+	}
+
+	It("should only hold squads back once the failures reach the threshold", func() {
+		streaks := &reconcileStreaks{}
+		now := time.Now()
+
+		for range circuitBreakerThreshold - 1 {
+			streaks.fail(key, 1, now)
+		}
+		Expect(streaks.wait(squad(1), now)).To(BeZero())
+
+		streak := streaks.fail(key, 1, now)
+		Expect(streak.failures).To(Equal(int32(circuitBreakerThreshold)))
+		Expect(streaks.wait(squad(1), now)).To(Equal(circuitBreakerBaseDelay))
+		Expect(streaks.wait(squad(1), now.Add(circuitBreakerBaseDelay))).To(BeZero())
+
+		streaks.fail(key, 1, now)
+		Expect(streaks.wait(squad(1), now)).To(Equal(2 * circuitBreakerBaseDelay))
+	})
+
+	It("should let a spec change through at once", func() {
+		streaks := &reconcileStreaks{}
+		for range circuitBreakerThreshold {
+			streaks.fail(key, 1, time.Now())
+		}
+		Expect(streaks.wait(squad(2), time.Now())).To(BeZero())
+	})
+
+	It("should end the streak on success and report whether the squad was blocked", func() {
+		streaks := &reconcileStreaks{}
+		streaks.fail(key, 1, time.Now())
+		Expect(streaks.reset(key)).To(BeFalse())
+
+		for range circuitBreakerThreshold {
+			streaks.fail(key, 1, time.Now())
+		}
+		Expect(streaks.reset(key)).To(BeTrue())
+		Expect(streaks.wait(squad(1), time.Now())).To(BeZero())
+		Expect(streaks.fail(key, 1, time.Now()).failures).To(Equal(int32(1)))
+	})
+
+	It("should cap the back-off", func() {
+		Expect(circuitBreakerDelay(circuitBreakerThreshold)).To(Equal(circuitBreakerBaseDelay))
+		Expect(circuitBreakerDelay(100)).To(Equal(circuitBreakerMaxDelay))
+	})
+
+	It("should only touch the status of squads that were blocked", func() {
+		status := &appsv1.VirtSquadStatus{}
+		Expect(clearReconcileBlocked(status)).To(BeFalse())
+		Expect(status.Conditions).To(BeEmpty())
+
+		status.ReconcileFailures = 3
+		status.LastReconcileFailureTime = &metav1.Time{Time: time.Now()}
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:   appsv1.ConditionReconcileBlocked,
+			Status: metav1.ConditionTrue,
+			Reason: reasonReconcileFailing,
+		})
+		Expect(clearReconcileBlocked(status)).To(BeTrue())
+		Expect(status.Conditions).To(BeEmpty())
+		Expect(status.ReconcileFailures).To(BeZero())
+		Expect(status.LastReconcileFailureTime).To(BeNil())
+	})
+})
@@ -0,0 +1,382 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// reasonSquadConflict is the event reason used when a target namespace already holds a
+	// VirtSquad of the same name that the ClusterVirtSquad does not own
+	reasonSquadConflict = "SquadConflict"
+	// reasonNamespaceConflict is the event reason used when the namespace to provision already
+	// exists and was not created by the ClusterVirtSquad
+	reasonNamespaceConflict = "NamespaceConflict"
+	// reasonAllSquadsReady is reported when the squad of every target namespace is Ready
+	reasonAllSquadsReady = "AllSquadsReady"
+	// reasonSquadsNotReady is reported while the squad of some target namespace is not Ready
+	reasonSquadsNotReady = "SquadsNotReady"
+)
+
+// ClusterVirtSquadReconciler materializes ClusterVirtSquads as VirtSquads in their target namespaces
+type ClusterVirtSquadReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// Paused halts all creates, updates and deletes of squads while status keeps being refreshed
+	Paused bool
+
+	// ResyncJitter is the window the periodic resync of ClusterVirtSquads is spread over; zero resyncs
+	// them all at once
+	ResyncJitter time.Duration
+}
+
+// +kubebuilder:rbac:groups=apps.mshort55.io,resources=clustervirtsquads,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.mshort55.io,resources=clustervirtsquads/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile creates, updates and deletes the VirtSquads of a ClusterVirtSquad so that each target
+// namespace runs one squad from its template, and aggregates their status
+func (r *ClusterVirtSquadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	clusterSquad := &appsv1.ClusterVirtSquad{}
+	if err := r.Get(ctx, req.NamespacedName, clusterSquad); err != nil {
+		// The materialized squads and provisioned namespace are garbage collected through their owner references
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if clusterSquad.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	targets, err := r.targetNamespaces(ctx, clusterSquad)
+	if err != nil {
+		log.Error(err, "Failed to resolve target namespaces")
+		return ctrl.Result{}, err
+	}
+
+	if !r.Paused {
+		if err := r.deleteProvisionedNamespaces(ctx, clusterSquad, targets); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	existing := &appsv1.VirtSquadList{}
+	if err := r.List(ctx, existing, client.MatchingLabels{appsv1.ClusterVirtSquadLabel: clusterSquad.Name}); err != nil {
+		log.Error(err, "Failed to list materialized squads")
+		return ctrl.Result{}, err
+	}
+
+	squads := map[string]*appsv1.VirtSquad{}
+	for i := range existing.Items {
+		virtSquad := &existing.Items[i]
+		if !metav1.IsControlledBy(virtSquad, clusterSquad) {
+			continue
+		}
+		if targets[virtSquad.Namespace] {
+			squads[virtSquad.Namespace] = virtSquad
+			continue
+		}
+		if r.Paused {
+			continue
+		}
+		log.Info("Deleting squad from namespace that is no longer targeted", "namespace", virtSquad.Namespace)
+		if err := r.Delete(ctx, virtSquad); err != nil && !errors.IsNotFound(err) {
+			log.Error(err, "Failed to delete squad", "namespace", virtSquad.Namespace)
+			return ctrl.Result{}, err
+		}
+	}
+
+	if !r.Paused {
+		for namespace := range targets {
+			virtSquad, err := r.materializeSquad(ctx, clusterSquad, namespace)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if virtSquad != nil {
+				squads[namespace] = virtSquad
+			}
+		}
+	}
+
+	return ctrl.Result{}, r.updateClusterSquadStatus(ctx, clusterSquad, squads)
+}
+
+// targetNamespaces returns the existing namespaces a ClusterVirtSquad targets
+func (r *ClusterVirtSquadReconciler) targetNamespaces(ctx context.Context, clusterSquad *appsv1.ClusterVirtSquad) (map[string]bool, error) {
+	targets := map[string]bool{}
+	if clusterSquad.Spec.ProvisionNamespace != nil {
+		namespace, err := r.provisionNamespace(ctx, clusterSquad)
+		if err != nil || namespace == "" {
+			return targets, err
+		}
+		targets[namespace] = true
+		return targets, nil
+	}
+	if clusterSquad.Spec.TargetNamespace != "" {
+		namespace := &corev1.Namespace{}
+		if err := r.Get(ctx, client.ObjectKey{Name: clusterSquad.Spec.TargetNamespace}, namespace); err != nil {
+			return targets, client.IgnoreNotFound(err)
+		}
+		if namespace.DeletionTimestamp == nil {
+			targets[namespace.Name] = true
+		}
+		return targets, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(clusterSquad.Spec.NamespaceSelector)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := &corev1.NamespaceList{}
+	if err := r.List(ctx, namespaces, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	for _, namespace := range namespaces.Items {
+		if namespace.DeletionTimestamp == nil {
+			targets[namespace.Name] = true
+		}
+	}
+	return targets, nil
+}
+
+// provisionNamespace creates or updates the namespace a ClusterVirtSquad provisions and owns, and
+// returns its name. It returns an empty name without an error when the namespace is being
+// deleted, or exists without being owned by the ClusterVirtSquad, in which case it is left alone.
+func (r *ClusterVirtSquadReconciler) provisionNamespace(ctx context.Context, clusterSquad *appsv1.ClusterVirtSquad) (string, error) {
+	log := logf.FromContext(ctx)
+	spec := clusterSquad.Spec.ProvisionNamespace
+
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: cmp.Or(spec.Name, clusterSquad.Name)},
+	}
+	err := r.Get(ctx, client.ObjectKeyFromObject(namespace), namespace)
+	switch {
+	case errors.IsNotFound(err):
+		if r.Paused {
+			return "", nil
+		}
+	case err != nil:
+		log.Error(err, "Failed to get namespace", "namespace", namespace.Name)
+		return "", err
+	case !metav1.IsControlledBy(namespace, clusterSquad):
+		r.Recorder.AnnotatedEventf(clusterSquad, reconcileAnnotations(ctx), corev1.EventTypeWarning, reasonNamespaceConflict,
+			"Namespace %s already exists and was not created by this ClusterVirtSquad", namespace.Name)
+		return "", nil
+	case namespace.DeletionTimestamp != nil:
+		return "", nil
+	case r.Paused:
+		return namespace.Name, nil
+	}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, namespace, func() error {
+		if namespace.Labels == nil {
+			namespace.Labels = map[string]string{}
+		}
+		for key, value := range spec.Labels {
+			namespace.Labels[key] = value
+		}
+		namespace.Labels[appsv1.ClusterVirtSquadLabel] = clusterSquad.Name
+		if len(spec.Annotations) > 0 && namespace.Annotations == nil {
+			namespace.Annotations = map[string]string{}
+		}
+		for key, value := range spec.Annotations {
+			namespace.Annotations[key] = value
+		}
+		return controllerutil.SetControllerReference(clusterSquad, namespace, r.Scheme)
+	})
+	if err != nil {
+		log.Error(err, "Failed to reconcile namespace", "namespace", namespace.Name)
+		return "", err
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Info("Reconciled provisioned namespace", "namespace", namespace.Name, "operation", result)
+	}
+	return namespace.Name, nil
+}
+
+// deleteProvisionedNamespaces deletes the namespaces the ClusterVirtSquad provisioned that it
+// no longer targets, e.g. after switching to an existing namespace
+func (r *ClusterVirtSquadReconciler) deleteProvisionedNamespaces(ctx context.Context, clusterSquad *appsv1.ClusterVirtSquad, targets map[string]bool) error {
+	log := logf.FromContext(ctx)
+
+	namespaces := &corev1.NamespaceList{}
+	if err := r.List(ctx, namespaces, client.MatchingLabels{appsv1.ClusterVirtSquadLabel: clusterSquad.Name}); err != nil {
+		log.Error(err, "Failed to list provisioned namespaces")
+		return err
+	}
+	for i := range namespaces.Items {
+		namespace := &namespaces.Items[i]
+		if targets[namespace.Name] || !metav1.IsControlledBy(namespace, clusterSquad) || namespace.DeletionTimestamp != nil {
+			continue
+		}
+		log.Info("Deleting provisioned namespace that is no longer targeted", "namespace", namespace.Name)
+		if err := r.Delete(ctx, namespace); err != nil && !errors.IsNotFound(err) {
+			log.Error(err, "Failed to delete provisioned namespace", "namespace", namespace.Name)
+			return err
+		}
+	}
+	return nil
+}
+
+// materializeSquad creates or updates the VirtSquad of a ClusterVirtSquad in a target namespace.
+// It returns nil without an error when the namespace holds a squad of the same name that the
+// ClusterVirtSquad does not own, which is left alone.
+func (r *ClusterVirtSquadReconciler) materializeSquad(ctx context.Context, clusterSquad *appsv1.ClusterVirtSquad, namespace string) (*appsv1.VirtSquad, error) {
+	log := logf.FromContext(ctx)
+
+	virtSquad := &appsv1.VirtSquad{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterSquad.Name, Namespace: namespace},
+	}
+	err := r.Get(ctx, client.ObjectKeyFromObject(virtSquad), virtSquad)
+	switch {
+	case errors.IsNotFound(err):
+	case err != nil:
+		log.Error(err, "Failed to get squad", "namespace", namespace)
+		return nil, err
+	case !metav1.IsControlledBy(virtSquad, clusterSquad):
+		r.Recorder.AnnotatedEventf(clusterSquad, reconcileAnnotations(ctx), corev1.EventTypeWarning, reasonSquadConflict,
+			"Namespace %s already has a VirtSquad named %s that is not managed by this ClusterVirtSquad",
+			namespace, virtSquad.Name)
+		return nil, nil
+	}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, virtSquad, func() error {
+		if virtSquad.Labels == nil {
+			virtSquad.Labels = map[string]string{}
+		}
+		virtSquad.Labels[appsv1.ClusterVirtSquadLabel] = clusterSquad.Name
+		virtSquad.Spec = *clusterSquad.Spec.Template.DeepCopy()
+		// The ClusterVirtSquad already picks the namespaces; its squads are not replicated further
+		virtSquad.Spec.TargetNamespaces = nil
+		virtSquad.Spec.NamespaceSelector = nil
+		// A provisioned namespace belongs to the squad alone, so it is given a quota by default
+		if clusterSquad.Spec.ProvisionNamespace != nil && virtSquad.Spec.NamespaceQuota == nil {
+			virtSquad.Spec.NamespaceQuota = &appsv1.NamespaceQuotaSpec{}
+		}
+		return controllerutil.SetControllerReference(clusterSquad, virtSquad, r.Scheme)
+	})
+	if err != nil {
+		log.Error(err, "Failed to reconcile squad", "namespace", namespace)
+		return nil, err
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Info("Reconciled squad", "namespace", namespace, "operation", result)
+	}
+	return virtSquad, nil
+}
+
+// updateClusterSquadStatus aggregates the status of the materialized squads into the
+// ClusterVirtSquad's status
+func (r *ClusterVirtSquadReconciler) updateClusterSquadStatus(ctx context.Context, clusterSquad *appsv1.ClusterVirtSquad, squads map[string]*appsv1.VirtSquad) error {
+	status := clusterSquad.Status.DeepCopy()
+	status.Namespaces = make([]appsv1.ClusterVirtSquadNamespaceStatus, 0, len(squads))
+	status.ReadyNamespaces = 0
+	var notReady []string
+	for namespace, virtSquad := range squads {
+		ready := meta.IsStatusConditionTrue(virtSquad.Status.Conditions, appsv1.ConditionReady)
+		status.Namespaces = append(status.Namespaces, appsv1.ClusterVirtSquadNamespaceStatus{
+			Namespace:   namespace,
+			ReadyPods:   virtSquad.Status.ReadyPods,
+			DesiredPods: virtSquad.Status.DesiredPods,
+			Ready:       ready,
+		})
+		if ready {
+			status.ReadyNamespaces++
+		} else {
+			notReady = append(notReady, namespace)
+		}
+	}
+	sort.Slice(status.Namespaces, func(i, j int) bool {
+		return status.Namespaces[i].Namespace < status.Namespaces[j].Namespace
+	})
+	sort.Strings(notReady)
+	status.TargetNamespaces = int32(len(status.Namespaces))
+	status.ObservedGeneration = clusterSquad.Generation
+
+	condition := metav1.Condition{
+		Type:               appsv1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonAllSquadsReady,
+		Message:            "The squad of every target namespace is ready",
+		ObservedGeneration: clusterSquad.Generation,
+	}
+	if len(notReady) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonSquadsNotReady
+		condition.Message = "Squads not ready in namespaces " + strings.Join(notReady, ", ")
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+
+	if equality.Semantic.DeepEqual(&clusterSquad.Status, status) {
+		return nil
+	}
+	clusterSquad.Status = *status
+	if err := r.Status().Update(ctx, clusterSquad); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to update ClusterVirtSquad status")
+		return err
+	}
+	return nil
+}
+
+// clusterSquadsForNamespace returns a reconcile request for every ClusterVirtSquad that selects
+// namespaces by label, since a namespace change can add or remove a target
+func (r *ClusterVirtSquadReconciler) clusterSquadsForNamespace(ctx context.Context, _ client.Object) []reconcile.Request {
+	clusterSquads := &appsv1.ClusterVirtSquadList{}
+	if err := r.List(ctx, clusterSquads); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list ClusterVirtSquads")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range clusterSquads.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&clusterSquads.Items[i])})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterVirtSquadReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	blder := ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1.ClusterVirtSquad{})
+	return withJitteredResync(blder, mgr, &appsv1.ClusterVirtSquad{}, r.ResyncJitter).
+		Owns(&appsv1.VirtSquad{}).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.clusterSquadsForNamespace)).
+		Named("clustervirtsquad").
+		Complete(r)
+}
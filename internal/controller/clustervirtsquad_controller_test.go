@@ -0,0 +1,151 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+var _ = Describe("ClusterVirtSquad Controller", func() {
+	const resourceName = "test-cluster-squad"
+
+	ctx := context.Background()
+
+	namespace := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	newClusterSquad := func(spec appsv1.ClusterVirtSquadSpec) *appsv1.ClusterVirtSquad {
+		spec.Template = appsv1.VirtSquadSpec{
+			Oksana: &appsv1.TeamMemberSpec{Name: ptr.To("oksana-pod"), Replicas: ptr.To(int32(1))},
+		}
+		return &appsv1.ClusterVirtSquad{ObjectMeta: metav1.ObjectMeta{Name: resourceName}, Spec: spec}
+	}
+	reconcileClusterSquad := func(objs ...client.Object) client.Client {
+		c, scheme := newFakeClient(objs...)
+		controllerReconciler := &ClusterVirtSquadReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: resourceName}})
+		Expect(err).NotTo(HaveOccurred())
+		return c
+	}
+	squadNamespaces := func(c client.Client) []string {
+		squads := &appsv1.VirtSquadList{}
+		Expect(c.List(ctx, squads, client.MatchingLabels{appsv1.ClusterVirtSquadLabel: resourceName})).To(Succeed())
+		var namespaces []string
+		for _, virtSquad := range squads.Items {
+			namespaces = append(namespaces, virtSquad.Namespace)
+		}
+		return namespaces
+	}
+
+	It("should materialize the squad in the target namespace", func() {
+		clusterSquad := newClusterSquad(appsv1.ClusterVirtSquadSpec{TargetNamespace: "team"})
+		c := reconcileClusterSquad(clusterSquad, namespace("team", nil), namespace("other", nil))
+
+		virtSquad := &appsv1.VirtSquad{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: "team", Name: resourceName}, virtSquad)).To(Succeed())
+		Expect(virtSquad.Labels).To(HaveKeyWithValue(appsv1.ClusterVirtSquadLabel, resourceName))
+		Expect(metav1.IsControlledBy(virtSquad, clusterSquad)).To(BeTrue())
+		Expect(virtSquad.Spec.Oksana.Name).To(HaveValue(Equal("oksana-pod")))
+		Expect(squadNamespaces(c)).To(ConsistOf("team"))
+
+		stored := &appsv1.ClusterVirtSquad{}
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(clusterSquad), stored)).To(Succeed())
+		Expect(stored.Status.TargetNamespaces).To(Equal(int32(1)))
+	})
+
+	It("should skip a target namespace that does not exist", func() {
+		c := reconcileClusterSquad(newClusterSquad(appsv1.ClusterVirtSquadSpec{TargetNamespace: "missing"}))
+		Expect(squadNamespaces(c)).To(BeEmpty())
+	})
+
+	It("should target the namespaces matching the selector that are not being deleted", func() {
+		terminating := namespace("terminating", map[string]string{"team": "squad"})
+		terminating.DeletionTimestamp = ptr.To(metav1.Now())
+		terminating.Finalizers = []string{"kubernetes"}
+		clusterSquad := newClusterSquad(appsv1.ClusterVirtSquadSpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "squad"}},
+		})
+
+		c := reconcileClusterSquad(clusterSquad,
+			namespace("first", map[string]string{"team": "squad"}),
+			namespace("second", map[string]string{"team": "squad"}),
+			namespace("unlabeled", nil),
+			terminating,
+		)
+		Expect(squadNamespaces(c)).To(ConsistOf("first", "second"))
+	})
+
+	It("should delete its squads from namespaces that are no longer targeted", func() {
+		clusterSquad := newClusterSquad(appsv1.ClusterVirtSquadSpec{TargetNamespace: "team"})
+		_, scheme := newFakeClient()
+		stale := &appsv1.VirtSquad{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "old", Name: resourceName,
+			Labels: map[string]string{appsv1.ClusterVirtSquadLabel: resourceName},
+		}}
+		Expect(controllerutil.SetControllerReference(clusterSquad, stale, scheme)).To(Succeed())
+
+		c := reconcileClusterSquad(clusterSquad, namespace("team", nil), namespace("old", nil), stale)
+		Expect(squadNamespaces(c)).To(ConsistOf("team"))
+	})
+
+	It("should leave a squad of the same name it does not own alone", func() {
+		foreign := &appsv1.VirtSquad{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team", Name: resourceName},
+			Spec:       appsv1.VirtSquadSpec{Oksana: &appsv1.TeamMemberSpec{Name: ptr.To("someone-else")}},
+		}
+		c := reconcileClusterSquad(newClusterSquad(appsv1.ClusterVirtSquadSpec{TargetNamespace: "team"}),
+			namespace("team", nil), foreign)
+
+		virtSquad := &appsv1.VirtSquad{}
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(foreign), virtSquad)).To(Succeed())
+		Expect(virtSquad.Labels).NotTo(HaveKey(appsv1.ClusterVirtSquadLabel))
+		Expect(virtSquad.Spec.Oksana.Name).To(HaveValue(Equal("someone-else")))
+	})
+
+	It("should provision a namespace owned by the ClusterVirtSquad", func() {
+		clusterSquad := newClusterSquad(appsv1.ClusterVirtSquadSpec{
+			ProvisionNamespace: &appsv1.ProvisionNamespaceSpec{
+				Name:   "provisioned-squad",
+				Labels: map[string]string{"team": "squad"},
+			},
+		})
+		c := reconcileClusterSquad(clusterSquad)
+
+		provisioned := &corev1.Namespace{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: "provisioned-squad"}, provisioned)).To(Succeed())
+		Expect(provisioned.Labels).To(HaveKeyWithValue("team", "squad"))
+		Expect(metav1.IsControlledBy(provisioned, clusterSquad)).To(BeTrue())
+
+		virtSquad := &appsv1.VirtSquad{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: "provisioned-squad", Name: resourceName}, virtSquad)).To(Succeed())
+		// A provisioned namespace belongs to the squad alone, so it gets a quota by default
+		Expect(virtSquad.Spec.NamespaceQuota).NotTo(BeNil())
+	})
+})
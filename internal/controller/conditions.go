@@ -0,0 +1,227 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// reasonCrashLoopBackOff is reported when a container keeps crashing after start
+	reasonCrashLoopBackOff = "CrashLoopBackOff"
+	// reasonImagePullBackOff is reported when a container image cannot be pulled
+	reasonImagePullBackOff = "ImagePullBackOff"
+	// reasonPodsHealthy is reported when no pod is in a failing waiting state
+	reasonPodsHealthy = "PodsHealthy"
+	// reasonMembersHealthy is reported when no team member is degraded
+	reasonMembersHealthy = "MembersHealthy"
+	// reasonAllPodsReady is reported when every desired pod runs the current spec and is ready
+	reasonAllPodsReady = "AllPodsReady"
+	// reasonPodsNotReady is reported while desired pods are missing or not ready
+	reasonPodsNotReady = "PodsNotReady"
+)
+
+// degradedWaitingReasons lists the container waiting reasons that mark a member as degraded
+var degradedWaitingReasons = map[string]bool{
+	reasonCrashLoopBackOff: true,
+	reasonImagePullBackOff: true,
+}
+
+// memberStatus returns the status entry for a team member, creating it if needed
+func memberStatus(status *appsv1.VirtSquadStatus, memberName string) *appsv1.MemberStatus {
+	for i := range status.Members {
+		if status.Members[i].Name == memberName {
+			return &status.Members[i]
+		}
+	}
+	status.Members = append(status.Members, appsv1.MemberStatus{Name: memberName})
+	return &status.Members[len(status.Members)-1]
+}
+
+// removeMemberStatus drops the status entry for a team member that is no longer configured
+func removeMemberStatus(status *appsv1.VirtSquadStatus, memberName string) {
+	for i := range status.Members {
+		if status.Members[i].Name == memberName {
+			status.Members = append(status.Members[:i], status.Members[i+1:]...)
+			return
+		}
+	}
+}
+
+// podWaitingReason returns the first degraded waiting reason found on the pod's containers
+func podWaitingReason(pod *corev1.Pod) string {
+	statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		if cs.State.Waiting != nil && degradedWaitingReasons[cs.State.Waiting.Reason] {
+			return cs.State.Waiting.Reason
+		}
+	}
+	return ""
+}
+
+// updateMemberDegraded sets the member's Degraded condition from its pods' container states.
+// It returns true when the member has just transitioned into the degraded state.
+func updateMemberDegraded(virtSquad *appsv1.VirtSquad, member *appsv1.MemberStatus, pods []corev1.Pod) bool {
+	podsByReason := map[string][]string{}
+	for i := range pods {
+		if reason := podWaitingReason(&pods[i]); reason != "" {
+			podsByReason[reason] = append(podsByReason[reason], pods[i].Name)
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:               appsv1.ConditionDegraded,
+		Status:             metav1.ConditionFalse,
+		Reason:             reasonPodsHealthy,
+		Message:            "No pods are in a failing waiting state",
+		ObservedGeneration: virtSquad.Generation,
+	}
+
+	if len(podsByReason) > 0 {
+		reasons := make([]string, 0, len(podsByReason))
+		for reason := range podsByReason {
+			reasons = append(reasons, reason)
+		}
+		sort.Strings(reasons)
+
+		messages := make([]string, 0, len(reasons))
+		for _, reason := range reasons {
+			messages = append(messages, fmt.Sprintf("%s: %s", reason, strings.Join(podsByReason[reason], ", ")))
+		}
+
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = reasons[0]
+		condition.Message = strings.Join(messages, "; ")
+	}
+
+	wasDegraded := meta.IsStatusConditionTrue(member.Conditions, appsv1.ConditionDegraded)
+	meta.SetStatusCondition(&member.Conditions, condition)
+	return !wasDegraded && condition.Status == metav1.ConditionTrue
+}
+
+// updateSquadDegraded aggregates the members' Degraded conditions into the squad's Degraded condition.
+// It returns true when the squad has just transitioned into the degraded state.
+func updateSquadDegraded(virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus) bool {
+	condition := metav1.Condition{
+		Type:               appsv1.ConditionDegraded,
+		Status:             metav1.ConditionFalse,
+		Reason:             reasonMembersHealthy,
+		Message:            "No team members are degraded",
+		ObservedGeneration: virtSquad.Generation,
+	}
+
+	var messages []string
+	for _, member := range status.Members {
+		memberCondition := meta.FindStatusCondition(member.Conditions, appsv1.ConditionDegraded)
+		if memberCondition == nil || memberCondition.Status != metav1.ConditionTrue {
+			continue
+		}
+		if condition.Status != metav1.ConditionTrue {
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = memberCondition.Reason
+		}
+		messages = append(messages, fmt.Sprintf("%s: %s", member.Name, memberCondition.Message))
+	}
+	if len(messages) > 0 {
+		condition.Message = strings.Join(messages, "; ")
+	}
+
+	wasDegraded := meta.IsStatusConditionTrue(status.Conditions, appsv1.ConditionDegraded)
+	meta.SetStatusCondition(&status.Conditions, condition)
+	return !wasDegraded && condition.Status == metav1.ConditionTrue
+}
+
+// updateSquadReady sets the squad's Ready condition along with the kstatus Reconciling and
+// Stalled conditions, so GitOps tools can assess the squad's health without custom checks
+func updateSquadReady(virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus) {
+	ready := metav1.Condition{
+		Type:               appsv1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonAllPodsReady,
+		Message:            fmt.Sprintf("%d of %d pods are ready", status.ReadyPods, status.DesiredPods),
+		ObservedGeneration: virtSquad.Generation,
+	}
+	if status.ReadyPods < status.DesiredPods || status.TotalPods != status.DesiredPods {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = reasonPodsNotReady
+	}
+
+	var stalled []string
+	stalledReason := ""
+	for _, member := range status.Members {
+		if condition := meta.FindStatusCondition(member.Conditions, appsv1.ConditionFailed); condition != nil && condition.Status == metav1.ConditionTrue {
+			stalled = append(stalled, fmt.Sprintf("%s: %s", member.Name, condition.Message))
+			stalledReason = cmp.Or(stalledReason, condition.Reason)
+			continue
+		}
+		condition := meta.FindStatusCondition(member.Conditions, appsv1.ConditionProgressing)
+		if condition == nil {
+			continue
+		}
+		switch {
+		case condition.Reason == reasonCanaryAborted || condition.Reason == reasonProgressDeadlineExceeded:
+			stalled = append(stalled, fmt.Sprintf("%s: %s", member.Name, condition.Message))
+			stalledReason = cmp.Or(stalledReason, condition.Reason)
+		case condition.Status == metav1.ConditionTrue && ready.Status == metav1.ConditionTrue:
+			ready.Status = metav1.ConditionFalse
+			ready.Reason = condition.Reason
+			ready.Message = fmt.Sprintf("%s: %s", member.Name, condition.Message)
+		}
+	}
+
+	reconciling := metav1.Condition{
+		Type:               appsv1.ConditionReconciling,
+		Status:             metav1.ConditionFalse,
+		Reason:             ready.Reason,
+		Message:            ready.Message,
+		ObservedGeneration: virtSquad.Generation,
+	}
+	stalledCondition := metav1.Condition{
+		Type:               appsv1.ConditionStalled,
+		Status:             metav1.ConditionFalse,
+		Reason:             ready.Reason,
+		Message:            ready.Message,
+		ObservedGeneration: virtSquad.Generation,
+	}
+	switch {
+	case len(stalled) > 0:
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = stalledReason
+		ready.Message = strings.Join(stalled, "; ")
+		stalledCondition.Status = metav1.ConditionTrue
+		stalledCondition.Reason = stalledReason
+		stalledCondition.Message = ready.Message
+	case ready.Status == metav1.ConditionFalse:
+		reconciling.Status = metav1.ConditionTrue
+		reconciling.Reason = ready.Reason
+		reconciling.Message = ready.Message
+	}
+
+	meta.SetStatusCondition(&status.Conditions, ready)
+	meta.SetStatusCondition(&status.Conditions, reconciling)
+	meta.SetStatusCondition(&status.Conditions, stalledCondition)
+}
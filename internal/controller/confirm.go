@@ -0,0 +1,163 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// reasonAwaitingApproval is reported while a spec change waits for the approval annotation
+	reasonAwaitingApproval = "AwaitingApproval"
+	// reasonChangesApproved is reported once the pending spec change was approved
+	reasonChangesApproved = "ChangesApproved"
+)
+
+// awaitingApproval reports whether the squad's current generation is held until it is approved.
+// The status' observed generation only catches up once a generation has been acted on.
+func awaitingApproval(virtSquad *appsv1.VirtSquad) bool {
+	return ptr.Deref(virtSquad.Spec.ConfirmChanges, false) &&
+		virtSquad.Generation != virtSquad.Status.ObservedGeneration &&
+		virtSquad.Annotations[appsv1.ApproveGenerationAnnotation] != strconv.FormatInt(virtSquad.Generation, 10)
+}
+
+// previewMemberChanges returns what reconciling a team member would do to its pods, given the
+// number of pods it should run
+func (r *VirtSquadReconciler) previewMemberChanges(ctx context.Context, virtSquad *appsv1.VirtSquad, memberName string, memberSpec *appsv1.TeamMemberSpec, totalReplicas int32) (appsv1.MemberChangePreview, error) {
+	preview := appsv1.MemberChangePreview{Name: memberName}
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList,
+		client.InNamespace(virtSquad.Namespace),
+		client.MatchingLabels(memberLabels(virtSquad, memberName)),
+	); err != nil {
+		return preview, err
+	}
+	var pods []corev1.Pod
+	for _, pod := range podList.Items {
+		if pod.DeletionTimestamp == nil {
+			pods = append(pods, pod)
+		}
+	}
+
+	if memberSpec == nil || memberSpec.Name == nil {
+		preview.Delete = int32(len(pods))
+		return preview, nil
+	}
+
+	templateHash, err := computeHash(memberPodSpec(virtSquad, memberName, memberSpec))
+	if err != nil {
+		return preview, err
+	}
+	outdated := int32(0)
+	for i := range pods {
+		if !isPodUpdated(&pods[i], templateHash) {
+			outdated++
+		}
+	}
+
+	// Scaling down removes outdated pods first, so only the outdated pods left are replaced
+	current := int32(len(pods))
+	preview.Create = max(totalReplicas-current, 0)
+	preview.Delete = max(current-totalReplicas, 0)
+	preview.Replace = max(outdated-preview.Delete, 0)
+	return preview, nil
+}
+
+// reconcileConfirmation holds the squad's spec change until it is approved when the squad
+// confirms changes, publishing a preview of the change in status instead of acting on it.
+// It returns true while the change is held; the status has been written then.
+func (r *VirtSquadReconciler) reconcileConfirmation(ctx context.Context, virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	if !awaitingApproval(virtSquad) {
+		if status.PendingChanges != nil {
+			r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonChangesApproved,
+				"Applying approved changes of generation %d", virtSquad.Generation)
+		}
+		status.PendingChanges = nil
+		meta.RemoveStatusCondition(&status.Conditions, appsv1.ConditionChangesPending)
+		return false, nil
+	}
+
+	budget, err := r.newPodBudget(ctx, virtSquad)
+	if err != nil {
+		log.Error(err, "Failed to evaluate the pod budget")
+		return false, err
+	}
+	pending := &appsv1.PendingChanges{Generation: virtSquad.Generation}
+	var summary []string
+	for _, member := range squadMembers(virtSquad, status) {
+		totalReplicas := int32(0)
+		if member.spec != nil && member.spec.Name != nil {
+			totalReplicas = r.desiredReplicas(virtSquad, status, member.name, member.spec, budget) +
+				r.standbyReplicas(virtSquad, status, member.name, member.spec, budget)
+		}
+		preview, err := r.previewMemberChanges(ctx, virtSquad, member.name, member.spec, totalReplicas)
+		if err != nil {
+			log.Error(err, "Failed to preview changes", "member", member.name)
+			return false, err
+		}
+		if preview.Create == 0 && preview.Delete == 0 && preview.Replace == 0 {
+			continue
+		}
+		pending.Members = append(pending.Members, preview)
+		summary = append(summary, fmt.Sprintf("%s: %d to create, %d to delete, %d to replace",
+			member.name, preview.Create, preview.Delete, preview.Replace))
+	}
+
+	message := fmt.Sprintf("Generation %d leaves all pods unchanged", virtSquad.Generation)
+	if len(summary) > 0 {
+		message = fmt.Sprintf("Generation %d changes pods (%s)", virtSquad.Generation, strings.Join(summary, "; "))
+	}
+	message += fmt.Sprintf("; set the %s annotation to %d to apply it", appsv1.ApproveGenerationAnnotation, virtSquad.Generation)
+	if meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               appsv1.ConditionChangesPending,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonAwaitingApproval,
+		Message:            message,
+		ObservedGeneration: virtSquad.Generation,
+	}) {
+		r.event(ctx, virtSquad, corev1.EventTypeNormal, reasonAwaitingApproval, message)
+	}
+	status.PendingChanges = pending
+
+	// Refetch the latest version to avoid resource version conflicts
+	latest := &appsv1.VirtSquad{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(virtSquad), latest); err != nil {
+		log.Error(err, "Failed to refetch VirtSquad for status update")
+		return false, err
+	}
+	latest.Status = *status
+	if err := r.Status().Update(ctx, latest); err != nil {
+		log.Error(err, "Failed to update VirtSquad status")
+		return false, err
+	}
+	return true, nil
+}
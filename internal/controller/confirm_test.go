@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+var _ = Describe("Confirming changes", func() {
+	ctx := context.Background()
+
+	newSquad := func() *appsv1.VirtSquad {
+		return &appsv1.VirtSquad{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "squad", Generation: 1},
+			Spec: appsv1.VirtSquadSpec{
+				Oksana:         &appsv1.TeamMemberSpec{Name: ptr.To("oksana"), Replicas: ptr.To(int32(2))},
+				ConfirmChanges: ptr.To(true),
+			},
+		}
+	}
+
+	DescribeTable("awaitingApproval",
+		func(confirm bool, generation, observed int64, approved string, want bool) {
+			virtSquad := newSquad()
+			virtSquad.Spec.ConfirmChanges = ptr.To(confirm)
+			virtSquad.Generation = generation
+			virtSquad.Status.ObservedGeneration = observed
+			if approved != "" {
+				virtSquad.Annotations = map[string]string{appsv1.ApproveGenerationAnnotation: approved}
+			}
+			Expect(awaitingApproval(virtSquad)).To(Equal(want))
+		},
+		Entry("a new squad", true, int64(1), int64(0), "", true),
+		Entry("a changed squad", true, int64(3), int64(2), "", true),
+		Entry("an approved change", true, int64(3), int64(2), "3", false),
+		Entry("an approval of an earlier generation", true, int64(3), int64(2), "2", true),
+		Entry("a squad whose generation was acted on", true, int64(3), int64(3), "", false),
+		Entry("a squad that does not confirm changes", false, int64(3), int64(2), "", false),
+	)
+
+	It("should create nothing for a new squad until its first generation is approved", func() {
+		virtSquad := newSquad()
+		r, c := newFakeReconciler(virtSquad)
+		request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(virtSquad)}
+		pods := func() []corev1.Pod {
+			podList := &corev1.PodList{}
+			Expect(c.List(ctx, podList, client.InNamespace("default"))).To(Succeed())
+			return podList.Items
+		}
+
+		for range 2 {
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(pods()).To(BeEmpty())
+		services := &corev1.ServiceList{}
+		Expect(c.List(ctx, services, client.InNamespace("default"))).To(Succeed())
+		Expect(services.Items).To(BeEmpty())
+
+		stored := &appsv1.VirtSquad{}
+		Expect(c.Get(ctx, request.NamespacedName, stored)).To(Succeed())
+		Expect(meta.IsStatusConditionTrue(stored.Status.Conditions, appsv1.ConditionChangesPending)).To(BeTrue())
+		Expect(stored.Status.PendingChanges).To(Equal(&appsv1.PendingChanges{
+			Generation: 1,
+			Members:    []appsv1.MemberChangePreview{{Name: "oksana", Create: 2}},
+		}))
+
+		stored.Annotations = map[string]string{appsv1.ApproveGenerationAnnotation: strconv.FormatInt(stored.Generation, 10)}
+		Expect(c.Update(ctx, stored)).To(Succeed())
+		_, err := r.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pods()).To(HaveLen(2))
+
+		Expect(c.Get(ctx, request.NamespacedName, stored)).To(Succeed())
+		Expect(stored.Status.PendingChanges).To(BeNil())
+		Expect(meta.FindStatusCondition(stored.Status.Conditions, appsv1.ConditionChangesPending)).To(BeNil())
+	})
+})
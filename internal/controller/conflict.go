@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// reasonOwnershipConflict is reported when the squad's pods collide with those of a squad
+	// created before it, so it is not reconciled
+	reasonOwnershipConflict = "OwnershipConflict"
+	// reasonConflictWon is reported when a squad created later collides with the squad's pods,
+	// which the squad keeps reconciling
+	reasonConflictWon = "ConflictWon"
+	// reasonNoConflict is reported when no other squad collides with the squad's pods
+	reasonNoConflict = "NoConflict"
+)
+
+// squadPrecedes reports whether squad a was created before squad b, and so wins conflicts
+// between them. Squads created in the same second are ordered by name.
+func squadPrecedes(a, b *appsv1.VirtSquad) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Name < b.Name
+}
+
+// reconcileConflicts sets the squad's Conflict condition from the other squads of the namespace
+// whose pods collide with its own. A squad losing a conflict to one created before it is not
+// reconciled: its status is updated and true is returned.
+func (r *VirtSquadReconciler) reconcileConflicts(ctx context.Context, virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	squads := &appsv1.VirtSquadList{}
+	if err := r.List(ctx, squads, client.InNamespace(virtSquad.Namespace)); err != nil {
+		log.Error(err, "Failed to list squads")
+		return false, err
+	}
+
+	var lost, won []string
+	for i := range squads.Items {
+		other := &squads.Items[i]
+		if other.Name == virtSquad.Name || other.DeletionTimestamp != nil {
+			continue
+		}
+		collisions := virtSquad.Spec.Collisions(&other.Spec)
+		if len(collisions) == 0 {
+			continue
+		}
+		description := fmt.Sprintf("squad %s: %s", other.Name, strings.Join(collisions, ", "))
+		if squadPrecedes(other, virtSquad) {
+			lost = append(lost, description)
+		} else {
+			won = append(won, description)
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:               appsv1.ConditionConflict,
+		Status:             metav1.ConditionFalse,
+		Reason:             reasonNoConflict,
+		Message:            "No other squad collides with the squad's pods",
+		ObservedGeneration: virtSquad.Generation,
+	}
+	switch {
+	case len(lost) > 0:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = reasonOwnershipConflict
+		condition.Message = fmt.Sprintf("The squad's pods collide with those of squads created before it, which keep them (%s); the squad is not reconciled until the conflict is resolved",
+			strings.Join(lost, "; "))
+	case len(won) > 0:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = reasonConflictWon
+		condition.Message = fmt.Sprintf("Squads created after this one collide with its pods (%s); this squad was created first and keeps reconciling them",
+			strings.Join(won, "; "))
+	}
+	if meta.SetStatusCondition(&status.Conditions, condition) && condition.Status == metav1.ConditionTrue {
+		r.event(ctx, virtSquad, corev1.EventTypeWarning, condition.Reason, condition.Message)
+	}
+	if len(lost) == 0 {
+		return false, nil
+	}
+
+	// Refetch the latest version to avoid resource version conflicts
+	latest := &appsv1.VirtSquad{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(virtSquad), latest); err != nil {
+		log.Error(err, "Failed to refetch VirtSquad for status update")
+		return false, err
+	}
+	latest.Status = *status
+	if err := r.Status().Update(ctx, latest); err != nil {
+		log.Error(err, "Failed to update VirtSquad status")
+		return false, err
+	}
+	return true, nil
+}
+
+// conflictingSquads maps a squad to the other squads of its namespace that collide with it or
+// report a conflict, so they notice conflicts arising and being resolved
+func (r *VirtSquadReconciler) conflictingSquads(ctx context.Context, obj client.Object) []reconcile.Request {
+	changed, ok := obj.(*appsv1.VirtSquad)
+	if !ok {
+		return nil
+	}
+	squads := &appsv1.VirtSquadList{}
+	if err := r.List(ctx, squads, client.InNamespace(obj.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list squads", "namespace", obj.GetNamespace())
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range squads.Items {
+		virtSquad := &squads.Items[i]
+		if virtSquad.Name == changed.Name {
+			continue
+		}
+		if meta.IsStatusConditionTrue(virtSquad.Status.Conditions, appsv1.ConditionConflict) ||
+			len(virtSquad.Spec.Collisions(&changed.Spec)) > 0 {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(virtSquad)})
+		}
+	}
+	return requests
+}
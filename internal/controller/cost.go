@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+	"github.com/mshort55/virtsquad-operator/internal/quota"
+)
+
+// Keys of the price table ConfigMap
+const (
+	// priceCPUKey is the monthly price of one CPU core requested
+	priceCPUKey = "cpu"
+	// priceMemoryKey is the monthly price of one GiB of memory requested
+	priceMemoryKey = "memory"
+	// pricePodKey is a flat monthly price per pod
+	pricePodKey = "pod"
+	// priceCurrencyKey is the currency the prices are given in; it defaults to USD
+	priceCurrencyKey = "currency"
+)
+
+// priceTable holds the monthly prices used to estimate what team members cost
+type priceTable struct {
+	cpu      float64
+	memory   float64
+	pod      float64
+	currency string
+}
+
+// loadPriceTable reads the price table ConfigMap. It returns nil when no price table is
+// configured or it cannot be read, in which case costs are not estimated.
+func (r *VirtSquadReconciler) loadPriceTable(ctx context.Context) *priceTable {
+	log := logf.FromContext(ctx)
+
+	if r.PriceTable.Name == "" {
+		return nil
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, r.PriceTable, configMap); err != nil {
+		if !errors.IsNotFound(err) {
+			log.Error(err, "Failed to get price table", "configMap", r.PriceTable.String())
+		}
+		return nil
+	}
+
+	table := &priceTable{currency: "USD"}
+	if currency := configMap.Data[priceCurrencyKey]; currency != "" {
+		table.currency = currency
+	}
+	for key, price := range map[string]*float64{priceCPUKey: &table.cpu, priceMemoryKey: &table.memory, pricePodKey: &table.pod} {
+		value, ok := configMap.Data[key]
+		if !ok {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed < 0 {
+			log.Info("Ignoring invalid price", "configMap", r.PriceTable.String(), "key", key, "value", value)
+			continue
+		}
+		*price = parsed
+	}
+	return table
+}
+
+// monthlyCost estimates what replicas pods of a team member cost per month from the CPU and
+// memory their container requests, falling back to the limits when no requests are set, plus
+// the pod overhead of the member's RuntimeClass
+func (t *priceTable) monthlyCost(memberSpec *appsv1.TeamMemberSpec, overhead corev1.ResourceList, replicas int32) float64 {
+	requests := quota.PodRequests(memberSpec, overhead)
+	cpu, memory := requests[corev1.ResourceCPU], requests[corev1.ResourceMemory]
+	perPod := t.pod + cpu.AsApproximateFloat64()*t.cpu + memory.AsApproximateFloat64()/(1<<30)*t.memory
+	return perPod * float64(replicas)
+}
+
+// formatCost renders a monthly cost for the squad status
+func (t *priceTable) formatCost(cost float64) string {
+	return fmt.Sprintf("%.2f %s", cost, t.currency)
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// grafanaDashboardLabel is the label the Grafana sidecar loads dashboards from by default
+	grafanaDashboardLabel = "grafana_dashboard"
+	// grafanaFolderAnnotation is the annotation the Grafana sidecar reads the dashboard folder from
+	grafanaFolderAnnotation = "grafana_folder"
+)
+
+// grafanaDashboard is the subset of the Grafana dashboard model the operator renders
+type grafanaDashboard struct {
+	UID           string            `json:"uid"`
+	Title         string            `json:"title"`
+	Tags          []string          `json:"tags"`
+	SchemaVersion int               `json:"schemaVersion"`
+	Refresh       string            `json:"refresh"`
+	Time          grafanaTimeRange  `json:"time"`
+	Templating    grafanaTemplating `json:"templating"`
+	Panels        []grafanaPanel    `json:"panels"`
+}
+
+// grafanaTimeRange is the dashboard's default time range
+type grafanaTimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// grafanaTemplating holds the dashboard's variables
+type grafanaTemplating struct {
+	List []grafanaVariable `json:"list"`
+}
+
+// grafanaVariable is a dashboard variable
+type grafanaVariable struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Type  string `json:"type"`
+	Query string `json:"query"`
+}
+
+// grafanaPanel is a row or time series panel of the dashboard
+type grafanaPanel struct {
+	ID         int                `json:"id"`
+	Type       string             `json:"type"`
+	Title      string             `json:"title"`
+	GridPos    grafanaGridPos     `json:"gridPos"`
+	Datasource *grafanaDatasource `json:"datasource,omitempty"`
+	Targets    []grafanaTarget    `json:"targets,omitempty"`
+}
+
+// grafanaGridPos places a panel on the dashboard's 24 column grid
+type grafanaGridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// grafanaDatasource references the data source a panel queries
+type grafanaDatasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+// grafanaTarget is a Prometheus query of a panel
+type grafanaTarget struct {
+	RefID        string `json:"refId"`
+	Expr         string `json:"expr"`
+	LegendFormat string `json:"legendFormat"`
+}
+
+// dashboardConfigMapName returns the name of the ConfigMap holding a squad's Grafana dashboard
+func dashboardConfigMapName(virtSquad *appsv1.VirtSquad) string {
+	return virtSquad.Name + "-dashboard"
+}
+
+// renderDashboard renders the squad's Grafana dashboard: a row per configured team member with
+// its desired and ready replicas and its restarts, OOMKills and recreations
+func renderDashboard(virtSquad *appsv1.VirtSquad) (string, error) {
+	uid, err := computeHash(virtSquad.Namespace + "/" + virtSquad.Name)
+	if err != nil {
+		return "", err
+	}
+	dashboard := grafanaDashboard{
+		UID:           "virtsquad-" + uid,
+		Title:         fmt.Sprintf("VirtSquad %s/%s", virtSquad.Namespace, virtSquad.Name),
+		Tags:          []string{"virtsquad"},
+		SchemaVersion: 39,
+		Refresh:       "30s",
+		Time:          grafanaTimeRange{From: "now-6h", To: "now"},
+		Templating: grafanaTemplating{List: []grafanaVariable{
+			{Name: "datasource", Label: "Data source", Type: "datasource", Query: "prometheus"},
+		}},
+	}
+	datasource := &grafanaDatasource{Type: "prometheus", UID: "${datasource}"}
+
+	id, y := 0, 0
+	for _, member := range squadMembers(virtSquad, &appsv1.VirtSquadStatus{}) {
+		if member.spec == nil || member.spec.Name == nil {
+			continue
+		}
+		selector := fmt.Sprintf(`namespace=%q,squad=%q,member=%q`, virtSquad.Namespace, virtSquad.Name, member.name)
+		id++
+		dashboard.Panels = append(dashboard.Panels, grafanaPanel{
+			ID:      id,
+			Type:    "row",
+			Title:   fmt.Sprintf("%s (%s)", *member.spec.Name, member.name),
+			GridPos: grafanaGridPos{H: 1, W: 24, X: 0, Y: y},
+		})
+		y++
+		id++
+		dashboard.Panels = append(dashboard.Panels, grafanaPanel{
+			ID:         id,
+			Type:       "timeseries",
+			Title:      "Replicas",
+			GridPos:    grafanaGridPos{H: 8, W: 12, X: 0, Y: y},
+			Datasource: datasource,
+			Targets: []grafanaTarget{
+				{RefID: "A", Expr: fmt.Sprintf("virtsquad_member_desired_replicas{%s}", selector), LegendFormat: "desired"},
+				{RefID: "B", Expr: fmt.Sprintf("virtsquad_member_ready_replicas{%s}", selector), LegendFormat: "ready"},
+			},
+		})
+		id++
+		dashboard.Panels = append(dashboard.Panels, grafanaPanel{
+			ID:         id,
+			Type:       "timeseries",
+			Title:      "Health",
+			GridPos:    grafanaGridPos{H: 8, W: 12, X: 12, Y: y},
+			Datasource: datasource,
+			Targets: []grafanaTarget{
+				{RefID: "A", Expr: fmt.Sprintf("virtsquad_member_container_restarts{%s}", selector), LegendFormat: "container restarts"},
+				{RefID: "B", Expr: fmt.Sprintf("virtsquad_member_oom_killed_containers{%s}", selector), LegendFormat: "OOMKilled containers"},
+				{RefID: "C", Expr: fmt.Sprintf("increase(virtsquad_member_recreations_total{%s}[1h])", selector), LegendFormat: "recreations per hour"},
+			},
+		})
+		y += 8
+	}
+
+	data, err := json.MarshalIndent(dashboard, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// reconcileDashboard creates or updates the ConfigMap holding the squad's Grafana dashboard, or
+// deletes it when the squad no longer asks for one. The sidecar reloads the dashboard whenever
+// the ConfigMap changes, so it follows the squad's members.
+func (r *VirtSquadReconciler) reconcileDashboard(ctx context.Context, virtSquad *appsv1.VirtSquad) error {
+	log := logf.FromContext(ctx)
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dashboardConfigMapName(virtSquad),
+			Namespace: virtSquad.Namespace,
+		},
+	}
+
+	if virtSquad.Spec.Dashboard == nil {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(configMap), configMap); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if err := r.Delete(ctx, configMap); err != nil && !errors.IsNotFound(err) {
+			log.Error(err, "Failed to delete dashboard ConfigMap", "configMap", configMap.Name)
+			return err
+		}
+		return nil
+	}
+
+	dashboard, err := renderDashboard(virtSquad)
+	if err != nil {
+		return err
+	}
+	spec := virtSquad.Spec.Dashboard
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Labels = map[string]string{squadLabel: virtSquad.Name}
+		if len(spec.Labels) == 0 {
+			configMap.Labels[grafanaDashboardLabel] = "1"
+		}
+		for key, value := range spec.Labels {
+			configMap.Labels[key] = value
+		}
+
+		if configMap.Annotations == nil {
+			configMap.Annotations = map[string]string{}
+		}
+		delete(configMap.Annotations, grafanaFolderAnnotation)
+		if spec.Folder != "" {
+			configMap.Annotations[grafanaFolderAnnotation] = spec.Folder
+		}
+
+		configMap.Data = map[string]string{
+			fmt.Sprintf("%s-%s.json", virtSquad.Namespace, virtSquad.Name): dashboard,
+		}
+		return controllerutil.SetControllerReference(virtSquad, configMap, r.Scheme)
+	})
+	if err != nil {
+		log.Error(err, "Failed to reconcile dashboard ConfigMap", "configMap", configMap.Name)
+		return err
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Info("Reconciled dashboard ConfigMap", "configMap", configMap.Name, "operation", result)
+	}
+	return nil
+}
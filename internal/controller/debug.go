@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// defaultDebugImage is used for debug containers when neither the operator nor the squad sets one
+	defaultDebugImage = "busybox:latest"
+	// maxDebugContainerHistory bounds the number of debug containers listed in status
+	maxDebugContainerHistory = 10
+	// reasonDebugContainerInjected is the event reason used when a debug container was added to a pod
+	reasonDebugContainerInjected = "DebugContainerInjected"
+	// reasonDebugContainerRejected is the event reason used when a debug request cannot be honoured
+	reasonDebugContainerRejected = "DebugContainerRejected"
+)
+
+// injectDebugContainer handles the debug-pod annotation by adding an ephemeral debug container
+// to the requested squad pod. The annotations are removed once the request has been handled,
+// whether or not it succeeded, so that a bad request is not retried forever.
+func (r *VirtSquadReconciler) injectDebugContainer(ctx context.Context, virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus) error {
+	log := logf.FromContext(ctx)
+
+	podName, ok := virtSquad.Annotations[appsv1.DebugPodAnnotation]
+	if !ok {
+		return nil
+	}
+
+	image := r.DebugImage
+	if override := virtSquad.Annotations[appsv1.DebugImageAnnotation]; override != "" {
+		image = override
+	}
+	if image == "" {
+		image = defaultDebugImage
+	}
+
+	pod := &corev1.Pod{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: virtSquad.Namespace, Name: podName}, pod)
+	switch {
+	case errors.IsNotFound(err) || (err == nil && pod.Labels[squadLabel] != virtSquad.Name):
+		r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonDebugContainerRejected,
+			"Pod %s is not part of this squad", podName)
+	case err != nil:
+		log.Error(err, "Failed to get pod for debugging", "pod", podName)
+		return err
+	default:
+		targetContainer := pod.Labels[memberLabel]
+		if targetContainer == "" {
+			targetContainer = pod.Labels[quarantinedLabel]
+		}
+		containerName := fmt.Sprintf("debugger-%d", len(pod.Spec.EphemeralContainers))
+		pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, corev1.EphemeralContainer{
+			EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+				Name:  containerName,
+				Image: image,
+				Stdin: true,
+				TTY:   true,
+			},
+			TargetContainerName: targetContainer,
+		})
+
+		log.Info("Injecting debug container", "pod", podName, "container", containerName, "image", image)
+		if err := r.SubResource("ephemeralcontainers").Update(ctx, pod); err != nil {
+			log.Error(err, "Failed to inject debug container", "pod", podName)
+			r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonDebugContainerRejected,
+				"Failed to inject debug container into pod %s: %v", podName, err)
+		} else {
+			status.DebugContainers = append(status.DebugContainers, podName+"/"+containerName)
+			if len(status.DebugContainers) > maxDebugContainerHistory {
+				status.DebugContainers = status.DebugContainers[len(status.DebugContainers)-maxDebugContainerHistory:]
+			}
+			r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonDebugContainerInjected,
+				"Injected debug container %s into pod %s; attach with: kubectl attach -it -n %s %s -c %s",
+				containerName, podName, virtSquad.Namespace, podName, containerName)
+		}
+	}
+
+	delete(virtSquad.Annotations, appsv1.DebugPodAnnotation)
+	delete(virtSquad.Annotations, appsv1.DebugImageAnnotation)
+	return r.Update(ctx, virtSquad)
+}
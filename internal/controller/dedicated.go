@@ -0,0 +1,220 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// dedicatedLabel marks the nodes claimed by a squad, and is also the key of their taint
+	dedicatedLabel = "virtsquad.mshort55.io/dedicated"
+	// dedicatableLabel set to true by an administrator marks the nodes squads may claim
+	dedicatableLabel = "virtsquad.mshort55.io/dedicatable"
+
+	// reasonNodeClaimed is the event reason used when a node is claimed for the squad
+	reasonNodeClaimed = "NodeClaimed"
+	// reasonNodeReleased is the event reason used when a claimed node is released
+	reasonNodeReleased = "NodeReleased"
+)
+
+// dedicatedOwner returns the value of the dedicated label and taint identifying the squad.
+// Label values are limited to 63 characters, so long names are hashed.
+func dedicatedOwner(virtSquad *appsv1.VirtSquad) string {
+	owner := virtSquad.Namespace + "." + virtSquad.Name
+	if len(owner) <= 63 {
+		return owner
+	}
+	hash, _ := computeHash(owner)
+	return "squad-" + hash
+}
+
+// dedicatedTaint returns the taint keeping other pods off the squad's dedicated nodes
+func dedicatedTaint(virtSquad *appsv1.VirtSquad) corev1.Taint {
+	return corev1.Taint{Key: dedicatedLabel, Value: dedicatedOwner(virtSquad), Effect: corev1.TaintEffectNoSchedule}
+}
+
+// dedicatedNodePlacement makes the pod spec tolerate the squad's dedicated node taint and
+// run only on its dedicated nodes
+func dedicatedNodePlacement(virtSquad *appsv1.VirtSquad, podSpec *corev1.PodSpec) {
+	if virtSquad.Spec.DedicatedNodes == nil {
+		return
+	}
+	taint := dedicatedTaint(virtSquad)
+	podSpec.Tolerations = append(podSpec.Tolerations, corev1.Toleration{
+		Key:      taint.Key,
+		Operator: corev1.TolerationOpEqual,
+		Value:    taint.Value,
+		Effect:   taint.Effect,
+	})
+	if podSpec.NodeSelector == nil {
+		podSpec.NodeSelector = map[string]string{}
+	}
+	podSpec.NodeSelector[dedicatedLabel] = taint.Value
+}
+
+// reconcileDedicatedNodes claims the dedicatable nodes selected for the squad that no other
+// squad has claimed, and releases the nodes it claimed that are no longer selected. The claimed nodes are
+// recorded in the status.
+func (r *VirtSquadReconciler) reconcileDedicatedNodes(ctx context.Context, virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus) error {
+	log := logf.FromContext(ctx)
+	owner := dedicatedOwner(virtSquad)
+
+	claimed := &corev1.NodeList{}
+	if err := r.List(ctx, claimed, client.MatchingLabels{dedicatedLabel: owner}); err != nil {
+		log.Error(err, "Failed to list dedicated nodes")
+		return err
+	}
+	selected := &corev1.NodeList{}
+	switch {
+	case virtSquad.Spec.DedicatedNodes == nil:
+	case !r.AllowDedicatedNodes:
+		// Squads admitted before the operator stopped allowing dedicated nodes release theirs
+		log.Info("Not claiming dedicated nodes, the operator does not allow them")
+	default:
+		// Squads may only claim the nodes an administrator set aside, never arbitrary ones
+		selector := maps.Clone(virtSquad.Spec.DedicatedNodes.NodeSelector)
+		selector[dedicatableLabel] = "true"
+		if err := r.List(ctx, selected, client.MatchingLabels(selector)); err != nil {
+			log.Error(err, "Failed to list nodes to dedicate")
+			return err
+		}
+	}
+
+	var nodes []string
+	wanted := map[string]bool{}
+	for i := range selected.Items {
+		node := &selected.Items[i]
+		switch current, ok := node.Labels[dedicatedLabel]; {
+		case ok && current != owner:
+			log.Info("Skipping node claimed by another squad", "node", node.Name, "owner", current)
+			continue
+		case !ok:
+			if err := r.claimNode(ctx, virtSquad, node); err != nil {
+				return err
+			}
+		}
+		wanted[node.Name] = true
+		nodes = append(nodes, node.Name)
+	}
+	for i := range claimed.Items {
+		if !wanted[claimed.Items[i].Name] {
+			if err := r.releaseNode(ctx, virtSquad, &claimed.Items[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	slices.Sort(nodes)
+	status.DedicatedNodes = nodes
+	return nil
+}
+
+// claimNode labels and taints a node for the squad
+func (r *VirtSquadReconciler) claimNode(ctx context.Context, virtSquad *appsv1.VirtSquad, node *corev1.Node) error {
+	patch := client.MergeFromWithOptions(node.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	if node.Labels == nil {
+		node.Labels = map[string]string{}
+	}
+	node.Labels[dedicatedLabel] = dedicatedOwner(virtSquad)
+	node.Spec.Taints = append(removeDedicatedTaint(node.Spec.Taints), dedicatedTaint(virtSquad))
+	if err := r.Patch(ctx, node, patch); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to claim node", "node", node.Name)
+		return err
+	}
+	r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonNodeClaimed, "Claimed node %s for the squad's pods", node.Name)
+	return nil
+}
+
+// releaseNode removes the squad's label and taint from a node
+func (r *VirtSquadReconciler) releaseNode(ctx context.Context, virtSquad *appsv1.VirtSquad, node *corev1.Node) error {
+	patch := client.MergeFromWithOptions(node.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	delete(node.Labels, dedicatedLabel)
+	node.Spec.Taints = removeDedicatedTaint(node.Spec.Taints)
+	if err := r.Patch(ctx, node, patch); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to release node", "node", node.Name)
+		return err
+	}
+	r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonNodeReleased, "Released node %s", node.Name)
+	return nil
+}
+
+// removeDedicatedTaint returns the taints without the dedicated node taint
+func removeDedicatedTaint(taints []corev1.Taint) []corev1.Taint {
+	return slices.DeleteFunc(slices.Clone(taints), func(taint corev1.Taint) bool {
+		return taint.Key == dedicatedLabel
+	})
+}
+
+// releaseDedicatedNodes releases every node claimed for the squad, once it is deleted
+func (r *VirtSquadReconciler) releaseDedicatedNodes(ctx context.Context, virtSquad *appsv1.VirtSquad) error {
+	claimed := &corev1.NodeList{}
+	if err := r.List(ctx, claimed, client.MatchingLabels{dedicatedLabel: dedicatedOwner(virtSquad)}); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list dedicated nodes")
+		return err
+	}
+	for i := range claimed.Items {
+		if err := r.releaseNode(ctx, virtSquad, &claimed.Items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nodeLabelsChanged passes new nodes and node updates that change the node's labels, which can
+// add a node to a squad's dedicated node pool or take it out
+var nodeLabelsChanged = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return true },
+	DeleteFunc:  func(event.DeleteEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return !maps.Equal(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+	},
+}
+
+// squadsDedicatingNode maps a node to the squads whose dedicated node pool selects it
+func (r *VirtSquadReconciler) squadsDedicatingNode(ctx context.Context, obj client.Object) []reconcile.Request {
+	squads := &appsv1.VirtSquadList{}
+	if err := r.List(ctx, squads); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list squads")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range squads.Items {
+		virtSquad := &squads.Items[i]
+		if virtSquad.Spec.DedicatedNodes == nil {
+			continue
+		}
+		if labels.SelectorFromSet(virtSquad.Spec.DedicatedNodes.NodeSelector).Matches(labels.Set(obj.GetLabels())) ||
+			obj.GetLabels()[dedicatedLabel] == dedicatedOwner(virtSquad) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(virtSquad)})
+		}
+	}
+	return requests
+}
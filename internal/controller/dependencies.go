@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// reasonDependenciesReady is reported when every team member a member depends on is ready
+	reasonDependenciesReady = "DependenciesReady"
+	// reasonWaitingForDependencies is reported while a member's pods wait for its dependencies
+	reasonWaitingForDependencies = "WaitingForDependencies"
+)
+
+// reconcileDependencies sets the member's DependenciesReady condition and reports whether its
+// pods may be created. A dependency is ready once it runs pods and all of them are ready.
+func (r *VirtSquadReconciler) reconcileDependencies(ctx context.Context, virtSquad *appsv1.VirtSquad, member *appsv1.MemberStatus, spec *appsv1.TeamMemberSpec) (bool, error) {
+	if len(spec.DependsOn) == 0 {
+		meta.RemoveStatusCondition(&member.Conditions, appsv1.ConditionDependenciesReady)
+		return true, nil
+	}
+
+	var waiting []string
+	for _, dependency := range spec.DependsOn {
+		if dependencySpec := memberSpec(virtSquad, dependency); dependencySpec == nil || dependencySpec.Name == nil {
+			waiting = append(waiting, fmt.Sprintf("%s is not configured", dependency))
+			continue
+		}
+		pods := &corev1.PodList{}
+		if err := r.List(ctx, pods,
+			client.InNamespace(virtSquad.Namespace),
+			client.MatchingLabels(memberLabels(virtSquad, dependency)),
+		); err != nil {
+			return false, err
+		}
+		total, ready := 0, 0
+		for i := range pods.Items {
+			if pods.Items[i].DeletionTimestamp != nil {
+				continue
+			}
+			total++
+			if isPodReady(&pods.Items[i]) && smokeTested(virtSquad, &pods.Items[i]) {
+				ready++
+			}
+		}
+		if total == 0 || ready < total {
+			waiting = append(waiting, fmt.Sprintf("%s has %d of %d pods ready", dependency, ready, total))
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:               appsv1.ConditionDependenciesReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonDependenciesReady,
+		Message:            fmt.Sprintf("All pods of %s are ready", strings.Join(spec.DependsOn, ", ")),
+		ObservedGeneration: virtSquad.Generation,
+	}
+	if len(waiting) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonWaitingForDependencies
+		condition.Message = "Waiting for dependencies: " + strings.Join(waiting, "; ")
+	}
+	meta.SetStatusCondition(&member.Conditions, condition)
+	return len(waiting) == 0, nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+// reasonNodeDrained is the event reason used when a pod on a cordoned node has been replaced
+const reasonNodeDrained = "NodeDrained"
+
+// cordonedNodes returns the names of the nodes running any of the pods that are marked
+// unschedulable, as they are when cordoned ahead of a drain
+func (r *VirtSquadReconciler) cordonedNodes(ctx context.Context, pods []corev1.Pod) (map[string]bool, error) {
+	cordoned := map[string]bool{}
+	checked := map[string]bool{}
+	for _, pod := range pods {
+		nodeName := pod.Spec.NodeName
+		if nodeName == "" || checked[nodeName] {
+			continue
+		}
+		checked[nodeName] = true
+
+		node := &corev1.Node{}
+		if err := r.Get(ctx, types.NamespacedName{Name: nodeName}, node); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if node.Spec.Unschedulable {
+			cordoned[nodeName] = true
+		}
+	}
+	return cordoned, nil
+}
+
+// replaceDrainingPods splits off the member's pods on cordoned nodes so that replacements are
+// created before the pods are evicted. Once the member's other pods are ready in full, the pods
+// on cordoned nodes are deleted. It returns the pods that are not on cordoned nodes.
+func (r *VirtSquadReconciler) replaceDrainingPods(ctx context.Context, virtSquad *appsv1.VirtSquad, memberName string, pods []corev1.Pod, totalReplicas int32) ([]corev1.Pod, error) {
+	log := logf.FromContext(ctx)
+
+	cordoned, err := r.cordonedNodes(ctx, pods)
+	if err != nil || len(cordoned) == 0 {
+		return pods, err
+	}
+
+	remaining := make([]corev1.Pod, 0, len(pods))
+	var draining []corev1.Pod
+	ready := int32(0)
+	for _, pod := range pods {
+		if cordoned[pod.Spec.NodeName] {
+			draining = append(draining, pod)
+			continue
+		}
+		remaining = append(remaining, pod)
+		if isPodReady(&pod) && smokeTested(virtSquad, &pod) {
+			ready++
+		}
+	}
+
+	// Keep the pods on cordoned nodes serving until their replacements are ready
+	if ready < totalReplicas {
+		return remaining, nil
+	}
+	for i := range draining {
+		log.Info("Deleting pod on cordoned node", "pod", draining[i].Name, "node", draining[i].Spec.NodeName, "member", memberName)
+		if err := r.deletePod(ctx, virtSquad, &draining[i], deleteReasonNodeDrain); err != nil {
+			log.Error(err, "Failed to delete pod on cordoned node", "pod", draining[i].Name)
+			return remaining, err
+		}
+		r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonNodeDrained,
+			"Replaced pod %s of team member %s on cordoned node %s", draining[i].Name, memberName, draining[i].Spec.NodeName)
+	}
+	return remaining, nil
+}
+
+// nodeCordoned passes node updates that change whether the node is schedulable
+var nodeCordoned = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldNode, ok := e.ObjectOld.(*corev1.Node)
+		if !ok {
+			return false
+		}
+		newNode, ok := e.ObjectNew.(*corev1.Node)
+		return ok && oldNode.Spec.Unschedulable != newNode.Spec.Unschedulable
+	},
+}
+
+// squadsOnNode maps a node to the squads running pods on it
+func (r *VirtSquadReconciler) squadsOnNode(ctx context.Context, obj client.Object) []reconcile.Request {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.MatchingLabels{appLabel: appLabelValue}); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list squad pods", "node", obj.GetName())
+		return nil
+	}
+	seen := map[types.NamespacedName]bool{}
+	var requests []reconcile.Request
+	for _, pod := range pods.Items {
+		key := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Labels[squadLabel]}
+		if pod.Spec.NodeName != obj.GetName() || key.Name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		requests = append(requests, reconcile.Request{NamespacedName: key})
+	}
+	return requests
+}
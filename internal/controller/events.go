@@ -0,0 +1,50 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+// reconcileAnnotations returns the annotations that tie an object to the reconcile creating it.
+// controller-runtime adds the same ID to the logger as the reconcileID field.
+func reconcileAnnotations(ctx context.Context) map[string]string {
+	reconcileID := controller.ReconcileIDFromContext(ctx)
+	if reconcileID == "" {
+		return nil
+	}
+	return map[string]string{appsv1.ReconcileIDAnnotation: string(reconcileID)}
+}
+
+// event records an event annotated with the ID of the current reconcile and adds it to the
+// squad's history
+func (r *VirtSquadReconciler) event(ctx context.Context, object runtime.Object, eventType, reason, message string) {
+	r.Recorder.AnnotatedEventf(object, reconcileAnnotations(ctx), eventType, reason, "%s", message)
+	recordHistoryAction(ctx, eventType, reason, message)
+}
+
+// eventf records a formatted event annotated with the ID of the current reconcile and adds it
+// to the squad's history
+func (r *VirtSquadReconciler) eventf(ctx context.Context, object runtime.Object, eventType, reason, messageFmt string, args ...interface{}) {
+	r.event(ctx, object, eventType, reason, fmt.Sprintf(messageFmt, args...))
+}
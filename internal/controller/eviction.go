@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// safeToEvictAnnotation tells the cluster autoscaler whether it may evict a pod to remove its node
+	safeToEvictAnnotation = "cluster-autoscaler.kubernetes.io/safe-to-evict"
+	// deschedulerEvictAnnotation lets the descheduler evict a pod it would otherwise skip
+	deschedulerEvictAnnotation = "descheduler.alpha.kubernetes.io/evict"
+	// deschedulerPreventEvictionAnnotation keeps the descheduler from evicting a pod
+	deschedulerPreventEvictionAnnotation = "descheduler.alpha.kubernetes.io/prevent-eviction"
+)
+
+// evictionAnnotations returns the eviction annotations the member's pods should carry, where an
+// empty value means the annotation must be removed. Annotations missing from the map are not managed.
+func evictionAnnotations(memberSpec *appsv1.TeamMemberSpec) map[string]string {
+	if memberSpec == nil || memberSpec.Eviction == nil {
+		return nil
+	}
+	eviction := memberSpec.Eviction
+
+	annotations := map[string]string{}
+	if eviction.SafeToEvict != nil {
+		annotations[safeToEvictAnnotation] = strconv.FormatBool(*eviction.SafeToEvict)
+	}
+	switch eviction.Descheduler {
+	case appsv1.DeschedulerEvictionEvict:
+		annotations[deschedulerEvictAnnotation] = "true"
+		annotations[deschedulerPreventEvictionAnnotation] = ""
+	case appsv1.DeschedulerEvictionPrevent:
+		annotations[deschedulerEvictAnnotation] = ""
+		annotations[deschedulerPreventEvictionAnnotation] = "true"
+	}
+	return annotations
+}
+
+// applyEvictionAnnotations sets the managed eviction annotations on a pod's metadata and
+// reports whether anything changed
+func applyEvictionAnnotations(pod *corev1.Pod, annotations map[string]string) bool {
+	changed := false
+	for key, value := range annotations {
+		current, ok := pod.Annotations[key]
+		switch {
+		case value == "" && ok:
+			delete(pod.Annotations, key)
+			changed = true
+		case value != "" && current != value:
+			if pod.Annotations == nil {
+				pod.Annotations = map[string]string{}
+			}
+			pod.Annotations[key] = value
+			changed = true
+		}
+	}
+	return changed
+}
+
+// reconcileEvictionAnnotations patches the member's running pods whose eviction annotations
+// differ from the member's spec
+func (r *VirtSquadReconciler) reconcileEvictionAnnotations(ctx context.Context, memberSpec *appsv1.TeamMemberSpec, pods []corev1.Pod) error {
+	annotations := evictionAnnotations(memberSpec)
+	if len(annotations) == 0 {
+		return nil
+	}
+	for i := range pods {
+		patch := client.MergeFrom(pods[i].DeepCopy())
+		if !applyEvictionAnnotations(&pods[i], annotations) {
+			continue
+		}
+		if err := r.Patch(ctx, &pods[i], patch); err != nil {
+			logf.FromContext(ctx).Error(err, "Failed to update pod eviction annotations", "pod", pods[i].Name)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+// reasonFailedOver is the event reason used when a passive pod replaces a failed active pod
+const reasonFailedOver = "FailedOver"
+
+// activePassive reports whether the member runs a single active pod backed by passive pods
+func activePassive(memberSpec *appsv1.TeamMemberSpec) bool {
+	return memberSpec != nil && memberSpec.Failover != nil
+}
+
+// reconcileActivePod records the member's active pod, the first of servingPods. A change of
+// active pod is reported as a failover when the previous active pod is gone or no longer
+// ready; handovers to a newer revision during rollouts are only logged.
+func (r *VirtSquadReconciler) reconcileActivePod(ctx context.Context, virtSquad *appsv1.VirtSquad, member *appsv1.MemberStatus, pods []corev1.Pod, servingPods []string) {
+	log := logf.FromContext(ctx)
+
+	active := ""
+	if len(servingPods) > 0 {
+		active = servingPods[0]
+	}
+	previous := member.ActivePod
+	member.ActivePod = active
+	if previous == "" || active == "" || previous == active {
+		return
+	}
+
+	for i := range pods {
+		if pods[i].Name == previous && isPodReady(&pods[i]) {
+			log.Info("Handing over active pod", "member", member.Name, "from", previous, "to", active)
+			return
+		}
+	}
+
+	now := metav1.Now()
+	member.LastFailoverTime = &now
+	r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonFailedOver,
+		"Promoted passive pod %s to active for team member %s after pod %s failed", active, member.Name, previous)
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// reasonRecreateLimitExceeded is reported when failing pods were recreated maxRecreateAttempts times
+	reasonRecreateLimitExceeded = "RecreateLimitExceeded"
+	// reasonWithinRecreateLimit is reported while the member still has recreate attempts left
+	reasonWithinRecreateLimit = "WithinRecreateLimit"
+	// reasonRecreatingPod is the event reason used when a failing pod is replaced
+	reasonRecreatingPod = "RecreatingPod"
+)
+
+// isPodFailing reports whether a pod keeps failing and should be recreated under a failure policy
+func isPodFailing(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodFailed || podWaitingReason(pod) == reasonCrashLoopBackOff
+}
+
+// resetRecreateAttempts clears the failure policy bookkeeping of a team member
+func resetRecreateAttempts(member *appsv1.MemberStatus) {
+	member.RecreateAttempts = 0
+	member.LastRecreateTime = nil
+	meta.RemoveStatusCondition(&member.Conditions, appsv1.ConditionFailed)
+}
+
+// recreateBackoff returns the delay required before the next recreation attempt
+func recreateBackoff(policy *appsv1.FailurePolicySpec, attempts int32) time.Duration {
+	backoff := 10 * time.Second
+	if policy.BackoffSeconds != nil {
+		backoff = time.Duration(*policy.BackoffSeconds) * time.Second
+	}
+	for i := int32(1); i < attempts && backoff < time.Hour; i++ {
+		backoff *= 2
+	}
+	return backoff
+}
+
+// applyFailurePolicy deletes failing pods so they get recreated, as long as the member has
+// recreate attempts left and the backoff has elapsed. It returns the pods that are still active
+// and, when a recreation is pending on the backoff, how long to wait before trying again.
+func (r *VirtSquadReconciler) applyFailurePolicy(ctx context.Context, virtSquad *appsv1.VirtSquad, member *appsv1.MemberStatus, policy *appsv1.FailurePolicySpec, pods []corev1.Pod) ([]corev1.Pod, time.Duration, error) {
+	log := logf.FromContext(ctx)
+
+	if policy == nil {
+		meta.RemoveStatusCondition(&member.Conditions, appsv1.ConditionFailed)
+		return pods, 0, nil
+	}
+
+	// A spec change gives the member a fresh set of attempts
+	if member.ObservedGeneration != virtSquad.Generation {
+		resetRecreateAttempts(member)
+		member.ObservedGeneration = virtSquad.Generation
+	}
+
+	maxAttempts := int32(3)
+	if policy.MaxRecreateAttempts != nil {
+		maxAttempts = *policy.MaxRecreateAttempts
+	}
+
+	var failing []string
+	active := make([]corev1.Pod, 0, len(pods))
+	var failingPods []corev1.Pod
+	for _, pod := range pods {
+		if isPodFailing(&pod) {
+			failing = append(failing, pod.Name)
+			failingPods = append(failingPods, pod)
+			continue
+		}
+		active = append(active, pod)
+	}
+
+	condition := metav1.Condition{
+		Type:               appsv1.ConditionFailed,
+		Status:             metav1.ConditionFalse,
+		Reason:             reasonWithinRecreateLimit,
+		Message:            fmt.Sprintf("%d of %d recreate attempts used", member.RecreateAttempts, maxAttempts),
+		ObservedGeneration: virtSquad.Generation,
+	}
+
+	if len(failingPods) == 0 {
+		meta.SetStatusCondition(&member.Conditions, condition)
+		return pods, 0, nil
+	}
+
+	if member.RecreateAttempts >= maxAttempts {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = reasonRecreateLimitExceeded
+		condition.Message = fmt.Sprintf("Pods %s kept failing after %d recreate attempts; set the %s annotation or change the spec to retry",
+			strings.Join(failing, ", "), member.RecreateAttempts, appsv1.ResetFailuresAnnotation)
+		if meta.SetStatusCondition(&member.Conditions, condition) {
+			r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonRecreateLimitExceeded,
+				"Team member %s failed: %s", member.Name, condition.Message)
+		}
+		// Leave the failing pods in place so they can be inspected
+		return pods, 0, nil
+	}
+
+	if member.LastRecreateTime != nil {
+		if wait := time.Until(member.LastRecreateTime.Add(recreateBackoff(policy, member.RecreateAttempts))); wait > 0 {
+			meta.SetStatusCondition(&member.Conditions, condition)
+			return pods, wait, nil
+		}
+	}
+
+	for i := range failingPods {
+		log.Info("Recreating failing pod", "pod", failingPods[i].Name, "member", member.Name, "attempt", member.RecreateAttempts+1)
+		if err := r.deletePod(ctx, virtSquad, &failingPods[i], deleteReasonRecreate); err != nil {
+			log.Error(err, "Failed to delete failing pod", "pod", failingPods[i].Name)
+			return pods, 0, err
+		}
+	}
+
+	observeRecreations(virtSquad, member.Name, len(failingPods))
+	member.RecreateAttempts++
+	now := metav1.Now()
+	member.LastRecreateTime = &now
+	condition.Message = fmt.Sprintf("%d of %d recreate attempts used", member.RecreateAttempts, maxAttempts)
+	meta.SetStatusCondition(&member.Conditions, condition)
+	r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonRecreatingPod,
+		"Recreating failing pods %s for team member %s (attempt %d of %d)",
+		strings.Join(failing, ", "), member.Name, member.RecreateAttempts, maxAttempts)
+
+	return active, 0, nil
+}
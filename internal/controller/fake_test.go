@@ -0,0 +1,45 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+// newFakeClient returns a fake client holding the objects, for tests that do not need an API server
+func newFakeClient(objs ...client.Object) (client.Client, *runtime.Scheme) {
+	scheme := runtime.NewScheme()
+	Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	Expect(appsv1.AddToScheme(scheme)).To(Succeed())
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+		WithStatusSubresource(&appsv1.VirtSquad{}, &appsv1.ClusterVirtSquad{}, &appsv1.SquadFleet{}).Build()
+	return c, scheme
+}
+
+// newFakeReconciler returns a reconciler working on a fake client holding the objects. Its
+// recorder is a *record.FakeRecorder.
+func newFakeReconciler(objs ...client.Object) (*VirtSquadReconciler, client.Client) {
+	c, scheme := newFakeClient(objs...)
+	return &VirtSquadReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(10)}, c
+}
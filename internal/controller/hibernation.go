@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// reasonHibernationWindow is reported while a hibernation window is open
+	reasonHibernationWindow = "HibernationWindow"
+	// reasonOutsideHibernationWindow is reported while no hibernation window is open
+	reasonOutsideHibernationWindow = "OutsideHibernationWindow"
+	// reasonHibernatedBySpec is reported while the squad's spec asks for hibernation
+	reasonHibernatedBySpec = "HibernatedBySpec"
+	// reasonNotHibernated is reported once hibernation is turned off in the spec
+	reasonNotHibernated = "NotHibernated"
+)
+
+// hibernating reports whether the squad should run no pods, either because its spec asks for
+// hibernation or because a hibernation window is open
+func hibernating(virtSquad *appsv1.VirtSquad, now time.Time) bool {
+	return ptr.Deref(virtSquad.Spec.Hibernated, false) || inHibernationWindow(virtSquad, now)
+}
+
+// trackHibernatedReplicas remembers the pods a member runs when the squad is hibernated and,
+// once hibernation is turned off, forgets them when the member is back to that many pods. The
+// member resumes at its desired replicas rather than the remembered count, so spec or schedule
+// changes made meanwhile, the replica ceilings and the pod budget all still apply.
+func trackHibernatedReplicas(virtSquad *appsv1.VirtSquad, member *appsv1.MemberStatus, runningPods, desiredReplicas int32) {
+	if ptr.Deref(virtSquad.Spec.Hibernated, false) {
+		if member.ReplicasBeforeHibernation == nil {
+			member.ReplicasBeforeHibernation = &runningPods
+		}
+		return
+	}
+	if saved := member.ReplicasBeforeHibernation; saved != nil && runningPods >= min(*saved, desiredReplicas) {
+		member.ReplicasBeforeHibernation = nil
+	}
+}
+
+// parseHibernationWindow parses the cron expressions of a hibernation window in the time zone
+func parseHibernationWindow(timeZone string, window appsv1.HibernationWindow) (start, end cron.Schedule, err error) {
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	if start, err = cron.ParseStandard("CRON_TZ=" + timeZone + " " + window.Start); err != nil {
+		return nil, nil, err
+	}
+	if end, err = cron.ParseStandard("CRON_TZ=" + timeZone + " " + window.End); err != nil {
+		return nil, nil, err
+	}
+	return start, end, nil
+}
+
+// inHibernationWindow reports whether one of the squad's hibernation windows is open, meaning
+// its start fired more recently than its end. Windows that cannot be parsed are ignored.
+func inHibernationWindow(virtSquad *appsv1.VirtSquad, now time.Time) bool {
+	hibernation := virtSquad.Spec.Hibernation
+	if hibernation == nil {
+		return false
+	}
+	for _, window := range hibernation.Windows {
+		start, end, err := parseHibernationWindow(hibernation.TimeZone, window)
+		if err != nil {
+			continue
+		}
+		if started := lastFiring(start, now); !started.IsZero() && started.After(lastFiring(end, now)) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateHibernated sets the squad's Hibernated condition, or removes it when the squad has never
+// been hibernated and has no hibernation windows
+func updateHibernated(virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus, now time.Time) {
+	condition := metav1.Condition{
+		Type:               appsv1.ConditionHibernated,
+		Status:             metav1.ConditionFalse,
+		Reason:             reasonNotHibernated,
+		Message:            "The squad is not hibernated",
+		ObservedGeneration: virtSquad.Generation,
+	}
+	switch {
+	case ptr.Deref(virtSquad.Spec.Hibernated, false):
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = reasonHibernatedBySpec
+		condition.Message = "The spec hibernates the squad; all team members are scaled to zero"
+	case inHibernationWindow(virtSquad, now):
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = reasonHibernationWindow
+		condition.Message = "A hibernation window is open; all team members are scaled to zero"
+	case virtSquad.Spec.Hibernation != nil:
+		condition.Reason = reasonOutsideHibernationWindow
+		condition.Message = "No hibernation window is open"
+	case meta.FindStatusCondition(status.Conditions, appsv1.ConditionHibernated) == nil:
+		return
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+var _ = Describe("Hibernation", func() {
+	It("should remember the pods members ran until they run them again", func() {
+		virtSquad := &appsv1.VirtSquad{Spec: appsv1.VirtSquadSpec{Hibernated: ptr.To(true)}}
+		member := &appsv1.MemberStatus{}
+
+		trackHibernatedReplicas(virtSquad, member, 3, 0)
+		Expect(member.ReplicasBeforeHibernation).To(Equal(ptr.To(int32(3))))
+		// Scaling down while hibernated keeps the count from before
+		trackHibernatedReplicas(virtSquad, member, 0, 0)
+		Expect(member.ReplicasBeforeHibernation).To(Equal(ptr.To(int32(3))))
+
+		virtSquad.Spec.Hibernated = nil
+		trackHibernatedReplicas(virtSquad, member, 1, 3)
+		Expect(member.ReplicasBeforeHibernation).To(Equal(ptr.To(int32(3))))
+		trackHibernatedReplicas(virtSquad, member, 3, 3)
+		Expect(member.ReplicasBeforeHibernation).To(BeNil())
+	})
+
+	It("should forget the remembered pods once members run what they now ask for", func() {
+		virtSquad := &appsv1.VirtSquad{}
+		member := &appsv1.MemberStatus{ReplicasBeforeHibernation: ptr.To(int32(5))}
+
+		// The spec, a schedule, a ceiling or the pod budget lowered the member meanwhile
+		trackHibernatedReplicas(virtSquad, member, 1, 2)
+		Expect(member.ReplicasBeforeHibernation).To(Equal(ptr.To(int32(5))))
+		trackHibernatedReplicas(virtSquad, member, 2, 2)
+		Expect(member.ReplicasBeforeHibernation).To(BeNil())
+	})
+})
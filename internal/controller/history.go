@@ -0,0 +1,264 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// historyLimit is the number of entries kept in a squad's history
+	historyLimit = 50
+	// historyKey is the ConfigMap key holding the JSON list of history entries, oldest first
+	historyKey = "history"
+	// historySpecKey is the ConfigMap key holding the last recorded spec, used to diff the next change
+	historySpecKey = "spec"
+	// historyGenerationKey is the ConfigMap key holding the generation of the last recorded spec
+	historyGenerationKey = "generation"
+	// historyRepeatWindow is the number of recent entries an action is looked up in before it
+	// is recorded again
+	historyRepeatWindow = 10
+
+	// historySpecChange marks an entry recording a change of the squad's spec
+	historySpecChange = "SpecChange"
+	// historyAction marks an entry recording an action taken by the operator
+	historyAction = "Action"
+)
+
+// historyEntry is a single record of a squad's change history
+type historyEntry struct {
+	Time       metav1.Time `json:"time"`
+	Generation int64       `json:"generation"`
+	Kind       string      `json:"kind"`
+	// Manager and Operation identify who changed the spec, from the squad's managedFields
+	Manager   string   `json:"manager,omitempty"`
+	Operation string   `json:"operation,omitempty"`
+	Changes   []string `json:"changes,omitempty"`
+	// Type, Reason and Message describe the event recorded for an operator action
+	Type    string `json:"type,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// historyRecorder collects the operator actions of a single reconcile
+type historyRecorder struct {
+	actions []historyEntry
+}
+
+// historyRecorderKey is the context key of the reconcile's historyRecorder
+type historyRecorderKey struct{}
+
+// withHistoryRecorder returns a context collecting the events of the reconcile as history entries
+func withHistoryRecorder(ctx context.Context) (context.Context, *historyRecorder) {
+	recorder := &historyRecorder{}
+	return context.WithValue(ctx, historyRecorderKey{}, recorder), recorder
+}
+
+// recordHistoryAction adds an event to the reconcile's history, if it is collecting one
+func recordHistoryAction(ctx context.Context, eventType, reason, message string) {
+	recorder, ok := ctx.Value(historyRecorderKey{}).(*historyRecorder)
+	if !ok {
+		return
+	}
+	recorder.actions = append(recorder.actions, historyEntry{
+		Time:    metav1.Now(),
+		Kind:    historyAction,
+		Type:    eventType,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+// historyConfigMapName returns the name of the ConfigMap holding a squad's history
+func historyConfigMapName(virtSquad *appsv1.VirtSquad) string {
+	return virtSquad.Name + "-history"
+}
+
+// lastSpecManager returns the field manager that most recently wrote the squad, ignoring status
+// writes, as the best guess of who made a spec change
+func lastSpecManager(virtSquad *appsv1.VirtSquad) (string, string) {
+	var latest *metav1.ManagedFieldsEntry
+	for i := range virtSquad.ManagedFields {
+		entry := &virtSquad.ManagedFields[i]
+		if entry.Subresource != "" || entry.Time == nil {
+			continue
+		}
+		if latest == nil || !entry.Time.Before(latest.Time) {
+			latest = entry
+		}
+	}
+	if latest == nil {
+		return "", ""
+	}
+	return latest.Manager, string(latest.Operation)
+}
+
+// specChanges lists the spec fields that differ between two specs, down to the fields of a
+// team member, e.g. "oksana.replicas"
+func specChanges(previous, current map[string]any) []string {
+	var changes []string
+	for _, key := range unionKeys(previous, current) {
+		if reflect.DeepEqual(previous[key], current[key]) {
+			continue
+		}
+		previousFields, previousOK := previous[key].(map[string]any)
+		currentFields, currentOK := current[key].(map[string]any)
+		if !previousOK || !currentOK {
+			changes = append(changes, key)
+			continue
+		}
+		for _, field := range unionKeys(previousFields, currentFields) {
+			if !reflect.DeepEqual(previousFields[field], currentFields[field]) {
+				changes = append(changes, key+"."+field)
+			}
+		}
+	}
+	return changes
+}
+
+// unionKeys returns the sorted keys present in either map
+func unionKeys(a, b map[string]any) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// repeatedAction reports whether an action with the same reason and message was recorded
+// among the latest entries since the last spec change. Events some reconciles repeat, even
+// when several alternate, are recorded once instead of pushing older entries out.
+func repeatedAction(entries []historyEntry, action historyEntry) bool {
+	for i := len(entries) - 1; i >= max(len(entries)-historyRepeatWindow, 0); i-- {
+		if entries[i].Kind == historySpecChange {
+			return false
+		}
+		if entries[i].Reason == action.Reason && entries[i].Message == action.Message {
+			return true
+		}
+	}
+	return false
+}
+
+// recordHistory appends the reconcile's actions, and a spec change when the squad's generation
+// moved on, to the squad's history ConfigMap. History is best effort and never fails the reconcile.
+func (r *VirtSquadReconciler) recordHistory(ctx context.Context, virtSquad *appsv1.VirtSquad, recorder *historyRecorder) {
+	log := logf.FromContext(ctx)
+	if virtSquad.DeletionTimestamp != nil {
+		return
+	}
+
+	configMap := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: virtSquad.Namespace, Name: historyConfigMapName(virtSquad)}
+	err := r.Get(ctx, key, configMap)
+	if err != nil && !errors.IsNotFound(err) {
+		log.Error(err, "Failed to get history ConfigMap")
+		return
+	}
+	exists := err == nil
+
+	var entries []historyEntry
+	if data := configMap.Data[historyKey]; data != "" {
+		if err := json.Unmarshal([]byte(data), &entries); err != nil {
+			log.Error(err, "Discarding unreadable squad history")
+			entries = nil
+		}
+	}
+
+	changed := false
+	lastGeneration, _ := strconv.ParseInt(configMap.Data[historyGenerationKey], 10, 64)
+	spec, err := json.Marshal(virtSquad.Spec)
+	if err != nil {
+		log.Error(err, "Failed to encode spec for history")
+		return
+	}
+	if virtSquad.Generation > lastGeneration {
+		var previous, current map[string]any
+		_ = json.Unmarshal([]byte(configMap.Data[historySpecKey]), &previous)
+		_ = json.Unmarshal(spec, &current)
+		entry := historyEntry{
+			Time:       metav1.Now(),
+			Generation: virtSquad.Generation,
+			Kind:       historySpecChange,
+			Changes:    specChanges(previous, current),
+		}
+		entry.Manager, entry.Operation = lastSpecManager(virtSquad)
+		entries = append(entries, entry)
+		changed = true
+	}
+
+	for _, action := range recorder.actions {
+		if repeatedAction(entries, action) {
+			continue
+		}
+		action.Generation = virtSquad.Generation
+		entries = append(entries, action)
+		changed = true
+	}
+	if !changed {
+		return
+	}
+	if len(entries) > historyLimit {
+		entries = entries[len(entries)-historyLimit:]
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		log.Error(err, "Failed to encode squad history")
+		return
+	}
+	configMap.Name = key.Name
+	configMap.Namespace = key.Namespace
+	configMap.Labels = map[string]string{squadLabel: virtSquad.Name}
+	configMap.Data = map[string]string{
+		historyKey:           string(data),
+		historySpecKey:       string(spec),
+		historyGenerationKey: strconv.FormatInt(virtSquad.Generation, 10),
+	}
+	if err := controllerutil.SetControllerReference(virtSquad, configMap, r.Scheme); err != nil {
+		log.Error(err, "Failed to set owner of history ConfigMap")
+		return
+	}
+
+	if exists {
+		err = r.Update(ctx, configMap)
+	} else {
+		err = r.Create(ctx, configMap)
+	}
+	if err != nil {
+		log.Error(err, "Failed to save squad history", "configmap", key.Name)
+	}
+}
@@ -0,0 +1,346 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	appsv1k8s "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// reasonImportStarted is the event reason used when a member is generated from an imported workload
+	reasonImportStarted = "ImportStarted"
+	// reasonImported is the event reason used when an imported workload has been taken over
+	reasonImported = "Imported"
+	// reasonImportFailed is the event reason used when a workload cannot be imported
+	reasonImportFailed = "ImportFailed"
+)
+
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;update;patch
+
+// workloadImport is one entry of the import annotation
+type workloadImport struct {
+	member string
+	kind   string
+	name   string
+}
+
+// String returns the entry in the annotation's member=Kind/name format
+func (w workloadImport) String() string {
+	return fmt.Sprintf("%s=%s/%s", w.member, w.kind, w.name)
+}
+
+// unimportableError reports a workload the operator refuses to import
+type unimportableError string
+
+// Error implements error
+func (e unimportableError) Error() string {
+	return string(e)
+}
+
+// parseImports parses the import annotation, returning the valid entries and the invalid ones
+func parseImports(value string) ([]workloadImport, []string) {
+	var imports []workloadImport
+	var invalid []string
+	for entry := range strings.SplitSeq(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		member, workload, ok := strings.Cut(entry, "=")
+		kind, name, hasName := strings.Cut(workload, "/")
+		switch {
+		case !ok || !hasName || name == "":
+			invalid = append(invalid, entry)
+		case kind != "Deployment" && kind != "Pod":
+			invalid = append(invalid, entry)
+		case member != "oksana" && member != "kurtis" && member != "matt" && member != "kike":
+			invalid = append(invalid, entry)
+		default:
+			imports = append(imports, workloadImport{member: member, kind: kind, name: name})
+		}
+	}
+	return imports, invalid
+}
+
+// importedWorkload returns the pod template and replicas of an imported workload
+func (r *VirtSquadReconciler) importedWorkload(ctx context.Context, virtSquad *appsv1.VirtSquad, entry workloadImport) (*corev1.PodTemplateSpec, int32, error) {
+	key := client.ObjectKey{Namespace: virtSquad.Namespace, Name: entry.name}
+	if entry.kind == "Deployment" {
+		deployment := &appsv1k8s.Deployment{}
+		if err := r.Get(ctx, key, deployment); err != nil {
+			return nil, 0, err
+		}
+		if features := unimportableFeatures(&deployment.Spec.Template.Spec); len(features) > 0 {
+			return nil, 0, unimportableError(fmt.Sprintf("deployment %s uses %s, which team members do not support", deployment.Name, strings.Join(features, ", ")))
+		}
+		return &deployment.Spec.Template, ptr.Deref(deployment.Spec.Replicas, 1), nil
+	}
+
+	pod := &corev1.Pod{}
+	if err := r.Get(ctx, key, pod); err != nil {
+		return nil, 0, err
+	}
+	if owner := metav1.GetControllerOf(pod); owner != nil {
+		return nil, 0, unimportableError(fmt.Sprintf("pod %s is managed by %s %s; import its owner instead", pod.Name, owner.Kind, owner.Name))
+	}
+	if features := unimportableFeatures(&pod.Spec); len(features) > 0 {
+		return nil, 0, unimportableError(fmt.Sprintf("pod %s uses %s, which team members do not support", pod.Name, strings.Join(features, ", ")))
+	}
+	return &corev1.PodTemplateSpec{ObjectMeta: pod.ObjectMeta, Spec: pod.Spec}, 1, nil
+}
+
+// unimportableFeatures lists the parts of a pod template a team member cannot run, which
+// importing would silently drop
+func unimportableFeatures(spec *corev1.PodSpec) []string {
+	var features []string
+	if len(spec.Containers) > 1 || len(spec.InitContainers) > 0 {
+		features = append(features, "sidecar or init containers")
+	}
+	if len(spec.Volumes) > 0 {
+		features = append(features, "volumes")
+	}
+	if len(spec.Containers) == 0 {
+		return features
+	}
+	container := spec.Containers[0]
+	if len(container.VolumeMounts) > 0 {
+		features = append(features, "volume mounts")
+	}
+	for _, port := range container.Ports {
+		if port.ContainerPort != 80 || (port.Protocol != "" && port.Protocol != corev1.ProtocolTCP) {
+			features = append(features, "ports other than 80/TCP")
+			break
+		}
+	}
+	if container.LivenessProbe != nil || container.ReadinessProbe != nil || container.StartupProbe != nil {
+		features = append(features, "probes")
+	}
+	return features
+}
+
+// importedMemberSpec generates a team member running the workload's first container. It also
+// returns the workload's pod labels that clash with the operator's own labels and are dropped.
+func importedMemberSpec(entry workloadImport, template *corev1.PodTemplateSpec, replicas int32) (*appsv1.TeamMemberSpec, []string) {
+	spec := &appsv1.TeamMemberSpec{
+		Name:     ptr.To(entry.name),
+		Replicas: ptr.To(replicas),
+	}
+	if len(template.Spec.Containers) > 0 {
+		container := template.Spec.Containers[0]
+		spec.Image = container.Image
+		spec.Command = container.Command
+		spec.Args = container.Args
+		spec.Env = container.Env
+		if len(container.Resources.Requests) > 0 || len(container.Resources.Limits) > 0 {
+			spec.Resources = container.Resources.DeepCopy()
+		}
+	}
+	// Keep the labels the workload's Services select on, so traffic reaches the new pods too
+	var dropped []string
+	for key, value := range template.Labels {
+		switch key {
+		case appsv1k8s.DefaultDeploymentUniqueLabelKey:
+			continue
+		case appLabel, memberLabel, squadLabel, templateHashLabel, trafficLabel, roleLabel, capacityLabel, ordinalLabel:
+			dropped = append(dropped, fmt.Sprintf("%s=%s", key, value))
+			continue
+		}
+		if spec.PodLabels == nil {
+			spec.PodLabels = map[string]string{}
+		}
+		spec.PodLabels[key] = value
+	}
+	sort.Strings(dropped)
+	return spec, dropped
+}
+
+// setMemberSpec replaces the spec of the named team member
+func setMemberSpec(virtSquad *appsv1.VirtSquad, memberName string, spec *appsv1.TeamMemberSpec) {
+	switch memberName {
+	case "oksana":
+		virtSquad.Spec.Oksana = spec
+	case "kurtis":
+		virtSquad.Spec.Kurtis = spec
+	case "matt":
+		virtSquad.Spec.Matt = spec
+	case "kike":
+		virtSquad.Spec.Kike = spec
+	}
+}
+
+// memberPodsReady reports whether a team member runs at least its desired replicas of ready pods
+func (r *VirtSquadReconciler) memberPodsReady(ctx context.Context, virtSquad *appsv1.VirtSquad, memberName string, spec *appsv1.TeamMemberSpec) (bool, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(virtSquad.Namespace), client.MatchingLabels(memberLabels(virtSquad, memberName))); err != nil {
+		return false, err
+	}
+	ready := int32(0)
+	for i := range pods.Items {
+		if pods.Items[i].DeletionTimestamp == nil && isPodReady(&pods.Items[i]) {
+			ready++
+		}
+	}
+	return ready >= ptr.Deref(spec.Replicas, 1), nil
+}
+
+// retireWorkload stops an imported workload once its member has taken over: Deployments are
+// scaled to zero and kept for reference, pods are deleted
+func (r *VirtSquadReconciler) retireWorkload(ctx context.Context, virtSquad *appsv1.VirtSquad, entry workloadImport) error {
+	key := client.ObjectKey{Namespace: virtSquad.Namespace, Name: entry.name}
+	if entry.kind == "Pod" {
+		pod := &corev1.Pod{}
+		if err := r.Get(ctx, key, pod); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		return client.IgnoreNotFound(r.Delete(ctx, pod))
+	}
+
+	deployment := &appsv1k8s.Deployment{}
+	if err := r.Get(ctx, key, deployment); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	patch := client.MergeFrom(deployment.DeepCopy())
+	deployment.Spec.Replicas = ptr.To(int32(0))
+	if deployment.Annotations == nil {
+		deployment.Annotations = map[string]string{}
+	}
+	deployment.Annotations[appsv1.ImportedByAnnotation] = virtSquad.Name
+	return r.Patch(ctx, deployment, patch)
+}
+
+// reconcileImports works through the import annotation: members missing from the spec are
+// generated from their workloads, and workloads whose member's pods are all ready are retired,
+// so the squad takes over without a gap in capacity. It returns true when the squad was updated,
+// in which case the reconcile should stop and pick up the change next time.
+func (r *VirtSquadReconciler) reconcileImports(ctx context.Context, virtSquad *appsv1.VirtSquad) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	value, ok := virtSquad.Annotations[appsv1.ImportAnnotation]
+	if !ok {
+		return false, nil
+	}
+	imports, invalid := parseImports(value)
+	if len(invalid) > 0 {
+		r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonImportFailed,
+			"Ignoring import entries %s; entries must look like member=Deployment/name or member=Pod/name",
+			strings.Join(invalid, ", "))
+	}
+
+	generated := map[string]bool{}
+	for member := range strings.SplitSeq(virtSquad.Annotations[appsv1.ImportGeneratedAnnotation], ",") {
+		if member != "" {
+			generated[member] = true
+		}
+	}
+
+	changed := len(invalid) > 0
+	var pending []string
+	for _, entry := range imports {
+		member := memberSpec(virtSquad, entry.member)
+		if member != nil && !generated[entry.member] {
+			// The member was configured by hand: retiring the workload would hand its traffic
+			// to pods that were never built from it
+			r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonImportFailed,
+				"Not importing %s: team member %s is already configured", entry, entry.member)
+			changed = true
+			continue
+		}
+		if member == nil {
+			template, replicas, err := r.importedWorkload(ctx, virtSquad, entry)
+			if err != nil {
+				if _, unimportable := err.(unimportableError); !unimportable && !errors.IsNotFound(err) {
+					log.Error(err, "Failed to read imported workload", "workload", entry.String())
+					return false, err
+				}
+				r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonImportFailed, "Cannot import %s: %v", entry, err)
+				changed = true
+				continue
+			}
+			spec, dropped := importedMemberSpec(entry, template, replicas)
+			setMemberSpec(virtSquad, entry.member, spec)
+			r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonImportStarted,
+				"Generated team member %s from %s %s", entry.member, entry.kind, entry.name)
+			if len(dropped) > 0 {
+				r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonImportStarted,
+					"Pod labels %s of %s %s are reserved by the operator; Services selecting them will not reach team member %s",
+					strings.Join(dropped, ", "), entry.kind, entry.name, entry.member)
+			}
+			generated[entry.member] = true
+			changed = true
+			pending = append(pending, entry.String())
+			continue
+		}
+
+		ready, err := r.memberPodsReady(ctx, virtSquad, entry.member, member)
+		if err != nil {
+			log.Error(err, "Failed to list member pods", "member", entry.member)
+			return false, err
+		}
+		if !ready {
+			pending = append(pending, entry.String())
+			continue
+		}
+		if err := r.retireWorkload(ctx, virtSquad, entry); err != nil {
+			log.Error(err, "Failed to retire imported workload", "workload", entry.String())
+			return false, err
+		}
+		delete(generated, entry.member)
+		countOutcome(outcomeAdopted, entry.kind)
+		r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonImported,
+			"Team member %s took over from %s %s", entry.member, entry.kind, entry.name)
+		changed = true
+	}
+
+	if !changed {
+		return false, nil
+	}
+	if len(pending) > 0 {
+		virtSquad.Annotations[appsv1.ImportAnnotation] = strings.Join(pending, ",")
+	} else {
+		delete(virtSquad.Annotations, appsv1.ImportAnnotation)
+	}
+	// Members whose entry was dropped from the annotation are no longer tracked either
+	remaining := make([]string, 0, len(generated))
+	for _, entry := range imports {
+		if generated[entry.member] && slices.Contains(pending, entry.String()) {
+			remaining = append(remaining, entry.member)
+		}
+	}
+	if len(remaining) > 0 {
+		virtSquad.Annotations[appsv1.ImportGeneratedAnnotation] = strings.Join(remaining, ",")
+	} else {
+		delete(virtSquad.Annotations, appsv1.ImportGeneratedAnnotation)
+	}
+	if err := r.Update(ctx, virtSquad); err != nil {
+		return false, err
+	}
+	return true, nil
+}
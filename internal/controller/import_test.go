@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1k8s "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+var _ = Describe("Import", func() {
+	It("should parse the import annotation", func() {
+		imports, invalid := parseImports(" oksana=Deployment/web, kurtis=Pod/worker,,bogus=Pod/x,matt=StatefulSet/db,kike=Pod/,nope")
+		Expect(imports).To(Equal([]workloadImport{
+			{member: "oksana", kind: "Deployment", name: "web"},
+			{member: "kurtis", kind: "Pod", name: "worker"},
+		}))
+		Expect(invalid).To(Equal([]string{"bogus=Pod/x", "matt=StatefulSet/db", "kike=Pod/", "nope"}))
+		Expect(imports[0].String()).To(Equal("oksana=Deployment/web"))
+	})
+
+	It("should generate a team member from the workload's first container", func() {
+		template := &corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+				"tier":              "web",
+				"pod-template-hash": "abc",
+				squadLabel:          "other",
+			}},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Image:   "nginx:1.27",
+				Command: []string{"nginx"},
+				Args:    []string{"-g", "daemon off;"},
+				Env:     []corev1.EnvVar{{Name: "MODE", Value: "prod"}},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+				},
+			}}},
+		}
+
+		spec, dropped := importedMemberSpec(workloadImport{member: "oksana", kind: "Deployment", name: "web"}, template, 3)
+		Expect(*spec.Name).To(Equal("web"))
+		Expect(*spec.Replicas).To(Equal(int32(3)))
+		Expect(spec.Image).To(Equal("nginx:1.27"))
+		Expect(spec.Command).To(Equal([]string{"nginx"}))
+		Expect(spec.Args).To(Equal([]string{"-g", "daemon off;"}))
+		Expect(spec.Env).To(Equal([]corev1.EnvVar{{Name: "MODE", Value: "prod"}}))
+		Expect(spec.Resources.Requests.Cpu().String()).To(Equal("100m"))
+		Expect(spec.PodLabels).To(Equal(map[string]string{"tier": "web"}))
+		Expect(dropped).To(Equal([]string{squadLabel + "=other"}))
+	})
+
+	It("should refuse workloads whose pods a team member cannot run", func() {
+		Expect(unimportableFeatures(&corev1.PodSpec{Containers: []corev1.Container{{
+			Image: "nginx",
+			Ports: []corev1.ContainerPort{{ContainerPort: 80}},
+		}}})).To(BeEmpty())
+
+		Expect(unimportableFeatures(&corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Image:          "nginx",
+				Ports:          []corev1.ContainerPort{{ContainerPort: 8080}},
+				VolumeMounts:   []corev1.VolumeMount{{Name: "data", MountPath: "/data"}},
+				ReadinessProbe: &corev1.Probe{},
+			}, {Image: "proxy"}},
+			Volumes: []corev1.Volume{{Name: "data"}},
+		})).To(Equal([]string{"sidecar or init containers", "volumes", "volume mounts", "ports other than 80/TCP", "probes"}))
+	})
+
+	It("should leave workloads alone whose member was configured by hand without a name", func() {
+		ctx := context.Background()
+		deployment := &appsv1k8s.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec: appsv1k8s.DeploymentSpec{
+				Replicas: ptr.To(int32(2)),
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Image: "nginx:1.27"}}}},
+			},
+		}
+		virtSquad := &appsv1.VirtSquad{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "alpha",
+				Namespace:   "default",
+				Annotations: map[string]string{appsv1.ImportAnnotation: "oksana=Deployment/web"},
+			},
+			Spec: appsv1.VirtSquadSpec{Oksana: &appsv1.TeamMemberSpec{Image: "httpd:2.4"}},
+		}
+		reconciler, c := newFakeReconciler(deployment, virtSquad)
+
+		Expect(reconciler.reconcileImports(ctx, virtSquad)).To(BeTrue())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(virtSquad), virtSquad)).To(Succeed())
+		Expect(virtSquad.Spec.Oksana.Image).To(Equal("httpd:2.4"))
+		Expect(virtSquad.Spec.Oksana.Name).To(BeNil())
+		Expect(virtSquad.Annotations).NotTo(HaveKey(appsv1.ImportAnnotation))
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(deployment), deployment)).To(Succeed())
+		Expect(*deployment.Spec.Replicas).To(Equal(int32(2)))
+		Expect(reconciler.Recorder.(*record.FakeRecorder).Events).To(Receive(
+			ContainSubstring("Not importing oksana=Deployment/web: team member oksana is already configured")))
+	})
+})
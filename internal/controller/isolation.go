@@ -0,0 +1,171 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+
+// isolationName returns the name of the ServiceAccount and NetworkPolicy of a strictly isolated squad
+func isolationName(virtSquad *appsv1.VirtSquad) string {
+	return virtSquad.Name + "-isolation"
+}
+
+// isolated reports whether the squad asks for strict isolation
+func isolated(virtSquad *appsv1.VirtSquad) bool {
+	return virtSquad.Spec.Isolation == appsv1.IsolationStrict
+}
+
+// applyIsolation runs a strictly isolated squad's pods under its dedicated ServiceAccount,
+// without a service account token and with the restricted Pod Security settings. Windows pods
+// only get the settings that apply to them.
+func applyIsolation(virtSquad *appsv1.VirtSquad, memberSpec *appsv1.TeamMemberSpec, podSpec *corev1.PodSpec) {
+	if !isolated(virtSquad) {
+		return
+	}
+	podSpec.ServiceAccountName = isolationName(virtSquad)
+	podSpec.AutomountServiceAccountToken = ptr.To(false)
+	if memberSpec.OS == string(corev1.Windows) {
+		return
+	}
+	if podSpec.SecurityContext == nil {
+		podSpec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	podSpec.SecurityContext.RunAsNonRoot = ptr.To(true)
+	podSpec.SecurityContext.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+	for i := range podSpec.Containers {
+		restrictContainer(&podSpec.Containers[i])
+	}
+}
+
+// restrictContainer tightens a container's security context to the restricted Pod Security
+// settings, keeping whatever else it already sets, such as the user to run as
+func restrictContainer(container *corev1.Container) {
+	if container.SecurityContext == nil {
+		container.SecurityContext = &corev1.SecurityContext{}
+	}
+	securityContext := container.SecurityContext
+	securityContext.AllowPrivilegeEscalation = ptr.To(false)
+	securityContext.Privileged = nil
+	if securityContext.Capabilities == nil {
+		securityContext.Capabilities = &corev1.Capabilities{}
+	}
+	// The restricted settings only allow adding back NET_BIND_SERVICE
+	securityContext.Capabilities.Drop = []corev1.Capability{"ALL"}
+	securityContext.Capabilities.Add = slices.DeleteFunc(securityContext.Capabilities.Add, func(capability corev1.Capability) bool {
+		return capability != "NET_BIND_SERVICE"
+	})
+	if len(securityContext.Capabilities.Add) == 0 {
+		securityContext.Capabilities.Add = nil
+	}
+}
+
+// isolationNetworkPolicy returns the spec of the NetworkPolicy denying all traffic of the
+// squad's pods except between themselves and to DNS
+func isolationNetworkPolicy(virtSquad *appsv1.VirtSquad) networkingv1.NetworkPolicySpec {
+	squadPods := metav1.LabelSelector{MatchLabels: map[string]string{appLabel: appLabelValue, squadLabel: virtSquad.Name}}
+	dns := []networkingv1.NetworkPolicyPort{
+		{Protocol: ptr.To(corev1.ProtocolUDP), Port: ptr.To(intstr.FromInt32(53))},
+		{Protocol: ptr.To(corev1.ProtocolTCP), Port: ptr.To(intstr.FromInt32(53))},
+	}
+	return networkingv1.NetworkPolicySpec{
+		PodSelector: squadPods,
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+		Ingress: []networkingv1.NetworkPolicyIngressRule{{
+			From: []networkingv1.NetworkPolicyPeer{{PodSelector: squadPods.DeepCopy()}},
+		}},
+		Egress: []networkingv1.NetworkPolicyEgressRule{
+			{To: []networkingv1.NetworkPolicyPeer{{PodSelector: squadPods.DeepCopy()}}},
+			{To: []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}}, Ports: dns},
+		},
+	}
+}
+
+// reconcileIsolation creates or updates the ServiceAccount and NetworkPolicy of a strictly
+// isolated squad, or deletes them once the squad is no longer isolated
+func (r *VirtSquadReconciler) reconcileIsolation(ctx context.Context, virtSquad *appsv1.VirtSquad) error {
+	log := logf.FromContext(ctx)
+
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: isolationName(virtSquad), Namespace: virtSquad.Namespace},
+	}
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: isolationName(virtSquad), Namespace: virtSquad.Namespace},
+	}
+
+	if !isolated(virtSquad) {
+		for _, obj := range []client.Object{serviceAccount, networkPolicy} {
+			if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+				if errors.IsNotFound(err) {
+					continue
+				}
+				return err
+			}
+			if !metav1.IsControlledBy(obj, virtSquad) {
+				continue
+			}
+			if err := r.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+				log.Error(err, "Failed to delete isolation object", "name", obj.GetName())
+				return err
+			}
+		}
+		return nil
+	}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, serviceAccount, func() error {
+		serviceAccount.Labels = map[string]string{squadLabel: virtSquad.Name}
+		serviceAccount.AutomountServiceAccountToken = ptr.To(false)
+		return controllerutil.SetControllerReference(virtSquad, serviceAccount, r.Scheme)
+	})
+	if err != nil {
+		log.Error(err, "Failed to reconcile ServiceAccount", "serviceAccount", serviceAccount.Name)
+		return err
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Info("Reconciled ServiceAccount", "serviceAccount", serviceAccount.Name, "operation", result)
+	}
+
+	result, err = controllerutil.CreateOrUpdate(ctx, r.Client, networkPolicy, func() error {
+		networkPolicy.Labels = map[string]string{squadLabel: virtSquad.Name}
+		networkPolicy.Spec = isolationNetworkPolicy(virtSquad)
+		return controllerutil.SetControllerReference(virtSquad, networkPolicy, r.Scheme)
+	})
+	if err != nil {
+		log.Error(err, "Failed to reconcile NetworkPolicy", "networkPolicy", networkPolicy.Name)
+		return err
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Info("Reconciled NetworkPolicy", "networkPolicy", networkPolicy.Name, "operation", result)
+	}
+	return nil
+}
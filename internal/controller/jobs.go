@@ -0,0 +1,229 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	reasonJobSucceeded = "JobSucceeded"
+	reasonJobRunning   = "JobRunning"
+	reasonJobFailed    = "JobFailed"
+)
+
+// jobFinished returns whether the Job has finished and, if so, whether it succeeded
+func jobFinished(job *batchv1.Job) (finished bool, succeeded bool) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return true, true
+		case batchv1.JobFailed:
+			return true, false
+		}
+	}
+	return false, false
+}
+
+// ensureJob makes sure a Job rendered from the template exists under the given name. A Job
+// created from a different template is deleted so that it can be re-run. It returns nil while
+// the Job is being created or replaced.
+func (r *VirtSquadReconciler) ensureJob(ctx context.Context, virtSquad *appsv1.VirtSquad, jobName string, template *batchv1.JobTemplateSpec, labels map[string]string) (*batchv1.Job, error) {
+	log := logf.FromContext(ctx)
+
+	hash, err := computeHash(template)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &batchv1.Job{}
+	err = r.Get(ctx, types.NamespacedName{Namespace: virtSquad.Namespace, Name: jobName}, job)
+	if err != nil && !errors.IsNotFound(err) {
+		log.Error(err, "Failed to get Job", "job", jobName)
+		return nil, err
+	}
+
+	if err == nil {
+		if job.Labels[templateHashLabel] == hash {
+			return job, nil
+		}
+		// Re-run the Job when its template changed
+		if job.DeletionTimestamp == nil {
+			log.Info("Deleting outdated Job", "job", jobName)
+			if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+
+	job = &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        jobName,
+			Namespace:   virtSquad.Namespace,
+			Labels:      map[string]string{},
+			Annotations: template.Annotations,
+		},
+		Spec: *template.Spec.DeepCopy(),
+	}
+	for key, value := range template.Labels {
+		job.Labels[key] = value
+	}
+	for key, value := range labels {
+		job.Labels[key] = value
+	}
+	job.Labels[squadLabel] = virtSquad.Name
+	job.Labels[templateHashLabel] = hash
+
+	if err := controllerutil.SetControllerReference(virtSquad, job, r.Scheme); err != nil {
+		return nil, err
+	}
+
+	log.Info("Creating Job", "job", jobName)
+	if err := r.Create(ctx, job); err != nil {
+		log.Error(err, "Failed to create Job", "job", jobName)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// deleteJob deletes the squad's Job of the given name, if it exists and the squad controls it
+func (r *VirtSquadReconciler) deleteJob(ctx context.Context, virtSquad *appsv1.VirtSquad, jobName string) error {
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: virtSquad.Namespace, Name: jobName}, job); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(job, virtSquad) || job.DeletionTimestamp != nil {
+		return nil
+	}
+	logf.FromContext(ctx).Info("Deleting Job no longer in the spec", "job", jobName)
+	if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// reconcilePreStartJob makes sure the team member's pre-start Job has run for the current
+// template. It returns true once the Job has succeeded and the member's pods may be created.
+func (r *VirtSquadReconciler) reconcilePreStartJob(ctx context.Context, virtSquad *appsv1.VirtSquad, member *appsv1.MemberStatus, template *batchv1.JobTemplateSpec) (bool, error) {
+	jobName := fmt.Sprintf("%s-%s-prestart", virtSquad.Name, member.Name)
+	if template == nil {
+		meta.RemoveStatusCondition(&member.Conditions, appsv1.ConditionPreStartJobSucceeded)
+		// Clean up the Job left behind when the pre-start Job was removed from the member
+		return true, r.deleteJob(ctx, virtSquad, jobName)
+	}
+
+	job, err := r.ensureJob(ctx, virtSquad, jobName, template, map[string]string{memberLabel: member.Name})
+	if err != nil {
+		return false, err
+	}
+
+	finished, succeeded := false, false
+	if job != nil {
+		finished, succeeded = jobFinished(job)
+	}
+	switch {
+	case !finished:
+		setPreStartCondition(virtSquad, member, metav1.ConditionFalse, reasonJobRunning, "Waiting for the pre-start Job to complete")
+		return false, nil
+	case !succeeded:
+		message := fmt.Sprintf("Pre-start Job %s failed; update the template to run it again", jobName)
+		if setPreStartCondition(virtSquad, member, metav1.ConditionFalse, reasonJobFailed, message) {
+			r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonJobFailed, "Team member %s: %s", member.Name, message)
+		}
+		return false, nil
+	}
+
+	setPreStartCondition(virtSquad, member, metav1.ConditionTrue, reasonJobSucceeded, "The pre-start Job completed successfully")
+	return true, nil
+}
+
+// reconcileFinalizeJob runs the squad's teardown Job during finalization. It returns true once
+// finalization may continue with deleting the squad's pods, or a delay after which to check again.
+func (r *VirtSquadReconciler) reconcileFinalizeJob(ctx context.Context, virtSquad *appsv1.VirtSquad) (bool, time.Duration, error) {
+	spec := virtSquad.Spec.FinalizeJob
+	if spec == nil {
+		return true, 0, nil
+	}
+
+	timeout := 300 * time.Second
+	if spec.TimeoutSeconds != nil {
+		timeout = time.Duration(*spec.TimeoutSeconds) * time.Second
+	}
+
+	jobName := fmt.Sprintf("%s-finalize", virtSquad.Name)
+	job, err := r.ensureJob(ctx, virtSquad, jobName, &spec.Template, nil)
+	if err != nil {
+		return false, 0, err
+	}
+
+	finished, succeeded := false, false
+	if job != nil {
+		finished, succeeded = jobFinished(job)
+	}
+	if finished && succeeded {
+		r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonJobSucceeded, "Finalize Job %s completed successfully", jobName)
+		return true, 0, nil
+	}
+
+	remaining := time.Until(virtSquad.DeletionTimestamp.Add(timeout))
+	if !finished && remaining > 0 {
+		// Job events requeue the squad as well; this only bounds the wait for the timeout
+		return false, remaining, nil
+	}
+
+	message := fmt.Sprintf("Finalize Job %s failed", jobName)
+	if !finished {
+		message = fmt.Sprintf("Finalize Job %s did not complete within %s", jobName, timeout)
+	}
+	if spec.FailurePolicy == appsv1.FinalizeJobFailurePolicyFail {
+		r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonJobFailed,
+			"%s; deletion is blocked until the Job succeeds or the failure policy is set to Ignore", message)
+		return false, time.Minute, nil
+	}
+
+	r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonJobFailed, "%s; continuing with deletion", message)
+	return true, 0, nil
+}
+
+// setPreStartCondition updates the member's PreStartJobSucceeded condition and reports whether it changed
+func setPreStartCondition(virtSquad *appsv1.VirtSquad, member *appsv1.MemberStatus, status metav1.ConditionStatus, reason, message string) bool {
+	return meta.SetStatusCondition(&member.Conditions, metav1.Condition{
+		Type:               appsv1.ConditionPreStartJobSucceeded,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: virtSquad.Generation,
+	})
+}
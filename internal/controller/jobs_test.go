@@ -0,0 +1,54 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+var _ = Describe("Pre-start Jobs", func() {
+	It("should delete the squad's pre-start Job once it is removed from the member", func() {
+		_, scheme := newFakeClient()
+		virtSquad := &appsv1.VirtSquad{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "squad", UID: "squad-uid"}}
+		owned := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "squad-oksana-prestart"}}
+		Expect(controllerutil.SetControllerReference(virtSquad, owned, scheme)).To(Succeed())
+		foreign := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "squad-kurtis-prestart"}}
+
+		r, c := newFakeReconciler(virtSquad, owned, foreign)
+
+		for _, name := range []string{"oksana", "kurtis", "matt"} {
+			ready, err := r.reconcilePreStartJob(context.Background(), virtSquad, &appsv1.MemberStatus{Name: name}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ready).To(BeTrue())
+		}
+
+		err := c.Get(context.Background(), client.ObjectKeyFromObject(owned), &batchv1.Job{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		// Jobs the squad does not control are left alone
+		Expect(c.Get(context.Background(), client.ObjectKeyFromObject(foreign), &batchv1.Job{})).To(Succeed())
+	})
+})
@@ -0,0 +1,160 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// roleLabel marks the pod elected as its team member's leader
+	roleLabel = "virtsquad.mshort55.io/role"
+	// roleLeader is the roleLabel value of the leader pod
+	roleLeader = "leader"
+	// reasonLeaderElected is the event reason used when a pod becomes its member's leader
+	reasonLeaderElected = "LeaderElected"
+	// reasonLeaderLost is the event reason used when no pod is left to take over as leader
+	reasonLeaderLost = "LeaderLost"
+)
+
+// leaderServiceName returns the name of the Service selecting a team member's leader
+func leaderServiceName(virtSquad *appsv1.VirtSquad, memberName string) string {
+	return fmt.Sprintf("%s-%s-leader", virtSquad.Name, memberName)
+}
+
+// reconcileLeaderService creates or updates the Service selecting the team member's leader, or
+// deletes it when the member no longer elects one
+func (r *VirtSquadReconciler) reconcileLeaderService(ctx context.Context, virtSquad *appsv1.VirtSquad, memberName string, memberSpec *appsv1.TeamMemberSpec) error {
+	log := logf.FromContext(ctx)
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      leaderServiceName(virtSquad, memberName),
+			Namespace: virtSquad.Namespace,
+		},
+	}
+
+	if memberSpec == nil || memberSpec.Name == nil || memberSpec.LeaderElection == nil {
+		return r.deleteService(ctx, service)
+	}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, service, func() error {
+		service.Labels = map[string]string{
+			memberLabel: memberName,
+			squadLabel:  virtSquad.Name,
+		}
+		service.Spec.Type = memberSpec.LeaderElection.ServiceType
+		if service.Spec.Type == "" {
+			service.Spec.Type = corev1.ServiceTypeClusterIP
+		}
+		service.Spec.Selector = memberLabels(virtSquad, memberName)
+		service.Spec.Selector[roleLabel] = roleLeader
+		service.Spec.Ports = servicePorts()
+
+		return controllerutil.SetControllerReference(virtSquad, service, r.Scheme)
+	})
+	if err != nil {
+		log.Error(err, "Failed to reconcile Service", "service", service.Name)
+		return err
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Info("Reconciled Service", "service", service.Name, "operation", result)
+	}
+	return nil
+}
+
+// pickLeader returns the pod to lead the team member: the current leader while it stays ready,
+// otherwise the oldest ready pod, preferring pods that serve traffic. It returns an empty
+// string when no pod is ready.
+func pickLeader(pods []corev1.Pod, current string) string {
+	var candidates []*corev1.Pod
+	for i := range pods {
+		if !isPodReady(&pods[i]) {
+			continue
+		}
+		if pods[i].Name == current {
+			return current
+		}
+		candidates = append(candidates, &pods[i])
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		servingI := candidates[i].Labels[trafficLabel] != trafficStandby
+		servingJ := candidates[j].Labels[trafficLabel] != trafficStandby
+		if servingI != servingJ {
+			return servingI
+		}
+		if !candidates[i].CreationTimestamp.Equal(&candidates[j].CreationTimestamp) {
+			return candidates[i].CreationTimestamp.Before(&candidates[j].CreationTimestamp)
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	return candidates[0].Name
+}
+
+// electLeader labels the team member's leader pod and removes the label from every other pod,
+// electing a new leader when the previous one is gone or no longer ready
+func (r *VirtSquadReconciler) electLeader(ctx context.Context, virtSquad *appsv1.VirtSquad, member *appsv1.MemberStatus, memberSpec *appsv1.TeamMemberSpec, pods []corev1.Pod) error {
+	log := logf.FromContext(ctx)
+
+	leader := ""
+	if memberSpec.LeaderElection != nil {
+		leader = pickLeader(pods, member.Leader)
+	}
+
+	for i := range pods {
+		role := ""
+		if pods[i].Name == leader {
+			role = roleLeader
+		}
+		if pods[i].Labels[roleLabel] == role {
+			continue
+		}
+		log.Info("Changing pod role", "pod", pods[i].Name, "role", role)
+		if err := r.patchPodLabel(ctx, &pods[i], roleLabel, role); err != nil {
+			return err
+		}
+	}
+
+	previous := member.Leader
+	member.Leader = leader
+	switch {
+	case leader == previous || memberSpec.LeaderElection == nil:
+	case leader == "":
+		r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonLeaderLost,
+			"Team member %s has no ready pod to take over from leader %s", member.Name, previous)
+	case previous == "":
+		r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonLeaderElected,
+			"Elected pod %s as leader of team member %s", leader, member.Name)
+	default:
+		r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonLeaderElected,
+			"Elected pod %s as leader of team member %s in place of pod %s", leader, member.Name, previous)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+	"github.com/mshort55/virtsquad-operator/internal/quota"
+)
+
+const (
+	// reasonReplicaCeilingExceeded is the event reason used when a member asks for more replicas than allowed
+	reasonReplicaCeilingExceeded = "ReplicaCeilingExceeded"
+	// reasonQuotaExceeded is reported when team members request more pods than the squad's budget
+	reasonQuotaExceeded = "QuotaExceeded"
+	// reasonWithinQuota is reported when every requested pod fits in the squad's budget
+	reasonWithinQuota = "WithinQuota"
+)
+
+// podBudget tracks how many more pods a squad may run under its pod limit, and how many of the
+// pods its team members request did not fit
+type podBudget struct {
+	// limited is false when the squad has no pod limit
+	limited   bool
+	remaining int32
+	denied    int32
+	// source describes the limit that applies, for messages
+	source string
+	// capped describes the team members held below their requested replicas by the operator's
+	// per-member ceiling
+	capped []string
+}
+
+// take grants up to n pods from the budget and returns how many were granted
+func (b *podBudget) take(n int32) int32 {
+	if !b.limited {
+		return n
+	}
+	granted := min(n, b.remaining)
+	b.remaining -= granted
+	b.denied += n - granted
+	return granted
+}
+
+// limit lowers the budget to remaining pods, unless a stricter limit already applies
+func (b *podBudget) limit(remaining int32, source string) {
+	if b.limited && b.remaining <= remaining {
+		return
+	}
+	b.limited = true
+	b.remaining = max(remaining, 0)
+	b.source = source
+}
+
+// newPodBudget returns the pod budget of a squad: the strictest of its spec.maxTotalPods, the
+// operator's per-squad ceiling and what the namespace's VirtSquadQuotas leave to it
+func (r *VirtSquadReconciler) newPodBudget(ctx context.Context, virtSquad *appsv1.VirtSquad) (*podBudget, error) {
+	budget := &podBudget{}
+	if r.MaxPodsPerSquad > 0 {
+		budget.limit(r.MaxPodsPerSquad, fmt.Sprintf("the operator's limit of %d pods per squad", r.MaxPodsPerSquad))
+	}
+	if maxPods := virtSquad.Spec.MaxTotalPods; maxPods != nil {
+		budget.limit(*maxPods, fmt.Sprintf("the squad's budget of %d pods", *maxPods))
+	}
+
+	limits, err := quota.ForNamespace(ctx, r.Client, virtSquad.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if limits.MaxSquads == nil && limits.MaxPods == nil {
+		return budget, nil
+	}
+	squads := &appsv1.VirtSquadList{}
+	if err := r.List(ctx, squads, client.InNamespace(virtSquad.Namespace)); err != nil {
+		return nil, err
+	}
+	if limits.MaxSquads != nil && !quota.Admitted(squads.Items, virtSquad.Name, *limits.MaxSquads) {
+		budget.limit(0, fmt.Sprintf("the namespace's quota of %d squads", *limits.MaxSquads))
+	}
+	if limits.MaxPods != nil {
+		used := int32(0)
+		for i := range squads.Items {
+			if squads.Items[i].Name != virtSquad.Name {
+				used += squads.Items[i].Status.DesiredPods
+			}
+		}
+		budget.limit(*limits.MaxPods-used, fmt.Sprintf("VirtSquadQuota %s (%d of its %d pods used by other squads)",
+			limits.Quota, used, *limits.MaxPods))
+	}
+	return budget, nil
+}
+
+// updateQuotaExceeded sets the squad's QuotaExceeded condition from the pods its pod budget
+// had to deny and the members the per-member ceiling capped. It returns true when the condition
+// has just become true.
+func updateQuotaExceeded(virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus, budget *podBudget) bool {
+	condition := metav1.Condition{
+		Type:               appsv1.ConditionQuotaExceeded,
+		Status:             metav1.ConditionFalse,
+		Reason:             reasonWithinQuota,
+		Message:            "All requested pods fit in the squad's pod budget",
+		ObservedGeneration: virtSquad.Generation,
+	}
+	var exceeded []string
+	if budget.denied > 0 {
+		condition.Reason = reasonQuotaExceeded
+		exceeded = append(exceeded, fmt.Sprintf("Requested pods exceed %s by %d", budget.source, budget.denied))
+	} else if len(budget.capped) > 0 {
+		condition.Reason = reasonReplicaCeilingExceeded
+	}
+	if exceeded = append(exceeded, budget.capped...); len(exceeded) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Message = strings.Join(exceeded, "; ")
+	}
+
+	wasExceeded := meta.IsStatusConditionTrue(status.Conditions, appsv1.ConditionQuotaExceeded)
+	meta.SetStatusCondition(&status.Conditions, condition)
+	return !wasExceeded && condition.Status == metav1.ConditionTrue
+}
+
+// desiredReplicas returns the number of pods to run for a team member, which is zero while the
+// squad hibernates or the member is off call. The requested replicas,
+// taken from the member's active schedule if it has one, are capped by the operator's per-member ceiling and by what is left of the squad's pod budget,
+// so specs admitted while the webhook was bypassed cannot flood the namespace. Capped members
+// are recorded in the budget and reported through the QuotaExceeded condition.
+func (r *VirtSquadReconciler) desiredReplicas(virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus, memberName string, memberSpec *appsv1.TeamMemberSpec, budget *podBudget) int32 {
+	if memberSpec == nil || memberSpec.Name == nil || hibernating(virtSquad, time.Now()) || offCall(virtSquad, status, memberName) {
+		return 0
+	}
+
+	requested := int32(1)
+	if memberSpec.Replicas != nil {
+		requested = *memberSpec.Replicas
+	}
+	if replicas, ok := scheduledReplicas(virtSquad, memberName, time.Now()); ok {
+		requested = replicas
+	}
+
+	desired := requested
+	if r.MaxReplicasPerMember > 0 && desired > r.MaxReplicasPerMember {
+		desired = r.MaxReplicasPerMember
+		budget.capped = append(budget.capped, fmt.Sprintf("Team member %s requests %d replicas, more than the operator's limit of %d per team member",
+			memberName, requested, r.MaxReplicasPerMember))
+	}
+	return budget.take(desired)
+}
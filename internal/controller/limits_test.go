@@ -0,0 +1,60 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+var _ = Describe("Replica ceilings", func() {
+	It("should report capped members in the QuotaExceeded condition once", func() {
+		r := &VirtSquadReconciler{MaxReplicasPerMember: 5}
+		virtSquad := &appsv1.VirtSquad{Spec: appsv1.VirtSquadSpec{
+			Oksana: &appsv1.TeamMemberSpec{Name: ptr.To("oksana"), Replicas: ptr.To(int32(1000))},
+		}}
+		status := &appsv1.VirtSquadStatus{}
+
+		budget := &podBudget{}
+		Expect(r.desiredReplicas(virtSquad, status, "oksana", virtSquad.Spec.Oksana, budget)).To(Equal(int32(5)))
+		Expect(updateQuotaExceeded(virtSquad, status, budget)).To(BeTrue())
+		condition := meta.FindStatusCondition(status.Conditions, appsv1.ConditionQuotaExceeded)
+		Expect(condition.Reason).To(Equal(reasonReplicaCeilingExceeded))
+		Expect(condition.Message).To(ContainSubstring("oksana requests 1000 replicas"))
+
+		// The next reconcile finds the condition already set and reports nothing new
+		budget = &podBudget{}
+		r.desiredReplicas(virtSquad, status, "oksana", virtSquad.Spec.Oksana, budget)
+		Expect(updateQuotaExceeded(virtSquad, status, budget)).To(BeFalse())
+
+		virtSquad.Spec.Oksana.Replicas = ptr.To(int32(3))
+		budget = &podBudget{}
+		Expect(r.desiredReplicas(virtSquad, status, "oksana", virtSquad.Spec.Oksana, budget)).To(Equal(int32(3)))
+		Expect(updateQuotaExceeded(virtSquad, status, budget)).To(BeFalse())
+		Expect(meta.IsStatusConditionFalse(status.Conditions, appsv1.ConditionQuotaExceeded)).To(BeTrue())
+	})
+
+	It("should run no pods for members without a name", func() {
+		r := &VirtSquadReconciler{}
+		Expect(r.desiredReplicas(&appsv1.VirtSquad{}, &appsv1.VirtSquadStatus{}, "oksana",
+			&appsv1.TeamMemberSpec{Replicas: ptr.To(int32(3))}, &podBudget{})).To(BeZero())
+	})
+})
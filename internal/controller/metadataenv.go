@@ -0,0 +1,53 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ordinalLabel records the ordinal suffix of a pod's name, so it can be read through the
+// Downward API
+const ordinalLabel = "virtsquad.mshort55.io/ordinal"
+
+// metadataEnv returns the environment variables that tell a member's processes which squad,
+// member and replica they belong to. They are read through the Downward API, so the pod spec
+// stays the same for every replica of the member.
+func metadataEnv() []corev1.EnvVar {
+	fromField := func(name, fieldPath string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name:      name,
+			ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: fieldPath}},
+		}
+	}
+	return []corev1.EnvVar{
+		fromField("VIRTSQUAD_NAME", fmt.Sprintf("metadata.labels['%s']", squadLabel)),
+		fromField("MEMBER_NAME", fmt.Sprintf("metadata.labels['%s']", memberLabel)),
+		fromField("REPLICA_ORDINAL", fmt.Sprintf("metadata.labels['%s']", ordinalLabel)),
+		fromField("POD_IP", "status.podIP"),
+	}
+}
+
+// setOrdinalLabel labels a new pod with the ordinal of its name, when it has one
+func setOrdinalLabel(pod *corev1.Pod) {
+	if ordinal := podOrdinal(pod); ordinal >= 0 {
+		pod.Labels[ordinalLabel] = strconv.Itoa(ordinal)
+	}
+}
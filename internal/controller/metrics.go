@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+var (
+	// memberDesiredReplicas exposes how many pods each team member should run
+	memberDesiredReplicas = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "virtsquad_member_desired_replicas",
+		Help: "Pods a team member should run, including standby pods",
+	}, []string{"namespace", "squad", "member"})
+
+	// memberReadyReplicas exposes how many of each team member's pods are ready
+	memberReadyReplicas = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "virtsquad_member_ready_replicas",
+		Help: "Ready pods of a team member",
+	}, []string{"namespace", "squad", "member"})
+
+	// memberRecreations counts the failing pods recreated under each team member's failure policy
+	memberRecreations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "virtsquad_member_recreations_total",
+		Help: "Failing pods of a team member recreated under its failure policy",
+	}, []string{"namespace", "squad", "member"})
+
+	// memberRolloutDuration observes how long each team member's rollouts took to complete
+	memberRolloutDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "virtsquad_member_rollout_duration_seconds",
+		Help:    "Time from a new revision of a team member's pod template to all of its pods running it",
+		Buckets: prometheus.ExponentialBuckets(5, 2, 12),
+	}, []string{"namespace", "squad", "member"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(memberDesiredReplicas, memberReadyReplicas, memberRecreations, memberRolloutDuration)
+}
+
+// deleteMemberMetrics drops the replica, recreation and rollout metrics of a squad, or of one of
+// its members when memberName is set
+func deleteMemberMetrics(virtSquad *appsv1.VirtSquad, memberName string) {
+	labels := prometheus.Labels{"namespace": virtSquad.Namespace, "squad": virtSquad.Name}
+	if memberName != "" {
+		labels["member"] = memberName
+	}
+	memberDesiredReplicas.DeletePartialMatch(labels)
+	memberReadyReplicas.DeletePartialMatch(labels)
+	memberRecreations.DeletePartialMatch(labels)
+	memberRolloutDuration.DeletePartialMatch(labels)
+}
+
+// updateMemberReplicaMetrics sets the member's desired and ready replica gauges
+func updateMemberReplicaMetrics(virtSquad *appsv1.VirtSquad, memberName string, pods []corev1.Pod, desiredReplicas int32) {
+	ready := 0
+	for i := range pods {
+		if isPodReady(&pods[i]) {
+			ready++
+		}
+	}
+	memberDesiredReplicas.WithLabelValues(virtSquad.Namespace, virtSquad.Name, memberName).Set(float64(desiredReplicas))
+	memberReadyReplicas.WithLabelValues(virtSquad.Namespace, virtSquad.Name, memberName).Set(float64(ready))
+}
+
+// observeRecreations counts failing pods of the member recreated under its failure policy
+func observeRecreations(virtSquad *appsv1.VirtSquad, memberName string, pods int) {
+	memberRecreations.WithLabelValues(virtSquad.Namespace, virtSquad.Name, memberName).Add(float64(pods))
+}
+
+// observeRolloutDuration records how long the member's rollout took, from the time it started
+func observeRolloutDuration(virtSquad *appsv1.VirtSquad, memberName string, started time.Time) {
+	memberRolloutDuration.WithLabelValues(virtSquad.Namespace, virtSquad.Name, memberName).Observe(time.Since(started).Seconds())
+}
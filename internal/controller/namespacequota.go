@@ -0,0 +1,309 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+	"github.com/mshort55/virtsquad-operator/internal/quota"
+)
+
+// +kubebuilder:rbac:groups="",resources=resourcequotas,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=limitranges,verbs=get;list;watch;create;update;patch;delete
+
+const (
+	// reasonQuotaApplied is reported when the squad's ResourceQuota and LimitRange are in place
+	reasonQuotaApplied = "QuotaApplied"
+	// reasonNamespaceShared is reported when other squads or workloads share the namespace
+	reasonNamespaceShared = "NamespaceShared"
+	// reasonQuotaNotOwned is reported when a quota object of the same name is not the squad's
+	reasonQuotaNotOwned = "QuotaNotOwned"
+)
+
+// defaultContainerRequests are requested by containers without requests when the squad's
+// namespace quota sets no default requests
+var defaultContainerRequests = corev1.ResourceList{
+	corev1.ResourceCPU:    resource.MustParse("100m"),
+	corev1.ResourceMemory: resource.MustParse("128Mi"),
+}
+
+// namespaceQuotaName returns the name of the ResourceQuota generated for a squad
+func namespaceQuotaName(virtSquad *appsv1.VirtSquad) string {
+	return virtSquad.Name + "-quota"
+}
+
+// limitRangeName returns the name of the LimitRange generated for a squad
+func limitRangeName(virtSquad *appsv1.VirtSquad) string {
+	return virtSquad.Name + "-limits"
+}
+
+// containerDefaultRequests returns the requests the LimitRange gives containers without any
+func containerDefaultRequests(spec *appsv1.NamespaceQuotaSpec) corev1.ResourceList {
+	requests := defaultContainerRequests.DeepCopy()
+	for name, quantity := range spec.DefaultRequests {
+		requests[name] = quantity.DeepCopy()
+	}
+	return requests
+}
+
+// addMemberFootprint adds what the member's pods request from the namespace quota to the total:
+// the pods themselves and their CPU and memory requests, with the LimitRange's defaults standing
+// in for requests the member does not set
+func addMemberFootprint(total corev1.ResourceList, virtSquad *appsv1.VirtSquad, member *appsv1.TeamMemberSpec, overhead corev1.ResourceList, pods int32) {
+	if virtSquad.Spec.NamespaceQuota == nil || pods == 0 {
+		return
+	}
+	requests := quota.PodRequests(member, nil)
+	defaults := containerDefaultRequests(virtSquad.Spec.NamespaceQuota)
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		perPod, ok := requests[name]
+		if !ok {
+			perPod = defaults[name].DeepCopy()
+		}
+		if q, ok := overhead[name]; ok {
+			perPod.Add(q)
+		}
+		key := corev1.ResourceName("requests." + string(name))
+		sum := total[key]
+		for range pods {
+			sum.Add(perPod)
+		}
+		total[key] = sum
+	}
+	count := total[corev1.ResourcePods]
+	count.Add(*resource.NewQuantity(int64(pods), resource.DecimalSI))
+	total[corev1.ResourcePods] = count
+}
+
+// withHeadroom returns the quantity raised by the percentage, rounded up
+func withHeadroom(name corev1.ResourceName, quantity resource.Quantity, percent int64) resource.Quantity {
+	switch name {
+	case corev1.ResourcePods:
+		return *resource.NewQuantity((quantity.Value()*(100+percent)+99)/100, resource.DecimalSI)
+	case corev1.ResourceRequestsCPU:
+		return *resource.NewMilliQuantity((quantity.MilliValue()*(100+percent)+99)/100, resource.DecimalSI)
+	default:
+		return *resource.NewQuantity((quantity.Value()*(100+percent)+99)/100, resource.BinarySI)
+	}
+}
+
+// namespaceSharer describes another squad or workload in the squad's namespace, which a
+// generated ResourceQuota would limit as well, or returns an empty string when there is none
+func (r *VirtSquadReconciler) namespaceSharer(ctx context.Context, virtSquad *appsv1.VirtSquad) (string, error) {
+	squads := &appsv1.VirtSquadList{}
+	if err := r.List(ctx, squads, client.InNamespace(virtSquad.Namespace)); err != nil {
+		return "", err
+	}
+	for i := range squads.Items {
+		if squads.Items[i].Name != virtSquad.Name {
+			return fmt.Sprintf("VirtSquad %s", squads.Items[i].Name), nil
+		}
+	}
+
+	// The squad's own Jobs run pods too
+	jobs := &batchv1.JobList{}
+	if err := r.List(ctx, jobs, client.InNamespace(virtSquad.Namespace), client.MatchingLabels{squadLabel: virtSquad.Name}); err != nil {
+		return "", err
+	}
+	owned := map[types.UID]bool{virtSquad.UID: true}
+	for i := range jobs.Items {
+		if metav1.IsControlledBy(&jobs.Items[i], virtSquad) {
+			owned[jobs.Items[i].UID] = true
+		}
+	}
+	pods, err := r.listPods(ctx, client.InNamespace(virtSquad.Namespace))
+	if err != nil {
+		return "", err
+	}
+	for i := range pods {
+		owner := metav1.GetControllerOf(&pods[i])
+		if owner != nil && owned[owner.UID] {
+			continue
+		}
+		if pods[i].Status.Phase == corev1.PodSucceeded || pods[i].Status.Phase == corev1.PodFailed {
+			continue
+		}
+		return fmt.Sprintf("pod %s", pods[i].Name), nil
+	}
+	return "", nil
+}
+
+// deleteNamespaceQuota deletes the squad's ResourceQuota and LimitRange, leaving objects of the
+// same names it does not control alone
+func (r *VirtSquadReconciler) deleteNamespaceQuota(ctx context.Context, virtSquad *appsv1.VirtSquad, objs ...client.Object) error {
+	for _, obj := range objs {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if !metav1.IsControlledBy(obj, virtSquad) {
+			continue
+		}
+		if err := r.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+			logf.FromContext(ctx).Error(err, "Failed to delete namespace quota object", "name", obj.GetName())
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileNamespaceQuota creates or updates the squad's ResourceQuota, sized from the footprint
+// of its members' pods plus headroom, and its LimitRange, or deletes both when the squad no
+// longer asks for them. They are not generated while other squads or workloads share the
+// namespace, nor when objects of the same names exist that the squad does not control, which
+// the NamespaceQuota condition reports.
+func (r *VirtSquadReconciler) reconcileNamespaceQuota(ctx context.Context, virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus, footprint corev1.ResourceList) error {
+	log := logf.FromContext(ctx)
+
+	resourceQuota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: namespaceQuotaName(virtSquad), Namespace: virtSquad.Namespace},
+	}
+	limitRange := &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: limitRangeName(virtSquad), Namespace: virtSquad.Namespace},
+	}
+
+	spec := virtSquad.Spec.NamespaceQuota
+	if spec == nil {
+		meta.RemoveStatusCondition(&status.Conditions, appsv1.ConditionNamespaceQuota)
+		return r.deleteNamespaceQuota(ctx, virtSquad, resourceQuota, limitRange)
+	}
+
+	condition := metav1.Condition{
+		Type:               appsv1.ConditionNamespaceQuota,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonQuotaApplied,
+		Message:            fmt.Sprintf("ResourceQuota %s and LimitRange %s are sized for the squad's pods", resourceQuota.Name, limitRange.Name),
+		ObservedGeneration: virtSquad.Generation,
+	}
+	sharer, err := r.namespaceSharer(ctx, virtSquad)
+	if err != nil {
+		log.Error(err, "Failed to check whether the namespace is shared")
+		return err
+	}
+	if sharer != "" {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonNamespaceShared
+		condition.Message = fmt.Sprintf("The namespace is shared with %s, which a ResourceQuota would limit as well; no quota is generated", sharer)
+	}
+	for kind, obj := range map[string]client.Object{"ResourceQuota": resourceQuota, "LimitRange": limitRange} {
+		if condition.Status == metav1.ConditionFalse {
+			break
+		}
+		existing := obj.DeepCopyObject().(client.Object)
+		if err := r.Get(ctx, client.ObjectKeyFromObject(existing), existing); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if !metav1.IsControlledBy(existing, virtSquad) {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = reasonQuotaNotOwned
+			condition.Message = fmt.Sprintf("%s %s already exists and is not managed by the squad", kind, existing.GetName())
+		}
+	}
+	if meta.SetStatusCondition(&status.Conditions, condition) && condition.Status == metav1.ConditionFalse {
+		r.event(ctx, virtSquad, corev1.EventTypeWarning, condition.Reason, condition.Message)
+	}
+	if condition.Status == metav1.ConditionFalse {
+		return r.deleteNamespaceQuota(ctx, virtSquad, resourceQuota, limitRange)
+	}
+
+	headroom := int64(20)
+	if spec.HeadroomPercent != nil {
+		headroom = int64(*spec.HeadroomPercent)
+	}
+	hard := corev1.ResourceList{}
+	for _, name := range []corev1.ResourceName{corev1.ResourcePods, corev1.ResourceRequestsCPU, corev1.ResourceRequestsMemory} {
+		hard[name] = withHeadroom(name, footprint[name], headroom)
+	}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, resourceQuota, func() error {
+		resourceQuota.Labels = map[string]string{squadLabel: virtSquad.Name}
+		resourceQuota.Spec.Hard = hard
+		return controllerutil.SetControllerReference(virtSquad, resourceQuota, r.Scheme)
+	})
+	if err != nil {
+		log.Error(err, "Failed to reconcile ResourceQuota", "resourceQuota", resourceQuota.Name)
+		return err
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Info("Reconciled ResourceQuota", "resourceQuota", resourceQuota.Name, "operation", result)
+	}
+
+	result, err = controllerutil.CreateOrUpdate(ctx, r.Client, limitRange, func() error {
+		limitRange.Labels = map[string]string{squadLabel: virtSquad.Name}
+		limitRange.Spec.Limits = []corev1.LimitRangeItem{{
+			Type:           corev1.LimitTypeContainer,
+			DefaultRequest: containerDefaultRequests(spec),
+			Default:        spec.DefaultLimits.DeepCopy(),
+		}}
+		return controllerutil.SetControllerReference(virtSquad, limitRange, r.Scheme)
+	})
+	if err != nil {
+		log.Error(err, "Failed to reconcile LimitRange", "limitRange", limitRange.Name)
+		return err
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Info("Reconciled LimitRange", "limitRange", limitRange.Name, "operation", result)
+	}
+	return nil
+}
+
+// squadJoinedOrLeft passes the creation and deletion of squads
+var squadJoinedOrLeft = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return true },
+	DeleteFunc:  func(event.DeleteEvent) bool { return true },
+	UpdateFunc:  func(event.UpdateEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
+
+// squadsSharingNamespaceQuota returns a reconcile request for the other squads in the namespace
+// of obj that ask for a namespace quota, since a squad joining or leaving the namespace decides
+// whether they may have one
+func (r *VirtSquadReconciler) squadsSharingNamespaceQuota(ctx context.Context, obj client.Object) []reconcile.Request {
+	squads := &appsv1.VirtSquadList{}
+	if err := r.List(ctx, squads, client.InNamespace(obj.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list squads", "namespace", obj.GetNamespace())
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range squads.Items {
+		if squads.Items[i].Name != obj.GetName() && squads.Items[i].Spec.NamespaceQuota != nil {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&squads.Items[i])})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+	"github.com/mshort55/virtsquad-operator/internal/notifications"
+)
+
+// reasonNotificationFailed is the event reason used when a notification cannot be delivered
+const reasonNotificationFailed = "NotificationFailed"
+
+// secretValue reads a key of a Secret in the squad's namespace
+func (r *VirtSquadReconciler) secretValue(ctx context.Context, virtSquad *appsv1.VirtSquad, selector *corev1.SecretKeySelector) (string, error) {
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: virtSquad.Namespace, Name: selector.Name}
+	if err := r.Get(ctx, key, secret); err != nil {
+		return "", fmt.Errorf("reading secret %s: %w", selector.Name, err)
+	}
+	value, ok := secret.Data[selector.Key]
+	if !ok {
+		return "", fmt.Errorf("secret %s has no key %q", selector.Name, selector.Key)
+	}
+	return string(value), nil
+}
+
+// notify delivers a squad event to the squad's notification sinks. Delivery is best effort:
+// failures are logged and recorded as events but never fail the reconcile.
+func (r *VirtSquadReconciler) notify(ctx context.Context, virtSquad *appsv1.VirtSquad, eventType notifications.EventType, message string) {
+	log := logf.FromContext(ctx)
+
+	notification := notifications.Notification{
+		Squad:     virtSquad.Name,
+		Namespace: virtSquad.Namespace,
+		Type:      eventType,
+		Message:   message,
+	}
+
+	notifier := r.Notifier
+	if notifier == nil {
+		notifier = notifications.NewClient()
+	}
+
+	webhookURL := r.SlackWebhookURL
+	if spec := virtSquad.Spec.Notifications; spec != nil && spec.Slack != nil {
+		value, err := r.secretValue(ctx, virtSquad, &spec.Slack.WebhookURLSecretRef)
+		if err != nil {
+			log.Error(err, "Failed to read Slack webhook URL")
+			r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonNotificationFailed,
+				"Cannot send %s notification to Slack: %v", eventType, err)
+			return
+		}
+		webhookURL = value
+	}
+	if webhookURL == "" {
+		return
+	}
+
+	if err := notifier.PostSlack(ctx, webhookURL, notification); err != nil {
+		log.Error(err, "Failed to send Slack notification", "type", eventType)
+		r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonNotificationFailed,
+			"Cannot send %s notification to Slack: %v", eventType, err)
+	}
+}
+
+// notifyConditionTransitions posts every squad condition whose status changed from previous to
+// the squad's notification webhook. Like notify, delivery is best effort.
+func (r *VirtSquadReconciler) notifyConditionTransitions(ctx context.Context, virtSquad *appsv1.VirtSquad, previous, current []metav1.Condition) {
+	if virtSquad.Spec.Notifications == nil || virtSquad.Spec.Notifications.Webhook == nil {
+		return
+	}
+	log := logf.FromContext(ctx)
+	webhook := virtSquad.Spec.Notifications.Webhook
+
+	var transitions []notifications.ConditionTransition
+	for _, condition := range current {
+		if len(webhook.Conditions) > 0 && !slices.Contains(webhook.Conditions, condition.Type) {
+			continue
+		}
+		transition := notifications.ConditionTransition{
+			Squad:              virtSquad.Name,
+			Namespace:          virtSquad.Namespace,
+			Condition:          condition.Type,
+			Status:             string(condition.Status),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			ObservedGeneration: condition.ObservedGeneration,
+			Time:               condition.LastTransitionTime.Time,
+		}
+		if old := meta.FindStatusCondition(previous, condition.Type); old != nil {
+			if old.Status == condition.Status {
+				continue
+			}
+			transition.PreviousStatus = string(old.Status)
+		}
+		transitions = append(transitions, transition)
+	}
+	if len(transitions) == 0 {
+		return
+	}
+
+	headers := map[string]string{}
+	if webhook.HeadersSecretRef != nil {
+		secret := &corev1.Secret{}
+		key := types.NamespacedName{Namespace: virtSquad.Namespace, Name: webhook.HeadersSecretRef.Name}
+		if err := r.Get(ctx, key, secret); err != nil {
+			log.Error(err, "Failed to read notification webhook headers")
+			r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonNotificationFailed,
+				"Cannot send condition transitions to webhook: reading secret %s: %v", webhook.HeadersSecretRef.Name, err)
+			return
+		}
+		for name, value := range secret.Data {
+			headers[name] = string(value)
+		}
+	}
+
+	notifier := r.Notifier
+	if notifier == nil {
+		notifier = notifications.NewClient()
+	}
+	// One failed delivery does not hold back the other transitions
+	var errs []error
+	for _, transition := range transitions {
+		if err := notifier.PostWebhook(ctx, webhook.URL, headers, transition); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", transition.Condition, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		log.Error(err, "Failed to send condition transitions", "failed", len(errs), "transitions", len(transitions))
+		r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonNotificationFailed,
+			"Cannot send %d of %d condition transitions to webhook: %v", len(errs), len(transitions), err)
+	}
+}
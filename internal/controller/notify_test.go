@@ -0,0 +1,64 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+	"github.com/mshort55/virtsquad-operator/internal/notifications"
+)
+
+var _ = Describe("Condition transition notifications", func() {
+	It("should keep sending transitions after one fails, and report the failures together", func() {
+		var received []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			transition := notifications.ConditionTransition{}
+			Expect(json.NewDecoder(req.Body).Decode(&transition)).To(Succeed())
+			received = append(received, transition.Condition)
+			if transition.Condition == appsv1.ConditionReady {
+				w.WriteHeader(http.StatusBadGateway)
+			}
+		}))
+		defer server.Close()
+
+		virtSquad := &appsv1.VirtSquad{Spec: appsv1.VirtSquadSpec{Notifications: &appsv1.NotificationsSpec{
+			Webhook: &appsv1.WebhookNotificationSpec{URL: server.URL + "/hooks/secret-token"},
+		}}}
+		recorder := record.NewFakeRecorder(10)
+		r := &VirtSquadReconciler{Recorder: recorder}
+		current := []metav1.Condition{
+			{Type: appsv1.ConditionReady, Status: metav1.ConditionFalse},
+			{Type: appsv1.ConditionDegraded, Status: metav1.ConditionTrue},
+		}
+		r.notifyConditionTransitions(context.Background(), virtSquad, nil, current)
+
+		Expect(received).To(Equal([]string{appsv1.ConditionReady, appsv1.ConditionDegraded}))
+		Expect(recorder.Events).To(HaveLen(1))
+		event := <-recorder.Events
+		Expect(event).To(ContainSubstring("Cannot send 1 of 2 condition transitions"))
+		Expect(event).NotTo(ContainSubstring("secret-token"))
+	})
+})
@@ -0,0 +1,153 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// reasonMemoryIncreased is the event reason used when the OOM policy raises a member's memory
+	reasonMemoryIncreased = "MemoryIncreased"
+	// reasonMemoryAtMax is the event reason used when the OOM policy cannot raise a member's memory further
+	reasonMemoryAtMax = "MemoryAtMax"
+)
+
+// memberMemory returns the member's memory limit, or its request when it sets no limit
+func memberMemory(spec *appsv1.TeamMemberSpec) (resource.Quantity, bool) {
+	if spec.Resources == nil {
+		return resource.Quantity{}, false
+	}
+	if memory, ok := spec.Resources.Limits[corev1.ResourceMemory]; ok {
+		return memory, true
+	}
+	memory, ok := spec.Resources.Requests[corev1.ResourceMemory]
+	return memory, ok
+}
+
+// applyMemoryAdjustment adds memory to the member's memory request and limit
+func applyMemoryAdjustment(spec *appsv1.TeamMemberSpec, adjustment resource.Quantity) {
+	if spec.Resources == nil {
+		return
+	}
+	resources := spec.Resources.DeepCopy()
+	for _, list := range []corev1.ResourceList{resources.Requests, resources.Limits} {
+		if memory, ok := list[corev1.ResourceMemory]; ok {
+			memory.Add(adjustment)
+			list[corev1.ResourceMemory] = memory
+		}
+	}
+	spec.Resources = resources
+}
+
+// applyMemoryAdjustments applies the memory the OOM policy added to each member's spec, and
+// forgets the adjustments of members that no longer have an OOM policy
+func applyMemoryAdjustments(virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus) {
+	for i := range status.Members {
+		member := &status.Members[i]
+		spec := memberSpec(virtSquad, member.Name)
+		if spec == nil || spec.OOMPolicy == nil {
+			member.OOMKills = 0
+			member.MemoryAdjustment = nil
+			member.LastMemoryAdjustmentTime = nil
+			continue
+		}
+		if member.MemoryAdjustment != nil {
+			applyMemoryAdjustment(spec, *member.MemoryAdjustment)
+		}
+	}
+}
+
+// reconcileOOMPolicy counts the OOMKills of the member's containers and, once the policy's
+// threshold is reached, raises the member's memory by a step up to the policy's cap. The new
+// memory is applied to the spec right away, so the member's pods roll in this reconcile.
+func (r *VirtSquadReconciler) reconcileOOMPolicy(ctx context.Context, virtSquad *appsv1.VirtSquad, member *appsv1.MemberStatus, spec *appsv1.TeamMemberSpec, pods []corev1.Pod) {
+	policy := spec.OOMPolicy
+	if policy == nil {
+		return
+	}
+
+	// Containers keep their last termination until they terminate again, so only OOMKills
+	// newer than the last one recorded are counted
+	lastOOMKill := member.LastOOMKillTime
+	for i := range pods {
+		for _, cs := range pods[i].Status.ContainerStatuses {
+			terminated := cs.LastTerminationState.Terminated
+			if terminated == nil || terminated.Reason != reasonOOMKilled {
+				continue
+			}
+			if member.LastOOMKillTime == nil || member.LastOOMKillTime.Before(&terminated.FinishedAt) {
+				member.OOMKills++
+				if lastOOMKill == nil || lastOOMKill.Before(&terminated.FinishedAt) {
+					finishedAt := terminated.FinishedAt
+					lastOOMKill = &finishedAt
+				}
+			}
+		}
+	}
+	member.LastOOMKillTime = lastOOMKill
+
+	threshold := int32(3)
+	if policy.OOMKills != nil {
+		threshold = *policy.OOMKills
+	}
+	if member.OOMKills < threshold {
+		return
+	}
+
+	current, ok := memberMemory(spec)
+	if !ok {
+		logf.FromContext(ctx).Info("Cannot raise the memory of a member without a memory request or limit", "member", member.Name)
+		return
+	}
+	oomKills := member.OOMKills
+	member.OOMKills = 0
+
+	next := current.DeepCopy()
+	next.Add(policy.Step)
+	if next.Cmp(policy.Max) > 0 {
+		next = policy.Max.DeepCopy()
+	}
+	if next.Cmp(current) <= 0 {
+		r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonMemoryAtMax,
+			"Team member %s was OOMKilled %d times but its memory is already at the OOM policy's max of %s",
+			member.Name, oomKills, policy.Max.String())
+		return
+	}
+
+	delta := next.DeepCopy()
+	delta.Sub(current)
+	adjustment := resource.Quantity{}
+	if member.MemoryAdjustment != nil {
+		adjustment = member.MemoryAdjustment.DeepCopy()
+	}
+	adjustment.Add(delta)
+	member.MemoryAdjustment = &adjustment
+	now := metav1.Now()
+	member.LastMemoryAdjustmentTime = &now
+	applyMemoryAdjustment(spec, delta)
+	r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonMemoryIncreased,
+		"Raised the memory of team member %s from %s to %s after %d OOMKills",
+		member.Name, current.String(), next.String(), oomKills)
+}
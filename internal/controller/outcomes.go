@@ -0,0 +1,66 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Outcomes of a reconcile counted by reconcileOutcomes
+const (
+	outcomeCreated = "created"
+	outcomeDeleted = "deleted"
+	outcomeAdopted = "adopted"
+	outcomeSkipped = "skipped"
+	outcomeErrored = "errored"
+)
+
+// Reasons of the created and skipped outcomes; deleted pods are counted with their delete
+// reason and errors with the API status reason
+const (
+	createReasonMissingReplica = "MissingReplica"
+	skipReasonNotFound         = "NotFound"
+	skipReasonOtherShard       = "OtherShard"
+	skipReasonReconcileBlocked = "ReconcileBlocked"
+	skipReasonPaused           = "Paused"
+)
+
+// reconcileOutcomes counts what reconciles did across all squads
+var reconcileOutcomes = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "virtsquad_reconcile_outcomes_total",
+	Help: "Outcomes of VirtSquad reconciles: pods created, deleted and adopted, reconciles skipped and errored",
+}, []string{"outcome", "reason"})
+
+func init() {
+	metrics.Registry.MustRegister(reconcileOutcomes)
+}
+
+// countOutcome counts an outcome of a reconcile
+func countOutcome(outcome, reason string) {
+	reconcileOutcomes.WithLabelValues(outcome, reason).Inc()
+}
+
+// countError counts a failed reconcile under the API status reason of its error
+func countError(err error) {
+	reason := string(errors.ReasonForError(err))
+	if reason == "" {
+		reason = "Unknown"
+	}
+	countOutcome(outcomeErrored, reason)
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// reasonOverrideApplied is reported when an override's changes are applied to its member
+	reasonOverrideApplied = "OverrideApplied"
+	// reasonOverridesNotAllowed is reported when the member does not accept overrides
+	reasonOverridesNotAllowed = "OverridesNotAllowed"
+	// reasonOverrideOutOfBounds is reported when an override's changes fall outside the member's bounds
+	reasonOverrideOutOfBounds = "OutOfBounds"
+	// reasonOverrideSuperseded is reported when an older override targets the same member
+	reasonOverrideSuperseded = "Superseded"
+)
+
+// +kubebuilder:rbac:groups=apps.mshort55.io,resources=virtsquadmemberoverrides,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.mshort55.io,resources=virtsquadmemberoverrides/status,verbs=get;update;patch
+
+// checkOverride returns why an override may not be applied to its member, or an empty reason
+// when it may
+func checkOverride(override *appsv1.VirtSquadMemberOverride, memberSpec *appsv1.TeamMemberSpec) (string, string) {
+	if memberSpec == nil || memberSpec.Name == nil || memberSpec.Overrides == nil {
+		return reasonOverridesNotAllowed, fmt.Sprintf("Team member %s does not accept overrides", override.Spec.Member)
+	}
+	policy := memberSpec.Overrides
+
+	var problems []string
+	if replicas := override.Spec.Replicas; replicas != nil {
+		if policy.MinReplicas != nil && *replicas < *policy.MinReplicas {
+			problems = append(problems, fmt.Sprintf("replicas %d are below the minimum of %d", *replicas, *policy.MinReplicas))
+		}
+		if policy.MaxReplicas != nil && *replicas > *policy.MaxReplicas {
+			problems = append(problems, fmt.Sprintf("replicas %d are above the maximum of %d", *replicas, *policy.MaxReplicas))
+		}
+	}
+	if image := override.Spec.Image; image != "" && !imageAllowed(image, policy.AllowedImages) {
+		problems = append(problems, fmt.Sprintf("image %s matches none of the allowed images", image))
+	}
+	if len(problems) > 0 {
+		return reasonOverrideOutOfBounds, strings.Join(problems, "; ")
+	}
+	return "", ""
+}
+
+// imageAllowed reports whether an image matches one of the allowed glob patterns. Patterns
+// follow path.Match, so a * stays within one path segment of the image.
+func imageAllowed(image string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, err := path.Match(pattern, image); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// applyMemberOverrides applies the VirtSquadMemberOverrides targeting the squad to its team
+// members and reports on each override whether it was applied. Like resolveTemplate, the
+// changes only live in memory.
+func (r *VirtSquadReconciler) applyMemberOverrides(ctx context.Context, virtSquad *appsv1.VirtSquad) error {
+	log := logf.FromContext(ctx)
+
+	overrides := &appsv1.VirtSquadMemberOverrideList{}
+	if err := r.List(ctx, overrides, client.InNamespace(virtSquad.Namespace)); err != nil {
+		log.Error(err, "Failed to list member overrides")
+		return err
+	}
+	var targeting []*appsv1.VirtSquadMemberOverride
+	for i := range overrides.Items {
+		if overrides.Items[i].Spec.SquadName == virtSquad.Name && overrides.Items[i].DeletionTimestamp == nil {
+			targeting = append(targeting, &overrides.Items[i])
+		}
+	}
+	// The oldest override of a member wins, so a newer one cannot take over silently
+	sort.Slice(targeting, func(i, j int) bool {
+		ti, tj := targeting[i].CreationTimestamp, targeting[j].CreationTimestamp
+		if !ti.Equal(&tj) {
+			return ti.Before(&tj)
+		}
+		return targeting[i].Name < targeting[j].Name
+	})
+
+	members := map[string]*appsv1.TeamMemberSpec{}
+	for _, member := range squadMembers(virtSquad, &appsv1.VirtSquadStatus{}) {
+		members[member.name] = member.spec
+	}
+
+	applied := map[string]string{}
+	for _, override := range targeting {
+		condition := metav1.Condition{
+			Type:               appsv1.ConditionApplied,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: override.Generation,
+		}
+		memberSpec := members[override.Spec.Member]
+		switch reason, message := checkOverride(override, memberSpec); {
+		case applied[override.Spec.Member] != "":
+			condition.Reason = reasonOverrideSuperseded
+			condition.Message = fmt.Sprintf("Override %s already applies to team member %s", applied[override.Spec.Member], override.Spec.Member)
+		case reason != "":
+			condition.Reason = reason
+			condition.Message = message
+		default:
+			if override.Spec.Replicas != nil {
+				memberSpec.Replicas = override.Spec.Replicas
+			}
+			if override.Spec.Image != "" {
+				memberSpec.Image = override.Spec.Image
+			}
+			applied[override.Spec.Member] = override.Name
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = reasonOverrideApplied
+			condition.Message = fmt.Sprintf("Applied to team member %s", override.Spec.Member)
+		}
+
+		status := override.Status.DeepCopy()
+		status.ObservedGeneration = override.Generation
+		meta.SetStatusCondition(&status.Conditions, condition)
+		if equality.Semantic.DeepEqual(&override.Status, status) {
+			continue
+		}
+		override.Status = *status
+		if err := r.Status().Update(ctx, override); err != nil {
+			log.Error(err, "Failed to update member override status", "override", override.Name)
+			return err
+		}
+	}
+	return nil
+}
+
+// squadForOverride returns a reconcile request for the squad a VirtSquadMemberOverride targets
+func (r *VirtSquadReconciler) squadForOverride(_ context.Context, obj client.Object) []reconcile.Request {
+	override, ok := obj.(*appsv1.VirtSquadMemberOverride)
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{Namespace: override.Namespace, Name: override.Spec.SquadName}}}
+}
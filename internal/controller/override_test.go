@@ -0,0 +1,77 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+var _ = Describe("Member overrides", func() {
+	DescribeTable("imageAllowed",
+		func(image string, patterns []string, allowed bool) {
+			Expect(imageAllowed(image, patterns)).To(Equal(allowed))
+		},
+		Entry("matches an image in the pattern's repository", "registry.example.com/team/app:1.0", []string{"registry.example.com/team/*"}, true),
+		Entry("matches a pinned digest", "registry.example.com/team/app@sha256:abc", []string{"registry.example.com/team/*"}, true),
+		Entry("does not let * cross a /", "registry.example.com/team/sub/app", []string{"registry.example.com/team/*"}, false),
+		Entry("matches deeper images with a pattern per segment", "registry.example.com/team/sub/app", []string{"registry.example.com/team/*", "registry.example.com/team/*/*"}, true),
+		Entry("does not match another registry", "evil.example.com/team/app", []string{"registry.example.com/team/*"}, false),
+		Entry("matches an exact image", "nginx:1.27", []string{"nginx:1.27"}, true),
+		Entry("ignores malformed patterns", "nginx:1.27", []string{"nginx:[", "nginx:*"}, true),
+		Entry("allows nothing without patterns", "nginx:1.27", nil, false),
+	)
+
+	DescribeTable("checkOverride",
+		func(override appsv1.VirtSquadMemberOverrideSpec, member *appsv1.TeamMemberSpec, reason string, message string) {
+			gotReason, gotMessage := checkOverride(&appsv1.VirtSquadMemberOverride{Spec: override}, member)
+			Expect(gotReason).To(Equal(reason))
+			Expect(gotMessage).To(ContainSubstring(message))
+		},
+		Entry("rejects members that are not configured",
+			appsv1.VirtSquadMemberOverrideSpec{Member: "oksana"}, nil,
+			reasonOverridesNotAllowed, "does not accept overrides"),
+		Entry("rejects members without an override policy",
+			appsv1.VirtSquadMemberOverrideSpec{Member: "oksana"}, &appsv1.TeamMemberSpec{Name: ptr.To("oksana")},
+			reasonOverridesNotAllowed, "does not accept overrides"),
+		Entry("accepts changes within the policy",
+			appsv1.VirtSquadMemberOverrideSpec{Member: "oksana", Replicas: ptr.To(int32(3)), Image: "registry.example.com/team/app:2"},
+			&appsv1.TeamMemberSpec{Name: ptr.To("oksana"), Overrides: &appsv1.OverridePolicy{
+				MinReplicas: ptr.To(int32(1)), MaxReplicas: ptr.To(int32(5)), AllowedImages: []string{"registry.example.com/team/*"},
+			}},
+			"", ""),
+		Entry("rejects replicas below the minimum",
+			appsv1.VirtSquadMemberOverrideSpec{Member: "oksana", Replicas: ptr.To(int32(0))},
+			&appsv1.TeamMemberSpec{Name: ptr.To("oksana"), Overrides: &appsv1.OverridePolicy{MinReplicas: ptr.To(int32(1))}},
+			reasonOverrideOutOfBounds, "below the minimum of 1"),
+		Entry("rejects replicas above the maximum",
+			appsv1.VirtSquadMemberOverrideSpec{Member: "oksana", Replicas: ptr.To(int32(9))},
+			&appsv1.TeamMemberSpec{Name: ptr.To("oksana"), Overrides: &appsv1.OverridePolicy{MaxReplicas: ptr.To(int32(5))}},
+			reasonOverrideOutOfBounds, "above the maximum of 5"),
+		Entry("rejects images when the policy allows none",
+			appsv1.VirtSquadMemberOverrideSpec{Member: "oksana", Image: "nginx:1.27"},
+			&appsv1.TeamMemberSpec{Name: ptr.To("oksana"), Overrides: &appsv1.OverridePolicy{}},
+			reasonOverrideOutOfBounds, "image nginx:1.27 matches none of the allowed images"),
+		Entry("reports every problem",
+			appsv1.VirtSquadMemberOverrideSpec{Member: "oksana", Replicas: ptr.To(int32(9)), Image: "nginx:1.27"},
+			&appsv1.TeamMemberSpec{Name: ptr.To("oksana"), Overrides: &appsv1.OverridePolicy{MaxReplicas: ptr.To(int32(5))}},
+			reasonOverrideOutOfBounds, "above the maximum of 5; image nginx:1.27"),
+	)
+})
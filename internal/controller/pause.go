@@ -0,0 +1,86 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+// reasonOperatorPaused is reported while the operator runs with --paused
+const reasonOperatorPaused = "OperatorPaused"
+
+// reconcilePaused refreshes the squad's pod lists, counts and conditions from the cluster
+// without creating, updating or deleting any of its resources, finalizers included. The desired
+// pods are still capped by the replica ceilings and the pod budget, but capped members are not
+// reported, so a paused operator emits no events.
+func (r *VirtSquadReconciler) reconcilePaused(ctx context.Context, virtSquad *appsv1.VirtSquad) error {
+	log := logf.FromContext(ctx)
+
+	if err := r.resolveTemplate(ctx, virtSquad); err != nil {
+		return err
+	}
+	if err := r.applyMemberOverrides(ctx, virtSquad); err != nil {
+		return err
+	}
+	status := virtSquad.Status.DeepCopy()
+	budget, err := r.newPodBudget(ctx, virtSquad)
+	if err != nil {
+		log.Error(err, "Failed to evaluate the pod budget")
+		return err
+	}
+	pods, err := r.listSquadPods(ctx, virtSquad)
+	if err != nil {
+		log.Error(err, "Failed to list squad pods")
+		return err
+	}
+	status.DesiredPods = 0
+	for _, member := range squadMembers(virtSquad, status) {
+		status.DesiredPods += r.desiredReplicas(virtSquad, status, member.name, member.spec, budget)
+		status.DesiredPods += r.standbyReplicas(virtSquad, status, member.name, member.spec, budget)
+
+		*member.statusPods = make([]string, 0, len(pods.members[member.name]))
+		for _, pod := range pods.members[member.name] {
+			if pod.DeletionTimestamp == nil {
+				*member.statusPods = append(*member.statusPods, pod.Name)
+			}
+		}
+	}
+	status.TotalPods = int32(len(status.OksanaPods) + len(status.KurtisPods) + len(status.MattPods) + len(status.KikePods))
+	status.ReadyPods = pods.ready(virtSquad)
+
+	updateSquadReady(virtSquad, status)
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               appsv1.ConditionPaused,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonOperatorPaused,
+		Message:            "The operator is paused; no pods or other resources are created or deleted",
+		ObservedGeneration: virtSquad.Generation,
+	})
+
+	virtSquad.Status = *status
+	if err := r.Status().Update(ctx, virtSquad); err != nil {
+		log.Error(err, "Failed to update VirtSquad status")
+		return err
+	}
+	return nil
+}
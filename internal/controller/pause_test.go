@@ -0,0 +1,54 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+var _ = Describe("Paused operator", func() {
+	It("should refresh the status of capped squads without emitting events", func() {
+		virtSquad := &appsv1.VirtSquad{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "squad"},
+			Spec: appsv1.VirtSquadSpec{
+				Oksana: &appsv1.TeamMemberSpec{Name: ptr.To("oksana"), Replicas: ptr.To(int32(10))},
+			},
+		}
+		r, c := newFakeReconciler(virtSquad)
+		r.Paused = true
+		r.MaxReplicasPerMember = 2
+		recorder := r.Recorder.(*record.FakeRecorder)
+
+		Expect(r.reconcilePaused(context.Background(), virtSquad)).To(Succeed())
+		Expect(recorder.Events).To(BeEmpty())
+
+		stored := &appsv1.VirtSquad{}
+		Expect(c.Get(context.Background(), client.ObjectKeyFromObject(virtSquad), stored)).To(Succeed())
+		Expect(stored.Status.DesiredPods).To(Equal(int32(2)))
+		Expect(meta.IsStatusConditionTrue(stored.Status.Conditions, appsv1.ConditionPaused)).To(BeTrue())
+	})
+})
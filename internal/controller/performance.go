@@ -0,0 +1,51 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+// applyPerformance renders the member's performance settings into its pod spec. Dedicated CPUs
+// set the container's CPU and memory limits to its requests for the Guaranteed QoS class, and the
+// NUMA node selector is added to the pod's node selector.
+func applyPerformance(podSpec *corev1.PodSpec, performance *appsv1.PerformanceSpec) {
+	if ptr.Deref(performance.DedicatedCPUs, false) {
+		resources := &podSpec.Containers[0].Resources
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			request, ok := resources.Requests[name]
+			if !ok {
+				continue
+			}
+			if resources.Limits == nil {
+				resources.Limits = corev1.ResourceList{}
+			}
+			resources.Limits[name] = request.DeepCopy()
+		}
+	}
+	if len(performance.NUMANodeSelector) > 0 {
+		if podSpec.NodeSelector == nil {
+			podSpec.NodeSelector = map[string]string{}
+		}
+		maps.Copy(podSpec.NodeSelector, performance.NUMANodeSelector)
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+// placementAffinity renders the squad's placement policy as affinity rules for a team member's
+// pods, or nil when the squad has no placement policy
+func placementAffinity(virtSquad *appsv1.VirtSquad, memberName string) *corev1.Affinity {
+	placement := virtSquad.Spec.Placement
+	if placement == nil {
+		return nil
+	}
+	topologyKey := placement.TopologyKey
+	if topologyKey == "" {
+		topologyKey = corev1.LabelHostname
+	}
+
+	var podAffinity []corev1.WeightedPodAffinityTerm
+	var podAntiAffinity []corev1.PodAffinityTerm
+	switch {
+	case ptr.Deref(placement.ColocateMembers, false):
+		podAffinity = append(podAffinity, corev1.WeightedPodAffinityTerm{
+			Weight: 100,
+			PodAffinityTerm: corev1.PodAffinityTerm{
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{squadLabel: virtSquad.Name},
+				},
+				TopologyKey: topologyKey,
+			},
+		})
+	case ptr.Deref(placement.SpreadMembers, false):
+		// Quarantined pods lose the member label, so they do not keep other members away
+		podAntiAffinity = append(podAntiAffinity, corev1.PodAffinityTerm{
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{squadLabel: virtSquad.Name},
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: memberLabel, Operator: metav1.LabelSelectorOpExists},
+					{Key: memberLabel, Operator: metav1.LabelSelectorOpNotIn, Values: []string{memberName}},
+				},
+			},
+			TopologyKey: topologyKey,
+		})
+	}
+
+	var avoid []string
+	for _, name := range placement.AvoidSquads {
+		if name != virtSquad.Name {
+			avoid = append(avoid, name)
+		}
+	}
+	if len(avoid) > 0 {
+		podAntiAffinity = append(podAntiAffinity, corev1.PodAffinityTerm{
+			LabelSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: squadLabel, Operator: metav1.LabelSelectorOpIn, Values: avoid},
+				},
+			},
+			TopologyKey: topologyKey,
+		})
+	}
+
+	affinity := &corev1.Affinity{}
+	if len(podAffinity) > 0 {
+		affinity.PodAffinity = &corev1.PodAffinity{PreferredDuringSchedulingIgnoredDuringExecution: podAffinity}
+	}
+	if len(podAntiAffinity) > 0 {
+		affinity.PodAntiAffinity = &corev1.PodAntiAffinity{RequiredDuringSchedulingIgnoredDuringExecution: podAntiAffinity}
+	}
+	if affinity.PodAffinity == nil && affinity.PodAntiAffinity == nil {
+		return nil
+	}
+	return affinity
+}
+
+// zoneNodeAffinity requires nodes in one of the listed zones
+func zoneNodeAffinity(zones []string) *corev1.NodeAffinity {
+	return &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+				MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key:      corev1.LabelTopologyZone,
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   zones,
+				}},
+			}},
+		},
+	}
+}
+
+// podsPerZone counts the pods pinned to each zone
+func podsPerZone(pods []corev1.Pod) map[string]int {
+	counts := map[string]int{}
+	for _, pod := range pods {
+		if zone := pod.Spec.NodeSelector[corev1.LabelTopologyZone]; zone != "" {
+			counts[zone]++
+		}
+	}
+	return counts
+}
+
+// nextZone returns the listed zone with the fewest pods, preferring earlier zones on ties,
+// and counts the new pod toward it. It returns an empty string when no zones are listed.
+func nextZone(zones []string, counts map[string]int) string {
+	zone := ""
+	for _, candidate := range zones {
+		if zone == "" || counts[candidate] < counts[zone] {
+			zone = candidate
+		}
+	}
+	if zone != "" {
+		counts[zone]++
+	}
+	return zone
+}
+
+// pinToZone returns a copy of the pod spec that only schedules into the zone, or the pod spec
+// itself when the zone is empty. The template hash is computed before pinning, so pods of one
+// revision share a hash whichever zone they run in.
+func pinToZone(podSpec corev1.PodSpec, zone string) corev1.PodSpec {
+	if zone == "" {
+		return podSpec
+	}
+	pinned := *podSpec.DeepCopy()
+	if pinned.NodeSelector == nil {
+		pinned.NodeSelector = map[string]string{}
+	}
+	pinned.NodeSelector[corev1.LabelTopologyZone] = zone
+	return pinned
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+	"github.com/mshort55/virtsquad-operator/internal/hooks"
+)
+
+// Reasons passed to the preDelete hook describing why a pod is deleted
+const (
+	deleteReasonScaleDown       = "ScaleDown"
+	deleteReasonMemberRemoved   = "MemberRemoved"
+	deleteReasonFinalize        = "SquadDeleted"
+	deleteReasonRecreate        = "Recreate"
+	deleteReasonTerminated      = "Terminated"
+	deleteReasonChaos           = "Chaos"
+	deleteReasonSpotInterrupted = "SpotInterrupted"
+	deleteReasonNodeDrain       = "NodeDrain"
+
+	// reasonPreDeleteHookFailed is the event reason used when the preDelete hook cannot be called
+	reasonPreDeleteHookFailed = "PreDeleteHookFailed"
+)
+
+// deletePod deletes a squad pod after notifying the squad's preDelete hook, if one is configured
+func (r *VirtSquadReconciler) deletePod(ctx context.Context, virtSquad *appsv1.VirtSquad, pod *corev1.Pod, reason string) error {
+	log := logf.FromContext(ctx)
+
+	if err := r.callPreDeleteHook(ctx, virtSquad, pod, reason); err != nil {
+		log.Error(err, "PreDelete hook failed", "pod", pod.Name)
+		r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonPreDeleteHookFailed,
+			"PreDelete hook failed for pod %s: %v", pod.Name, err)
+		if virtSquad.Spec.Hooks.PreDelete.FailurePolicy == appsv1.HookFailurePolicyFail {
+			return err
+		}
+	}
+
+	if err := r.Delete(ctx, pod); err != nil {
+		return err
+	}
+	countOutcome(outcomeDeleted, reason)
+	return nil
+}
+
+// callPreDeleteHook posts the pod's metadata to the squad's preDelete hook
+func (r *VirtSquadReconciler) callPreDeleteHook(ctx context.Context, virtSquad *appsv1.VirtSquad, pod *corev1.Pod, reason string) error {
+	if virtSquad.Spec.Hooks == nil || virtSquad.Spec.Hooks.PreDelete == nil {
+		return nil
+	}
+	hook := virtSquad.Spec.Hooks.PreDelete
+
+	token := ""
+	if hook.AuthSecretRef != nil {
+		value, err := r.secretValue(ctx, virtSquad, hook.AuthSecretRef)
+		if err != nil {
+			return fmt.Errorf("reading hook auth token: %w", err)
+		}
+		token = value
+	}
+
+	timeout := 10 * time.Second
+	if hook.TimeoutSeconds != nil {
+		timeout = time.Duration(*hook.TimeoutSeconds) * time.Second
+	}
+
+	memberName := pod.Labels[memberLabel]
+	if memberName == "" {
+		memberName = pod.Labels[quarantinedLabel]
+	}
+
+	hookClient := r.Hooks
+	if hookClient == nil {
+		hookClient = hooks.NewClient()
+	}
+	return hookClient.Post(ctx, hook.URL, token, timeout, hooks.PreDeletePayload{
+		Squad:     virtSquad.Name,
+		Namespace: virtSquad.Namespace,
+		Member:    memberName,
+		Pod:       pod.Name,
+		PodIP:     pod.Status.PodIP,
+		NodeName:  pod.Spec.NodeName,
+		Labels:    pod.Labels,
+		Reason:    reason,
+	})
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+// isPodTerminated reports whether all of the pod's containers have exited for good
+func isPodTerminated(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
+
+// collectTerminatedPods splits off pods that have exited so that they no longer count toward
+// the member's replicas, and deletes the oldest ones beyond the squad's retention count.
+// It returns the pods that are still running or starting.
+func (r *VirtSquadReconciler) collectTerminatedPods(ctx context.Context, virtSquad *appsv1.VirtSquad, pods []corev1.Pod) ([]corev1.Pod, error) {
+	log := logf.FromContext(ctx)
+
+	live := make([]corev1.Pod, 0, len(pods))
+	var terminated []corev1.Pod
+	for _, pod := range pods {
+		if isPodTerminated(&pod) {
+			terminated = append(terminated, pod)
+			continue
+		}
+		live = append(live, pod)
+	}
+
+	retention := 0
+	if virtSquad.Spec.TerminatedPodRetention != nil {
+		retention = int(*virtSquad.Spec.TerminatedPodRetention)
+	}
+	if len(terminated) <= retention {
+		return live, nil
+	}
+
+	// Keep the most recently created terminated pods around for debugging
+	sort.Slice(terminated, func(i, j int) bool {
+		return terminated[j].CreationTimestamp.Before(&terminated[i].CreationTimestamp)
+	})
+	for i := retention; i < len(terminated); i++ {
+		log.Info("Deleting terminated pod", "pod", terminated[i].Name, "phase", terminated[i].Status.Phase)
+		if err := r.deletePod(ctx, virtSquad, &terminated[i], deleteReasonTerminated); err != nil {
+			log.Error(err, "Failed to delete terminated pod", "pod", terminated[i].Name)
+			return live, err
+		}
+	}
+
+	return live, nil
+}
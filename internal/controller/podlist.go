@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+// podOwnerKey is the cache index holding the UID of the VirtSquad controlling a pod
+const podOwnerKey = ".metadata.controller"
+
+// indexPodOwner indexes a pod by the UID of the VirtSquad controlling it
+func indexPodOwner(obj client.Object) []string {
+	owner := metav1.GetControllerOf(obj)
+	if owner == nil || owner.APIVersion != appsv1.GroupVersion.String() || owner.Kind != "VirtSquad" {
+		return nil
+	}
+	return []string{string(owner.UID)}
+}
+
+// forEachPod calls fn for every pod in the cache matching the list options, stopping at the
+// first error. The cache already holds every pod, so listing it in pages would save no memory.
+func (r *VirtSquadReconciler) forEachPod(ctx context.Context, fn func(pod *corev1.Pod) error, opts ...client.ListOption) error {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, opts...); err != nil {
+		return err
+	}
+	for i := range pods.Items {
+		if err := fn(&pods.Items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forEachPagedPod calls fn for every pod matching the list options like forEachPod. With a
+// PodListPageSize set, pods are read from the API server in pages of that size instead, and each
+// page is released before the next one is read, so listing the pods of every squad in the
+// cluster never holds them in memory as one list.
+func (r *VirtSquadReconciler) forEachPagedPod(ctx context.Context, fn func(pod *corev1.Pod) error, opts ...client.ListOption) error {
+	if r.PodListPageSize == 0 || r.APIReader == nil {
+		return r.forEachPod(ctx, fn, opts...)
+	}
+	opts = append(opts, client.Limit(r.PodListPageSize))
+	continueToken := ""
+	for {
+		pods := &corev1.PodList{}
+		if err := r.APIReader.List(ctx, pods, append(opts, client.Continue(continueToken))...); err != nil {
+			return err
+		}
+		for i := range pods.Items {
+			if err := fn(&pods.Items[i]); err != nil {
+				return err
+			}
+		}
+		if pods.Continue == "" {
+			return nil
+		}
+		continueToken = pods.Continue
+	}
+}
+
+// forEachSquadPod calls fn for every pod the squad controls that carries the given labels. Pods
+// are found through the owner index, so relabeling a pod cannot move it into or out of a squad.
+// Until the index is registered, pods are selected by the squad label.
+func (r *VirtSquadReconciler) forEachSquadPod(ctx context.Context, virtSquad *appsv1.VirtSquad, podLabels map[string]string, fn func(pod *corev1.Pod) error) error {
+	if r.ownerIndexed {
+		return r.forEachPod(ctx, fn, client.InNamespace(virtSquad.Namespace),
+			client.MatchingFields{podOwnerKey: string(virtSquad.UID)}, client.MatchingLabels(podLabels))
+	}
+	selector := maps.Clone(podLabels)
+	if selector == nil {
+		selector = map[string]string{}
+	}
+	selector[squadLabel] = virtSquad.Name
+	return r.forEachPod(ctx, fn, client.InNamespace(virtSquad.Namespace), client.MatchingLabels(selector))
+}
+
+// squadPods are the pods of a squad, listed once per reconcile and partitioned by team member
+type squadPods struct {
+	// members holds the pods of each team member, including those being deleted
+	members map[string][]corev1.Pod
+	// quarantined names the squad's quarantined pods
+	quarantined []string
+}
+
+// listSquadPods lists the squad's pods in a single call and partitions them by team member
+func (r *VirtSquadReconciler) listSquadPods(ctx context.Context, virtSquad *appsv1.VirtSquad) (*squadPods, error) {
+	pods := &squadPods{members: map[string][]corev1.Pod{}}
+	err := r.forEachSquadPod(ctx, virtSquad, nil, func(pod *corev1.Pod) error {
+		if _, ok := pod.Labels[quarantinedLabel]; ok {
+			pods.quarantined = append(pods.quarantined, pod.Name)
+			return nil
+		}
+		if member, ok := pod.Labels[memberLabel]; ok && pod.Labels[appLabel] == appLabelValue {
+			pods.members[member] = append(pods.members[member], *pod)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pods, nil
+}
+
+// ready counts the team members' pods that are ready and passed their smoke test
+func (p *squadPods) ready(virtSquad *appsv1.VirtSquad) int32 {
+	readyCount := int32(0)
+	for _, pods := range p.members {
+		for i := range pods {
+			if isPodReady(&pods[i]) && smokeTested(virtSquad, &pods[i]) {
+				readyCount++
+			}
+		}
+	}
+	return readyCount
+}
+
+// listPods returns every pod in the cache matching the list options
+func (r *VirtSquadReconciler) listPods(ctx context.Context, opts ...client.ListOption) ([]corev1.Pod, error) {
+	var pods []corev1.Pod
+	err := r.forEachPod(ctx, func(pod *corev1.Pod) error {
+		pods = append(pods, *pod)
+		return nil
+	}, opts...)
+	return pods, err
+}
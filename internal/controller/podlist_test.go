@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+var _ = Describe("Pod listing", func() {
+	It("should read a squad's pods from the cache even when a page size is set", func() {
+		virtSquad := &appsv1.VirtSquad{ObjectMeta: metav1.ObjectMeta{Name: "alpha", Namespace: "default"}}
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      "oksana-pod",
+			Namespace: "default",
+			Labels:    map[string]string{appLabel: appLabelValue, squadLabel: "alpha", memberLabel: "oksana"},
+		}}
+		r, _ := newFakeReconciler(virtSquad, pod)
+		r.PodListPageSize = 1
+		r.APIReader = fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+			List: func(context.Context, client.WithWatch, client.ObjectList, ...client.ListOption) error {
+				return fmt.Errorf("reconciles must not list pods from the API server")
+			},
+		}).Build()
+
+		pods, err := r.listSquadPods(context.Background(), virtSquad)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pods.members).To(HaveKeyWithValue("oksana", HaveLen(1)))
+	})
+
+	It("should read pods from the API server in pages when a page size is set", func() {
+		// The API server serves two pages of one pod each
+		var limits []int64
+		r, _ := newFakeReconciler()
+		r.PodListPageSize = 1
+		r.APIReader = fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+			List: func(_ context.Context, _ client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				listOpts := &client.ListOptions{}
+				listOpts.ApplyOptions(opts)
+				limits = append(limits, listOpts.Limit)
+				pods := list.(*corev1.PodList)
+				if listOpts.Continue == "" {
+					pods.Items = []corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "first"}}}
+					pods.Continue = "second-page"
+					return nil
+				}
+				Expect(listOpts.Continue).To(Equal("second-page"))
+				pods.Items = []corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "second"}}}
+				return nil
+			},
+		}).Build()
+
+		var names []string
+		Expect(r.forEachPagedPod(context.Background(), func(pod *corev1.Pod) error {
+			names = append(names, pod.Name)
+			return nil
+		})).To(Succeed())
+		Expect(names).To(Equal([]string{"first", "second"}))
+		Expect(limits).To(Equal([]int64{1, 1}))
+	})
+})
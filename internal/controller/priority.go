@@ -0,0 +1,63 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// highPriority is the priority of squads that were just created or are being deleted, ahead of
+// ordinary changes at priority zero and of resyncs at handler.LowPriority
+const highPriority = 100
+
+// addWithPriority enqueues the request with the given priority when the controller runs a
+// priority queue, and plainly otherwise
+func addWithPriority(q workqueue.TypedRateLimitingInterface[reconcile.Request], request reconcile.Request, priority int) {
+	if pq, ok := q.(priorityqueue.PriorityQueue[reconcile.Request]); ok {
+		pq.AddWithOpts(priorityqueue.AddOpts{Priority: priority}, request)
+		return
+	}
+	q.Add(request)
+}
+
+// prioritizeLifecycle raises squads that were just created, or just started or finished being
+// deleted, to highPriority, so they get their first pods or are cleaned up before busy operators
+// work through the squads that merely changed or resynced. A squad already queued keeps the
+// higher of its priorities.
+var prioritizeLifecycle = handler.Funcs{
+	CreateFunc: func(_ context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+		// Squads listed at startup are not new; they are left to the default low priority
+		if !e.IsInInitialList {
+			addWithPriority(q, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(e.Object)}, highPriority)
+		}
+	},
+	UpdateFunc: func(_ context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+		if e.ObjectOld.GetDeletionTimestamp() == nil && e.ObjectNew.GetDeletionTimestamp() != nil {
+			addWithPriority(q, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(e.ObjectNew)}, highPriority)
+		}
+	},
+	DeleteFunc: func(_ context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+		addWithPriority(q, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(e.Object)}, highPriority)
+	},
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+// reasonPodQuarantined is the event reason used when a pod is detached from its team member
+const reasonPodQuarantined = "PodQuarantined"
+
+// quarantinePods relabels pods carrying the quarantine annotation so that they no longer match
+// their team member's selector. The pods keep the squad label and owner reference, so they are
+// still cleaned up with the squad. It returns the pods that remain part of the team member.
+func (r *VirtSquadReconciler) quarantinePods(ctx context.Context, virtSquad *appsv1.VirtSquad, pods []corev1.Pod) ([]corev1.Pod, error) {
+	log := logf.FromContext(ctx)
+
+	remaining := make([]corev1.Pod, 0, len(pods))
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Annotations[appsv1.QuarantineAnnotation] != "true" {
+			remaining = append(remaining, *pod)
+			continue
+		}
+
+		memberName := pod.Labels[memberLabel]
+		delete(pod.Labels, appLabel)
+		delete(pod.Labels, memberLabel)
+		pod.Labels[quarantinedLabel] = memberName
+
+		log.Info("Quarantining pod", "pod", pod.Name, "member", memberName)
+		if err := r.Update(ctx, pod); err != nil {
+			log.Error(err, "Failed to quarantine pod", "pod", pod.Name)
+			return pods, err
+		}
+		r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonPodQuarantined,
+			"Quarantined pod %s of team member %s; a replacement will be created", pod.Name, memberName)
+	}
+
+	return remaining, nil
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+// maxRenderReconciles bounds the reconciles run per squad while rendering, since pods never
+// become ready without a cluster and some reconciles would otherwise requeue forever
+const maxRenderReconciles = 10
+
+// Render returns the Pods and Services the operator would create for the VirtSquads among objs
+// as a multi-document YAML manifest, without applying anything. The squads are reconciled
+// against an in-memory client seeded with objs, so the templates, overrides and quotas listed
+// alongside them are taken into account. Settings that only reach outside the cluster, such as
+// notifications, hooks and backups, are dropped from the squads first.
+func (r *VirtSquadReconciler) Render(ctx context.Context, objs []client.Object) ([]byte, error) {
+	var squads []types.NamespacedName
+	seeded := make([]client.Object, 0, len(objs))
+	for _, obj := range objs {
+		obj = obj.DeepCopyObject().(client.Object)
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(corev1.NamespaceDefault)
+		}
+		if virtSquad, ok := obj.(*appsv1.VirtSquad); ok {
+			renderable(virtSquad)
+			squads = append(squads, client.ObjectKeyFromObject(virtSquad))
+		}
+		seeded = append(seeded, obj)
+	}
+	if len(squads) == 0 {
+		return nil, fmt.Errorf("no VirtSquad to render")
+	}
+
+	renderer := &VirtSquadReconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(r.Scheme).
+			WithObjects(seeded...).
+			WithStatusSubresource(&appsv1.VirtSquad{}, &appsv1.VirtSquadMemberOverride{}).
+			Build(),
+		Scheme:               r.Scheme,
+		Recorder:             &record.FakeRecorder{},
+		DebugImage:           r.DebugImage,
+		MaxReplicasPerMember: r.MaxReplicasPerMember,
+		MaxPodsPerSquad:      r.MaxPodsPerSquad,
+	}
+
+	var out bytes.Buffer
+	for _, key := range squads {
+		for range maxRenderReconciles {
+			result, err := renderer.reconcileSquad(ctx, ctrl.Request{NamespacedName: key})
+			if err != nil {
+				return nil, fmt.Errorf("rendering VirtSquad %s: %w", key, err)
+			}
+			if result.IsZero() {
+				break
+			}
+		}
+
+		manifests, err := renderer.renderedObjects(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		for _, manifest := range manifests {
+			out.WriteString("---\n")
+			out.Write(manifest)
+		}
+	}
+	return out.Bytes(), nil
+}
+
+// renderable strips a squad of its status and of the settings that act outside the cluster
+func renderable(virtSquad *appsv1.VirtSquad) {
+	virtSquad.Status = appsv1.VirtSquadStatus{}
+	virtSquad.Spec.Notifications = nil
+	virtSquad.Spec.Hooks = nil
+	virtSquad.Spec.Backup = nil
+	virtSquad.Spec.TargetNamespaces = nil
+	virtSquad.Spec.NamespaceSelector = nil
+	for _, annotation := range []string{appsv1.RestoreFromAnnotation, appsv1.ImportAnnotation, appsv1.ScaleRequestAnnotation, appsv1.DebugPodAnnotation} {
+		delete(virtSquad.Annotations, annotation)
+	}
+}
+
+// renderedObjects returns the YAML manifests of the Services and pods created for a squad,
+// sorted by kind and name
+func (r *VirtSquadReconciler) renderedObjects(ctx context.Context, key types.NamespacedName) ([][]byte, error) {
+	virtSquad := &appsv1.VirtSquad{}
+	if err := r.Get(ctx, key, virtSquad); err != nil {
+		return nil, err
+	}
+
+	var objects []client.Object
+	services := &corev1.ServiceList{}
+	if err := r.List(ctx, services, client.InNamespace(key.Namespace)); err != nil {
+		return nil, err
+	}
+	sort.Slice(services.Items, func(i, j int) bool { return services.Items[i].Name < services.Items[j].Name })
+	for i := range services.Items {
+		if metav1.IsControlledBy(&services.Items[i], virtSquad) {
+			services.Items[i].Status = corev1.ServiceStatus{}
+			objects = append(objects, &services.Items[i])
+		}
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(key.Namespace), client.MatchingLabels{squadLabel: key.Name}); err != nil {
+		return nil, err
+	}
+	sort.Slice(pods.Items, func(i, j int) bool { return pods.Items[i].Name < pods.Items[j].Name })
+	for i := range pods.Items {
+		pods.Items[i].Status = corev1.PodStatus{}
+		// The reconcile ID differs on every run and would only add noise to reviews
+		delete(pods.Items[i].Annotations, appsv1.ReconcileIDAnnotation)
+		objects = append(objects, &pods.Items[i])
+	}
+
+	manifests := make([][]byte, 0, len(objects))
+	for _, obj := range objects {
+		exported, err := r.exportObject(obj)
+		if err != nil {
+			return nil, err
+		}
+		// Drop the empty status and creation timestamp left on objects that were never applied
+		fields := map[string]any{}
+		if err := json.Unmarshal(exported.Raw, &fields); err != nil {
+			return nil, err
+		}
+		delete(fields, "status")
+		if metadata, ok := fields["metadata"].(map[string]any); ok {
+			delete(metadata, "creationTimestamp")
+		}
+		manifest, err := yaml.Marshal(fields)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, manifest)
+	}
+	return manifests, nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+var _ = Describe("Render", func() {
+	It("should render the squad's pods and Services without a cluster", func() {
+		template := &appsv1.VirtSquadTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "base"},
+			Spec: appsv1.VirtSquadTemplateSpec{
+				Kike: &appsv1.TeamMemberSpec{Name: ptr.To("kike"), Image: "busybox"},
+			},
+		}
+		virtSquad := &appsv1.VirtSquad{
+			ObjectMeta: metav1.ObjectMeta{Name: "render-squad"},
+			Spec: appsv1.VirtSquadSpec{
+				TemplateRef: &appsv1.TemplateReference{Name: "base"},
+				Oksana: &appsv1.TeamMemberSpec{
+					Name:     ptr.To("oksana"),
+					Replicas: ptr.To(int32(3)),
+					Service:  &appsv1.MemberServiceSpec{},
+				},
+			},
+		}
+
+		out, err := (&VirtSquadReconciler{Scheme: scheme.Scheme, MaxReplicasPerMember: 2}).
+			Render(context.Background(), []client.Object{template, virtSquad})
+		Expect(err).NotTo(HaveOccurred())
+
+		manifest := string(out)
+		Expect(manifest).To(ContainSubstring("kind: Service\n"))
+		Expect(manifest).To(ContainSubstring("name: render-squad-oksana\n"))
+		Expect(manifest).To(ContainSubstring("name: oksana-0\n"))
+		Expect(manifest).To(ContainSubstring("name: oksana-1\n"))
+		Expect(manifest).NotTo(ContainSubstring("name: oksana-2\n"))
+		Expect(manifest).To(ContainSubstring("name: kike-0\n"))
+		Expect(manifest).NotTo(ContainSubstring(appsv1.ReconcileIDAnnotation))
+		Expect(manifest).NotTo(ContainSubstring("resourceVersion"))
+	})
+
+	It("should require a squad", func() {
+		_, err := (&VirtSquadReconciler{Scheme: scheme.Scheme}).Render(context.Background(), nil)
+		Expect(err).To(HaveOccurred())
+	})
+})
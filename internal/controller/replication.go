@@ -0,0 +1,253 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+// reasonReplicaConflict is the event reason used when a replica namespace already holds a
+// VirtSquad of the same name that is not a copy of the replicated squad
+const reasonReplicaConflict = "ReplicaConflict"
+
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// replicated reports whether a squad is replicated into other namespaces
+func replicated(virtSquad *appsv1.VirtSquad) bool {
+	return len(virtSquad.Spec.TargetNamespaces) > 0 || virtSquad.Spec.NamespaceSelector != nil
+}
+
+// isReplicaOf reports whether replica is a copy of the squad replicated from namespace
+func isReplicaOf(replica *appsv1.VirtSquad, namespace, name string) bool {
+	return replica.Name == name && replica.Labels[appsv1.ReplicaOfLabel] == namespace
+}
+
+// replicaNamespaces returns the existing namespaces, other than its own, a squad is replicated into
+func (r *VirtSquadReconciler) replicaNamespaces(ctx context.Context, virtSquad *appsv1.VirtSquad) (map[string]bool, error) {
+	targets := map[string]bool{}
+	for _, name := range virtSquad.Spec.TargetNamespaces {
+		namespace := &corev1.Namespace{}
+		if err := r.Get(ctx, client.ObjectKey{Name: name}, namespace); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if namespace.DeletionTimestamp == nil {
+			targets[namespace.Name] = true
+		}
+	}
+
+	if virtSquad.Spec.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(virtSquad.Spec.NamespaceSelector)
+		if err != nil {
+			return nil, err
+		}
+		namespaces := &corev1.NamespaceList{}
+		if err := r.List(ctx, namespaces, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, err
+		}
+		for _, namespace := range namespaces.Items {
+			if namespace.DeletionTimestamp == nil {
+				targets[namespace.Name] = true
+			}
+		}
+	}
+
+	delete(targets, virtSquad.Namespace)
+	return targets, nil
+}
+
+// listReplicas returns the copies of a squad in other namespaces
+func (r *VirtSquadReconciler) listReplicas(ctx context.Context, virtSquad *appsv1.VirtSquad) ([]appsv1.VirtSquad, error) {
+	squads := &appsv1.VirtSquadList{}
+	if err := r.List(ctx, squads, client.MatchingLabels{appsv1.ReplicaOfLabel: virtSquad.Namespace}); err != nil {
+		return nil, err
+	}
+	var replicas []appsv1.VirtSquad
+	for _, replica := range squads.Items {
+		if isReplicaOf(&replica, virtSquad.Namespace, virtSquad.Name) {
+			replicas = append(replicas, replica)
+		}
+	}
+	return replicas, nil
+}
+
+// reconcileReplicas copies the squad into each namespace it is replicated into, deletes the
+// copies in namespaces it no longer targets, and reports the copies' status
+func (r *VirtSquadReconciler) reconcileReplicas(ctx context.Context, virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus) error {
+	log := logf.FromContext(ctx)
+
+	targets := map[string]bool{}
+	if replicated(virtSquad) {
+		var err error
+		if targets, err = r.replicaNamespaces(ctx, virtSquad); err != nil {
+			log.Error(err, "Failed to resolve replica namespaces")
+			return err
+		}
+	}
+
+	existing, err := r.listReplicas(ctx, virtSquad)
+	if err != nil {
+		log.Error(err, "Failed to list squad replicas")
+		return err
+	}
+	replicas := map[string]*appsv1.VirtSquad{}
+	for i := range existing {
+		replica := &existing[i]
+		if targets[replica.Namespace] {
+			replicas[replica.Namespace] = replica
+			continue
+		}
+		log.Info("Deleting replica from namespace that is no longer targeted", "namespace", replica.Namespace)
+		if err := r.Delete(ctx, replica); err != nil && !errors.IsNotFound(err) {
+			log.Error(err, "Failed to delete replica", "namespace", replica.Namespace)
+			return err
+		}
+	}
+
+	for namespace := range targets {
+		replica, err := r.replicateSquad(ctx, virtSquad, namespace)
+		if err != nil {
+			return err
+		}
+		if replica != nil {
+			replicas[namespace] = replica
+		}
+	}
+
+	status.ReplicaNamespaces = nil
+	status.ReadyReplicaNamespaces = 0
+	for namespace, replica := range replicas {
+		ready := meta.IsStatusConditionTrue(replica.Status.Conditions, appsv1.ConditionReady)
+		status.ReplicaNamespaces = append(status.ReplicaNamespaces, appsv1.ReplicaNamespaceStatus{
+			Namespace:   namespace,
+			ReadyPods:   replica.Status.ReadyPods,
+			DesiredPods: replica.Status.DesiredPods,
+			Ready:       ready,
+		})
+		if ready {
+			status.ReadyReplicaNamespaces++
+		}
+	}
+	sort.Slice(status.ReplicaNamespaces, func(i, j int) bool {
+		return status.ReplicaNamespaces[i].Namespace < status.ReplicaNamespaces[j].Namespace
+	})
+	return nil
+}
+
+// replicateSquad creates or updates the copy of a squad in a replica namespace. It returns nil
+// without an error when the namespace holds a squad of the same name that is not a copy of this
+// one, which is left alone.
+func (r *VirtSquadReconciler) replicateSquad(ctx context.Context, virtSquad *appsv1.VirtSquad, namespace string) (*appsv1.VirtSquad, error) {
+	log := logf.FromContext(ctx)
+
+	replica := &appsv1.VirtSquad{
+		ObjectMeta: metav1.ObjectMeta{Name: virtSquad.Name, Namespace: namespace},
+	}
+	err := r.Get(ctx, client.ObjectKeyFromObject(replica), replica)
+	switch {
+	case errors.IsNotFound(err):
+	case err != nil:
+		log.Error(err, "Failed to get replica", "namespace", namespace)
+		return nil, err
+	case !isReplicaOf(replica, virtSquad.Namespace, virtSquad.Name):
+		r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonReplicaConflict,
+			"Namespace %s already has a VirtSquad named %s that is not a replica of this squad", namespace, replica.Name)
+		return nil, nil
+	}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, replica, func() error {
+		if replica.Labels == nil {
+			replica.Labels = map[string]string{}
+		}
+		replica.Labels[appsv1.ReplicaOfLabel] = virtSquad.Namespace
+		replica.Spec = *virtSquad.Spec.DeepCopy()
+		replica.Spec.TargetNamespaces = nil
+		replica.Spec.NamespaceSelector = nil
+		// The spec already holds the template's members, and the template may not exist there
+		replica.Spec.TemplateRef = nil
+		return nil
+	})
+	if err != nil {
+		log.Error(err, "Failed to reconcile replica", "namespace", namespace)
+		return nil, err
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Info("Reconciled replica", "namespace", namespace, "operation", result)
+	}
+	return replica, nil
+}
+
+// deleteReplicas deletes every copy of a squad from the namespaces it was replicated into
+func (r *VirtSquadReconciler) deleteReplicas(ctx context.Context, virtSquad *appsv1.VirtSquad) error {
+	log := logf.FromContext(ctx)
+
+	replicas, err := r.listReplicas(ctx, virtSquad)
+	if err != nil {
+		log.Error(err, "Failed to list squad replicas for cleanup")
+		return err
+	}
+	for i := range replicas {
+		if err := r.Delete(ctx, &replicas[i]); err != nil && !errors.IsNotFound(err) {
+			log.Error(err, "Failed to delete replica during cleanup", "namespace", replicas[i].Namespace)
+			return err
+		}
+	}
+	return nil
+}
+
+// replicaSource returns a reconcile request for the squad a replica was copied from, so that a
+// change to the copy's status or spec is reported or reverted
+func (r *VirtSquadReconciler) replicaSource(_ context.Context, obj client.Object) []reconcile.Request {
+	namespace, ok := obj.GetLabels()[appsv1.ReplicaOfLabel]
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{Namespace: namespace, Name: obj.GetName()}}}
+}
+
+// squadsReplicatedIntoNamespace returns a reconcile request for every squad that may replicate
+// into a namespace, since creating, deleting or relabeling it can add or remove a replica
+func (r *VirtSquadReconciler) squadsReplicatedIntoNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
+	squads := &appsv1.VirtSquadList{}
+	if err := r.List(ctx, squads); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list squads")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range squads.Items {
+		spec := &squads.Items[i].Spec
+		if spec.NamespaceSelector != nil || slices.Contains(spec.TargetNamespaces, obj.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&squads.Items[i])})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	kappsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+// reasonResizedInPlace is the event reason used when a pod's resources are changed without recreating it
+const reasonResizedInPlace = "ResizedInPlace"
+
+// revisionPodSpec returns the pod spec recorded in the member's revision for a template hash
+func (r *VirtSquadReconciler) revisionPodSpec(ctx context.Context, virtSquad *appsv1.VirtSquad, memberName, templateHash string) (*corev1.PodSpec, error) {
+	revision := &kappsv1.ControllerRevision{}
+	key := client.ObjectKey{Namespace: virtSquad.Namespace, Name: memberRevisionName(virtSquad, memberName, templateHash)}
+	if err := r.Get(ctx, key, revision); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	podSpec := &corev1.PodSpec{}
+	if err := json.Unmarshal(revision.Data.Raw, podSpec); err != nil {
+		return nil, fmt.Errorf("failed to decode revision %s: %w", revision.Name, err)
+	}
+	return podSpec, nil
+}
+
+// onlyResourcesChanged reports whether the current pod spec differs from the previous one in
+// nothing but container resources, so that pods can be moved to it by an in-place resize
+func onlyResourcesChanged(previous *corev1.PodSpec, current corev1.PodSpec, templateHash string) bool {
+	if len(previous.Containers) != len(current.Containers) {
+		return false
+	}
+	resized := previous.DeepCopy()
+	for i := range resized.Containers {
+		resized.Containers[i].Resources = current.Containers[i].Resources
+	}
+	hash, err := computeHash(*resized)
+	return err == nil && hash == templateHash
+}
+
+// resizePodsInPlace moves outdated pods whose revision differs from the current one only in
+// container resources to the current revision through the pods/resize subresource, instead of
+// leaving them to the rollout to recreate. A pod the API server refuses to resize, for example
+// because the change would alter its QoS class, is left outdated and recreated by the rollout.
+func (r *VirtSquadReconciler) resizePodsInPlace(ctx context.Context, virtSquad *appsv1.VirtSquad, memberName string, pods []corev1.Pod, podSpec corev1.PodSpec, templateHash string) error {
+	log := logf.FromContext(ctx)
+
+	previousSpecs := map[string]*corev1.PodSpec{}
+	for i := range pods {
+		pod := &pods[i]
+		podHash := pod.Labels[templateHashLabel]
+		if podHash == templateHash {
+			continue
+		}
+
+		previous, seen := previousSpecs[podHash]
+		if !seen {
+			var err error
+			if previous, err = r.revisionPodSpec(ctx, virtSquad, memberName, podHash); err != nil {
+				log.Error(err, "Failed to get revision", "member", memberName, "revision", podHash)
+				return err
+			}
+			previousSpecs[podHash] = previous
+		}
+		if previous == nil || !onlyResourcesChanged(previous, podSpec, templateHash) {
+			continue
+		}
+
+		resized := pod.DeepCopy()
+		for c := range resized.Spec.Containers {
+			for _, container := range podSpec.Containers {
+				if container.Name == resized.Spec.Containers[c].Name {
+					resized.Spec.Containers[c].Resources = container.Resources
+				}
+			}
+		}
+		if err := r.SubResource("resize").Patch(ctx, resized, client.StrategicMergeFrom(pod)); err != nil {
+			log.Info("Cannot resize pod in place, falling back to recreation", "pod", pod.Name, "error", err.Error())
+			continue
+		}
+
+		log.Info("Resized pod in place", "pod", pod.Name, "member", memberName, "revision", templateHash)
+		if err := r.patchPodLabel(ctx, pod, templateHashLabel, templateHash); err != nil {
+			return err
+		}
+		r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonResizedInPlace,
+			"Resized pod %s of team member %s in place to revision %s", pod.Name, memberName, templateHash)
+	}
+	return nil
+}
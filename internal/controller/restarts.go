@@ -0,0 +1,87 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+// reasonOOMKilled is the termination reason of containers killed for exceeding their memory limit
+const reasonOOMKilled = "OOMKilled"
+
+var (
+	// memberRestarts exposes how often the containers of each team member's pods restarted
+	memberRestarts = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "virtsquad_member_container_restarts",
+		Help: "Restarts of the containers of a team member's current pods",
+	}, []string{"namespace", "squad", "member"})
+
+	// memberOOMKilled exposes how many of each team member's containers were last OOMKilled
+	memberOOMKilled = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "virtsquad_member_oom_killed_containers",
+		Help: "Containers of a team member's current pods whose last termination was an OOMKill",
+	}, []string{"namespace", "squad", "member"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(memberRestarts, memberOOMKilled)
+}
+
+// deleteRestartMetrics drops the restart gauges of a squad, or of one of its members when
+// memberName is set
+func deleteRestartMetrics(virtSquad *appsv1.VirtSquad, memberName string) {
+	labels := prometheus.Labels{"namespace": virtSquad.Namespace, "squad": virtSquad.Name}
+	if memberName != "" {
+		labels["member"] = memberName
+	}
+	memberRestarts.DeletePartialMatch(labels)
+	memberOOMKilled.DeletePartialMatch(labels)
+}
+
+// updateMemberRestarts aggregates the container restarts and terminations of the member's pods
+// into its status and metrics
+func updateMemberRestarts(virtSquad *appsv1.VirtSquad, member *appsv1.MemberStatus, pods []corev1.Pod) {
+	restarts, oomKilled := int32(0), int32(0)
+	for i := range pods {
+		statuses := append([]corev1.ContainerStatus{}, pods[i].Status.InitContainerStatuses...)
+		statuses = append(statuses, pods[i].Status.ContainerStatuses...)
+		for _, cs := range statuses {
+			restarts += cs.RestartCount
+			terminated := cs.LastTerminationState.Terminated
+			if terminated == nil {
+				continue
+			}
+			if terminated.Reason == reasonOOMKilled {
+				oomKilled++
+			}
+			if member.LastTerminationTime == nil || member.LastTerminationTime.Before(&terminated.FinishedAt) {
+				finishedAt := terminated.FinishedAt
+				member.LastTerminationTime = &finishedAt
+				member.LastTerminationReason = terminated.Reason
+			}
+		}
+	}
+	member.Restarts = restarts
+	member.OOMKilledContainers = oomKilled
+
+	memberRestarts.WithLabelValues(virtSquad.Namespace, virtSquad.Name, member.Name).Set(float64(restarts))
+	memberOOMKilled.WithLabelValues(virtSquad.Namespace, virtSquad.Name, member.Name).Set(float64(oomKilled))
+}
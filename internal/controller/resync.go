@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"hash/fnv"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// isResync reports whether an update event comes from the cache's periodic resync, which
+// replays every object unchanged
+func isResync(e event.UpdateEvent) bool {
+	return e.ObjectOld.GetResourceVersion() == e.ObjectNew.GetResourceVersion()
+}
+
+// notResync drops the update events of the cache's periodic resync
+var notResync = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool { return !isResync(e) },
+}
+
+// onlyResync passes only the update events of the cache's periodic resync
+var onlyResync = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+	UpdateFunc:  isResync,
+}
+
+// resyncDelay spreads the objects' resyncs over the jitter window: each object is delayed by a
+// fixed share of it derived from its name, so the same objects do not bunch up every resync
+func resyncDelay(obj client.Object, jitter time.Duration) time.Duration {
+	hasher := fnv.New64a()
+	_, _ = hasher.Write([]byte(obj.GetNamespace() + "/" + obj.GetName()))
+	return time.Duration(hasher.Sum64() % uint64(jitter))
+}
+
+// jitteredResync enqueues the objects replayed by the cache's periodic resync after their
+// resyncDelay, instead of all at once, and with low priority on a priority queue
+func jitteredResync(jitter time.Duration) handler.EventHandler {
+	return handler.Funcs{
+		UpdateFunc: func(_ context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(e.ObjectNew)}
+			if pq, ok := q.(priorityqueue.PriorityQueue[reconcile.Request]); ok {
+				pq.AddWithOpts(priorityqueue.AddOpts{After: resyncDelay(e.ObjectNew, jitter), Priority: handler.LowPriority}, request)
+				return
+			}
+			q.AddAfter(request, resyncDelay(e.ObjectNew, jitter))
+		},
+	}
+}
+
+// withJitteredResync makes the controller spread the periodic resync of its objects over the
+// jitter window. Resync events are dropped from all of the controller's watches, so owned
+// objects no longer enqueue their owners all at once, and the objects of the given type are
+// enqueued after their resyncDelay instead. A zero jitter leaves the controller unchanged.
+func withJitteredResync(blder *builder.Builder, mgr ctrl.Manager, obj client.Object, jitter time.Duration, predicates ...predicate.Predicate) *builder.Builder {
+	if jitter <= 0 {
+		return blder
+	}
+	predicates = append(predicates, onlyResync)
+	return blder.
+		WithEventFilter(notResync).
+		WatchesRawSource(source.Kind(mgr.GetCache(), obj, jitteredResync(jitter), predicates...))
+}
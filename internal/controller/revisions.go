@@ -0,0 +1,181 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	kappsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// revisionHistoryLimit is the number of old revisions kept per team member
+	revisionHistoryLimit = 10
+
+	// reasonRevisionNotFound is reported when rollbackTo names a revision that does not exist
+	reasonRevisionNotFound = "RevisionNotFound"
+)
+
+// memberRevisionName returns the name of the ControllerRevision holding a member's template
+func memberRevisionName(virtSquad *appsv1.VirtSquad, memberName, templateHash string) string {
+	return fmt.Sprintf("%s-%s-%s", virtSquad.Name, memberName, templateHash)
+}
+
+// reconcileRevisions records the member's rendered pod template as a ControllerRevision, prunes
+// old revisions and returns the pod spec and template hash the member's pods should run. When
+// rollbackTo is set, the pod spec is restored from that revision instead of the member spec.
+func (r *VirtSquadReconciler) reconcileRevisions(ctx context.Context, virtSquad *appsv1.VirtSquad, member *appsv1.MemberStatus, memberName string, memberSpec *appsv1.TeamMemberSpec) (corev1.PodSpec, string, error) {
+	log := logf.FromContext(ctx)
+
+	podSpec := memberPodSpec(virtSquad, memberName, memberSpec)
+	templateHash, err := computeHash(podSpec)
+	if err != nil {
+		return podSpec, "", err
+	}
+
+	revisionList := &kappsv1.ControllerRevisionList{}
+	if err := r.List(ctx, revisionList,
+		client.InNamespace(virtSquad.Namespace),
+		client.MatchingLabels(memberLabels(virtSquad, memberName)),
+	); err != nil {
+		log.Error(err, "Failed to list revisions", "member", memberName)
+		return podSpec, "", err
+	}
+	revisions := revisionList.Items
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+
+	// Persist the template rendered from the spec unless it is already known
+	known := false
+	for i := range revisions {
+		if revisions[i].Labels[templateHashLabel] == templateHash {
+			known = true
+			break
+		}
+	}
+	if !known {
+		data, err := json.Marshal(podSpec)
+		if err != nil {
+			return podSpec, "", err
+		}
+		revision := kappsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      memberRevisionName(virtSquad, memberName, templateHash),
+				Namespace: virtSquad.Namespace,
+				Labels:    memberLabels(virtSquad, memberName),
+			},
+			Data:     runtime.RawExtension{Raw: data},
+			Revision: 1,
+		}
+		revision.Labels[templateHashLabel] = templateHash
+		if len(revisions) > 0 {
+			revision.Revision = revisions[len(revisions)-1].Revision + 1
+		}
+		if err := controllerutil.SetControllerReference(virtSquad, &revision, r.Scheme); err != nil {
+			return podSpec, "", err
+		}
+		log.Info("Creating revision", "revision", revision.Name, "member", memberName, "number", revision.Revision)
+		if err := r.Create(ctx, &revision); err != nil && !errors.IsAlreadyExists(err) {
+			log.Error(err, "Failed to create revision", "revision", revision.Name)
+			return podSpec, "", err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if memberSpec.RollbackTo != nil {
+		found := false
+		for i := range revisions {
+			if revisions[i].Revision != *memberSpec.RollbackTo {
+				continue
+			}
+			var restored corev1.PodSpec
+			if err := json.Unmarshal(revisions[i].Data.Raw, &restored); err != nil {
+				return podSpec, "", fmt.Errorf("failed to decode revision %s: %w", revisions[i].Name, err)
+			}
+			podSpec = restored
+			templateHash = revisions[i].Labels[templateHashLabel]
+			found = true
+			break
+		}
+		if !found {
+			r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonRevisionNotFound,
+				"Team member %s cannot roll back to revision %d: revision not found; using the current spec",
+				memberName, *memberSpec.RollbackTo)
+		}
+	}
+
+	// Drop the oldest revisions beyond the history limit, keeping the ones pods still run
+	member.CurrentRevisionNumber = 0
+	member.UpdateRevisionNumber = 0
+	excess := len(revisions) - revisionHistoryLimit
+	for i := range revisions {
+		hash := revisions[i].Labels[templateHashLabel]
+		switch {
+		case hash == templateHash:
+			member.UpdateRevisionNumber = revisions[i].Revision
+		case hash == member.CurrentRevision:
+			member.CurrentRevisionNumber = revisions[i].Revision
+		case excess > 0:
+			log.Info("Deleting old revision", "revision", revisions[i].Name, "member", memberName)
+			if err := r.Delete(ctx, &revisions[i]); err != nil && !errors.IsNotFound(err) {
+				log.Error(err, "Failed to delete revision", "revision", revisions[i].Name)
+				return podSpec, "", err
+			}
+			excess--
+		}
+	}
+	if member.CurrentRevision == templateHash {
+		member.CurrentRevisionNumber = member.UpdateRevisionNumber
+	}
+
+	return podSpec, templateHash, nil
+}
+
+// deleteMemberRevisions removes the revision history of a team member that is no longer configured
+func (r *VirtSquadReconciler) deleteMemberRevisions(ctx context.Context, virtSquad *appsv1.VirtSquad, memberName string) error {
+	log := logf.FromContext(ctx)
+
+	revisionList := &kappsv1.ControllerRevisionList{}
+	if err := r.List(ctx, revisionList,
+		client.InNamespace(virtSquad.Namespace),
+		client.MatchingLabels(memberLabels(virtSquad, memberName)),
+	); err != nil {
+		log.Error(err, "Failed to list revisions for deletion", "member", memberName)
+		return err
+	}
+
+	for i := range revisionList.Items {
+		if err := r.Delete(ctx, &revisionList.Items[i]); err != nil && !errors.IsNotFound(err) {
+			log.Error(err, "Failed to delete revision", "revision", revisionList.Items[i].Name)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,409 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+	"github.com/mshort55/virtsquad-operator/internal/notifications"
+)
+
+const (
+	// deleteReasonRollout is passed to the preDelete hook when an outdated pod is replaced
+	deleteReasonRollout = "Rollout"
+
+	reasonRollingOut         = "RollingOut"
+	reasonRolloutComplete    = "RolloutComplete"
+	reasonCanaryStepComplete = "CanaryStepComplete"
+	reasonCanaryAborted      = "CanaryAborted"
+	reasonPartitioned        = "Partitioned"
+	// reasonProgressDeadlineExceeded is reported when replaced pods did not become ready in time
+	reasonProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+	// reasonRolloutPaused is reported while a member's rollout is paused
+	reasonRolloutPaused = "RolloutPaused"
+)
+
+// podOrdinal returns the ordinal suffix of a pod's name, or -1 if it has none
+func podOrdinal(pod *corev1.Pod) int {
+	idx := strings.LastIndex(pod.Name, "-")
+	if idx < 0 {
+		return -1
+	}
+	ordinal, err := strconv.Atoi(pod.Name[idx+1:])
+	if err != nil {
+		return -1
+	}
+	return ordinal
+}
+
+// isPodNamedAfter reports whether a pod's name is the member's pod name followed by an ordinal,
+// which is not the case for pods created before the member was renamed
+func isPodNamedAfter(pod *corev1.Pod, name string) bool {
+	idx := strings.LastIndex(pod.Name, "-")
+	return idx >= 0 && pod.Name[:idx] == name && podOrdinal(pod) >= 0
+}
+
+// isPodUpdated reports whether a pod was rendered from the given template hash
+func isPodUpdated(pod *corev1.Pod, templateHash string) bool {
+	return pod.Labels[templateHashLabel] == templateHash
+}
+
+// templateContainer keeps the fields of a container that a pod template sets, with the defaults
+// the API server applies to them filled in, so a pod's container can be compared to the template's
+func templateContainer(container corev1.Container) corev1.Container {
+	ports := slices.Clone(container.Ports)
+	for i := range ports {
+		if ports[i].Protocol == "" {
+			ports[i].Protocol = corev1.ProtocolTCP
+		}
+	}
+	env := make([]corev1.EnvVar, len(container.Env))
+	for i, variable := range container.Env {
+		variable = *variable.DeepCopy()
+		if ref := variable.ValueFrom; ref != nil && ref.FieldRef != nil && ref.FieldRef.APIVersion == "" {
+			ref.FieldRef.APIVersion = "v1"
+		}
+		env[i] = variable
+	}
+	return corev1.Container{
+		Name:            container.Name,
+		Image:           container.Image,
+		Command:         container.Command,
+		Args:            container.Args,
+		Env:             env,
+		Ports:           ports,
+		Resources:       container.Resources,
+		SecurityContext: container.SecurityContext,
+	}
+}
+
+// podMatchesTemplate reports whether a pod runs what the pod spec renders: the same containers
+// and placement. Defaults and tolerations the API server adds to the pod are ignored.
+func podMatchesTemplate(pod *corev1.Pod, podSpec *corev1.PodSpec) bool {
+	containersMatch := func(running, rendered []corev1.Container) bool {
+		if len(running) != len(rendered) {
+			return false
+		}
+		for i := range rendered {
+			if !equality.Semantic.DeepEqual(templateContainer(running[i]), templateContainer(rendered[i])) {
+				return false
+			}
+		}
+		return true
+	}
+	podSecurityContext := func(spec *corev1.PodSpec) *corev1.PodSecurityContext {
+		if spec.SecurityContext == nil {
+			return &corev1.PodSecurityContext{}
+		}
+		return spec.SecurityContext
+	}
+	for _, toleration := range podSpec.Tolerations {
+		if !slices.ContainsFunc(pod.Spec.Tolerations, func(running corev1.Toleration) bool {
+			return equality.Semantic.DeepEqual(running, toleration)
+		}) {
+			return false
+		}
+	}
+	return containersMatch(pod.Spec.InitContainers, podSpec.InitContainers) &&
+		containersMatch(pod.Spec.Containers, podSpec.Containers) &&
+		equality.Semantic.DeepEqual(pod.Spec.NodeSelector, podSpec.NodeSelector) &&
+		equality.Semantic.DeepEqual(pod.Spec.Affinity, podSpec.Affinity) &&
+		equality.Semantic.DeepEqual(pod.Spec.RuntimeClassName, podSpec.RuntimeClassName) &&
+		equality.Semantic.DeepEqual(pod.Spec.OS, podSpec.OS) &&
+		equality.Semantic.DeepEqual(podSecurityContext(&pod.Spec), podSecurityContext(podSpec))
+}
+
+// adoptUnlabeledPods labels pods without a template hash, which were created before pods were
+// labeled with one, with the current hash when they already run the current template, so
+// upgrading the operator does not replace every pod
+func (r *VirtSquadReconciler) adoptUnlabeledPods(ctx context.Context, pods []corev1.Pod, podSpec *corev1.PodSpec, templateHash string) error {
+	for i := range pods {
+		pod := &pods[i]
+		if _, labeled := pod.Labels[templateHashLabel]; labeled || !podMatchesTemplate(pod, podSpec) {
+			continue
+		}
+		logf.FromContext(ctx).Info("Adopting pod running the current template", "pod", pod.Name, "revision", templateHash)
+		if err := r.patchPodLabel(ctx, pod, templateHashLabel, templateHash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortPodsForDeletion orders pods so that outdated pods come first, then pods that are not ready
+func sortPodsForDeletion(pods []corev1.Pod, templateHash string) {
+	rank := func(pod *corev1.Pod) int {
+		rank := 0
+		if isPodUpdated(pod, templateHash) {
+			rank += 2
+		}
+		if isPodReady(pod) {
+			rank++
+		}
+		return rank
+	}
+	sort.SliceStable(pods, func(i, j int) bool {
+		return rank(&pods[i]) < rank(&pods[j])
+	})
+}
+
+// canarySteps returns the canary step percentages, ending with a full rollout
+func canarySteps(canary *appsv1.CanarySpec) []int32 {
+	var steps []int32
+	if canary != nil {
+		steps = append(steps, canary.Steps...)
+	}
+	if len(steps) == 0 || steps[len(steps)-1] < 100 {
+		steps = append(steps, 100)
+	}
+	return steps
+}
+
+// setProgressing updates the member's Progressing condition and reports whether it changed
+func setProgressing(virtSquad *appsv1.VirtSquad, member *appsv1.MemberStatus, status metav1.ConditionStatus, reason, message string) bool {
+	return meta.SetStatusCondition(&member.Conditions, metav1.Condition{
+		Type:               appsv1.ConditionProgressing,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: virtSquad.Generation,
+	})
+}
+
+// reconcileRollout deletes outdated pods of a team member according to its rollout strategy so
+// that they are recreated from the current spec. It returns the pods that remain and, while a
+// canary step or the progress deadline is pending, how long to wait before checking again.
+func (r *VirtSquadReconciler) reconcileRollout(ctx context.Context, virtSquad *appsv1.VirtSquad, member *appsv1.MemberStatus, memberSpec *appsv1.TeamMemberSpec, pods []corev1.Pod, desiredReplicas int32, templateHash string) ([]corev1.Pod, time.Duration, error) {
+	log := logf.FromContext(ctx)
+
+	// A new template starts a new rollout
+	if member.UpdateRevision != templateHash {
+		member.UpdateRevision = templateHash
+		member.CanaryStep = 0
+		member.RolloutStepStartTime = nil
+		member.RolloutStartTime = nil
+		if member.CurrentRevision != "" && member.CurrentRevision != templateHash {
+			now := metav1.Now()
+			member.RolloutStartTime = &now
+		}
+		meta.RemoveStatusCondition(&member.Conditions, appsv1.ConditionProgressing)
+	}
+
+	partition := -1
+	if memberSpec.Rollout != nil && memberSpec.Rollout.Partition != nil {
+		partition = int(*memberSpec.Rollout.Partition)
+	}
+
+	// Only outdated pods at or above the partition are eligible for replacement. Pods still
+	// named after a previous name of the member are outdated too, so a rename replaces them.
+	var updated, outdated []corev1.Pod
+	held := 0
+	allReady := true
+	for _, pod := range pods {
+		switch {
+		case isPodUpdated(&pod, templateHash) && isPodNamedAfter(&pod, *memberSpec.Name):
+			updated = append(updated, pod)
+		case podOrdinal(&pod) < partition:
+			held++
+		default:
+			outdated = append(outdated, pod)
+		}
+		if !isPodReady(&pod) {
+			allReady = false
+		}
+	}
+	member.UpdatedReplicas = int32(len(updated))
+
+	// The rollout is stalled while replaced pods are missing or not ready yet
+	stalled := int32(len(pods)) < desiredReplicas
+	for i := range updated {
+		if !isPodReady(&updated[i]) {
+			stalled = true
+		}
+	}
+	stalled = stalled && member.RolloutStepStartTime != nil
+
+	if len(outdated) == 0 && !stalled {
+		member.RolloutStepStartTime = nil
+		if held > 0 {
+			setProgressing(virtSquad, member, metav1.ConditionFalse, reasonPartitioned,
+				fmt.Sprintf("%d pods below partition %d keep the previous spec", held, partition))
+			return pods, 0, nil
+		}
+		if member.CurrentRevision != templateHash && member.CurrentRevision != "" {
+			r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonRolloutComplete,
+				"Team member %s rolled out revision %s", member.Name, templateHash)
+			r.notify(ctx, virtSquad, notifications.EventRolloutComplete,
+				fmt.Sprintf("Team member %s rolled out revision %s", member.Name, templateHash))
+			if member.RolloutStartTime != nil {
+				observeRolloutDuration(virtSquad, member.Name, member.RolloutStartTime.Time)
+			}
+		}
+		member.RolloutStartTime = nil
+		member.CurrentRevision = templateHash
+		setProgressing(virtSquad, member, metav1.ConditionFalse, reasonRolloutComplete, "All pods run the current spec")
+		return pods, 0, nil
+	}
+
+	previousReason := ""
+	if condition := meta.FindStatusCondition(member.Conditions, appsv1.ConditionProgressing); condition != nil {
+		previousReason = condition.Reason
+	}
+	if previousReason == reasonCanaryAborted || previousReason == reasonProgressDeadlineExceeded {
+		// Stay stopped until the spec changes again
+		return pods, 0, nil
+	}
+
+	if ptr.Deref(memberSpec.RolloutPaused, false) {
+		setProgressing(virtSquad, member, metav1.ConditionUnknown, reasonRolloutPaused,
+			fmt.Sprintf("Rollout paused with %d of %d pods running the current spec", len(updated), desiredReplicas))
+		if previousReason != reasonRolloutPaused {
+			r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonRolloutPaused,
+				"Team member %s rollout paused", member.Name)
+		}
+		return pods, 0, nil
+	}
+	if previousReason == reasonRolloutPaused {
+		// Time spent paused does not count against the progress deadline or canary interval
+		if member.RolloutStepStartTime != nil {
+			now := metav1.Now()
+			member.RolloutStepStartTime = &now
+		}
+		setProgressing(virtSquad, member, metav1.ConditionTrue, reasonRollingOut,
+			fmt.Sprintf("%d of %d pods run the current spec", len(updated), desiredReplicas))
+		r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonRollingOut,
+			"Team member %s rollout resumed", member.Name)
+	}
+
+	var stallWait time.Duration
+	if stalled {
+		deadline := 600 * time.Second
+		if memberSpec.Rollout != nil && memberSpec.Rollout.ProgressDeadlineSeconds != nil {
+			deadline = time.Duration(*memberSpec.Rollout.ProgressDeadlineSeconds) * time.Second
+		}
+		stallWait = time.Until(member.RolloutStepStartTime.Add(deadline))
+		if stallWait <= 0 {
+			message := fmt.Sprintf("Replaced pods did not become ready within %s; %d of %d pods run the current spec",
+				deadline, len(updated), desiredReplicas)
+			if setProgressing(virtSquad, member, metav1.ConditionFalse, reasonProgressDeadlineExceeded, message) {
+				r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonProgressDeadlineExceeded,
+					"Team member %s: %s", member.Name, message)
+			}
+			return pods, 0, nil
+		}
+	}
+
+	// Replacements of previously deleted pods are still being created or started
+	if len(outdated) == 0 || int32(len(pods)) < desiredReplicas {
+		return pods, stallWait, nil
+	}
+
+	// Number of pods that should run the new spec at this point of the rollout
+	target := int32(1)
+	strategy := appsv1.RollingUpdateStrategy
+	var canary *appsv1.CanarySpec
+	if memberSpec.Rollout != nil && memberSpec.Rollout.Strategy != "" {
+		strategy = memberSpec.Rollout.Strategy
+		canary = memberSpec.Rollout.Canary
+	}
+
+	switch strategy {
+	case appsv1.CanaryStrategy:
+		steps := canarySteps(canary)
+		step := min(int(member.CanaryStep), len(steps)-1)
+		target = min(max((steps[step]*desiredReplicas+99)/100, 1), desiredReplicas)
+
+		if int32(len(updated)) >= target {
+			interval := 60 * time.Second
+			if canary != nil && canary.StepIntervalSeconds != nil {
+				interval = time.Duration(*canary.StepIntervalSeconds) * time.Second
+			}
+			if member.RolloutStepStartTime != nil {
+				if wait := time.Until(member.RolloutStepStartTime.Add(interval)); wait > 0 {
+					return pods, minRequeue(wait, stallWait), nil
+				}
+			}
+
+			for i := range updated {
+				if !isPodReady(&updated[i]) {
+					message := fmt.Sprintf("Canary pod %s did not become ready within %s at step %d (%d%%)",
+						updated[i].Name, interval, step, steps[step])
+					if setProgressing(virtSquad, member, metav1.ConditionFalse, reasonCanaryAborted, message) {
+						r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonCanaryAborted,
+							"Team member %s: %s", member.Name, message)
+					}
+					return pods, 0, nil
+				}
+			}
+
+			r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonCanaryStepComplete,
+				"Team member %s completed canary step %d (%d%%)", member.Name, step, steps[step])
+			member.CanaryStep++
+			step = min(int(member.CanaryStep), len(steps)-1)
+			target = min(max((steps[step]*desiredReplicas+99)/100, 1), desiredReplicas)
+		}
+	default:
+		// Replace one pod at a time, once every pod is ready again
+		if !allReady {
+			return pods, stallWait, nil
+		}
+		target = int32(len(updated)) + 1
+	}
+
+	toReplace := min(int(target)-len(updated), len(outdated))
+	if toReplace <= 0 {
+		return pods, 0, nil
+	}
+
+	sortPodsForDeletion(outdated, templateHash)
+	replaced := make(map[string]bool, toReplace)
+	for i := 0; i < toReplace; i++ {
+		log.Info("Replacing outdated pod", "pod", outdated[i].Name, "member", member.Name, "revision", templateHash)
+		if err := r.deletePod(ctx, virtSquad, &outdated[i], deleteReasonRollout); err != nil {
+			log.Error(err, "Failed to delete outdated pod", "pod", outdated[i].Name)
+			return pods, 0, err
+		}
+		replaced[outdated[i].Name] = true
+	}
+
+	now := metav1.Now()
+	member.RolloutStepStartTime = &now
+	setProgressing(virtSquad, member, metav1.ConditionTrue, reasonRollingOut,
+		fmt.Sprintf("%d of %d pods run the current spec", len(updated), desiredReplicas))
+
+	remaining := make([]corev1.Pod, 0, len(pods)-toReplace)
+	for _, pod := range pods {
+		if !replaced[pod.Name] {
+			remaining = append(remaining, pod)
+		}
+	}
+	return remaining, 0, nil
+}
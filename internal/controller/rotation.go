@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+// reasonOnCallRotated is the event reason used when the next member takes over the rotation
+const reasonOnCallRotated = "OnCallRotated"
+
+// reconcileRotation hands the rotation over to the next member when its schedule fired since
+// the last handover. The first member in the rotation starts on call. A rotation whose schedule
+// cannot be parsed keeps its current member; the InvalidSchedule condition reports the error.
+func (r *VirtSquadReconciler) reconcileRotation(ctx context.Context, virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus, now time.Time) {
+	rotation := virtSquad.Spec.Rotation
+	if rotation == nil || len(rotation.Members) == 0 {
+		status.OnCallMember = ""
+		status.LastRotationTime = nil
+		return
+	}
+
+	current := slices.Index(rotation.Members, status.OnCallMember)
+	if current < 0 || status.LastRotationTime == nil {
+		status.OnCallMember = rotation.Members[0]
+		status.LastRotationTime = &metav1.Time{Time: now}
+		return
+	}
+
+	schedule, err := cron.ParseStandard(rotation.Schedule)
+	if err != nil {
+		return
+	}
+	// Missed handovers, e.g. while the operator was down, advance the rotation only once
+	fired := lastFiring(schedule, now)
+	if fired.IsZero() || !fired.After(status.LastRotationTime.Time) {
+		return
+	}
+
+	previous := status.OnCallMember
+	status.OnCallMember = rotation.Members[(current+1)%len(rotation.Members)]
+	status.LastRotationTime = &metav1.Time{Time: fired}
+	r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonOnCallRotated,
+		"Team member %s took over on call from %s", status.OnCallMember, previous)
+}
+
+// offCall reports whether a team member takes part in the squad's rotation without being on call
+func offCall(virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus, memberName string) bool {
+	rotation := virtSquad.Spec.Rotation
+	return rotation != nil && status.OnCallMember != "" && memberName != status.OnCallMember &&
+		slices.Contains(rotation.Members, memberName)
+}
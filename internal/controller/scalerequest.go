@@ -0,0 +1,125 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// maxScaleRequestHistory bounds the number of scale requests listed in status
+	maxScaleRequestHistory = 10
+	// reasonScaleRequestApplied is the event reason used when a scale request changed a member's replicas
+	reasonScaleRequestApplied = "ScaleRequestApplied"
+	// reasonScaleRequestRejected is the event reason used when a scale request cannot be honoured
+	reasonScaleRequestRejected = "ScaleRequestRejected"
+)
+
+// checkScaleRequest returns why a scale request cannot be applied to the squad, or "" if it can
+func (r *VirtSquadReconciler) checkScaleRequest(virtSquad *appsv1.VirtSquad, request *appsv1.ScaleRequest) string {
+	for _, label := range []string{appsv1.SquadFleetLabel, appsv1.ClusterVirtSquadLabel, appsv1.ReplicaOfLabel} {
+		if _, ok := virtSquad.Labels[label]; ok {
+			return fmt.Sprintf("the squad is managed through its %s label and would be reverted", label)
+		}
+	}
+	spec := memberSpec(virtSquad, request.Member)
+	switch {
+	case spec == nil || spec.Name == nil:
+		return fmt.Sprintf("team member %q is not configured in the squad's spec", request.Member)
+	case request.Replicas < 0:
+		return "replicas must not be negative"
+	case r.MaxReplicasPerMember > 0 && request.Replicas > r.MaxReplicasPerMember:
+		return fmt.Sprintf("replicas must not exceed the operator's limit of %d replicas per team member", r.MaxReplicasPerMember)
+	}
+	return ""
+}
+
+// reconcileScaleRequest handles the scale-request annotation by changing the requested member's
+// replicas in the spec and recording the request in status. The annotation is removed once the
+// request has been handled, whether or not it was applied, so that a bad request is not retried.
+func (r *VirtSquadReconciler) reconcileScaleRequest(ctx context.Context, virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus) error {
+	log := logf.FromContext(ctx)
+
+	value, ok := virtSquad.Annotations[appsv1.ScaleRequestAnnotation]
+	if !ok {
+		return nil
+	}
+	delete(virtSquad.Annotations, appsv1.ScaleRequestAnnotation)
+
+	record := appsv1.ScaleRequestStatus{Time: metav1.Now(), Result: appsv1.ScaleRequestRejected}
+	if err := json.Unmarshal([]byte(value), &record.ScaleRequest); err != nil {
+		record.Message = fmt.Sprintf("the %s annotation is not a valid scale request: %v", appsv1.ScaleRequestAnnotation, err)
+	} else {
+		record.Message = r.checkScaleRequest(virtSquad, &record.ScaleRequest)
+	}
+
+	if record.Message == "" {
+		spec := memberSpec(virtSquad, record.Member)
+		record.PreviousReplicas = ptr.To(ptr.Deref(spec.Replicas, 1))
+		previous := spec.Replicas
+		spec.Replicas = ptr.To(record.Replicas)
+		err := r.Update(ctx, virtSquad)
+		switch {
+		case err == nil:
+			record.Result = appsv1.ScaleRequestApplied
+		case errors.IsInvalid(err) || errors.IsForbidden(err):
+			// Rejected by admission, such as for exceeding the squad's quota
+			spec.Replicas = previous
+			record.Message = err.Error()
+		default:
+			log.Error(err, "Failed to apply scale request", "member", record.Member)
+			return err
+		}
+	}
+
+	if record.Result == appsv1.ScaleRequestRejected {
+		if err := r.Update(ctx, virtSquad); err != nil {
+			return err
+		}
+		r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonScaleRequestRejected,
+			"Rejected scale request for team member %s from %s: %s", record.Member, requester(&record.ScaleRequest), record.Message)
+	} else {
+		log.Info("Applied scale request", "member", record.Member, "replicas", record.Replicas, "requester", record.Requester)
+		r.eventf(ctx, virtSquad, corev1.EventTypeNormal, reasonScaleRequestApplied,
+			"Scaled team member %s from %d to %d replicas at the request of %s", record.Member,
+			*record.PreviousReplicas, record.Replicas, requester(&record.ScaleRequest))
+	}
+
+	status.ScaleRequests = append(status.ScaleRequests, record)
+	if len(status.ScaleRequests) > maxScaleRequestHistory {
+		status.ScaleRequests = status.ScaleRequests[len(status.ScaleRequests)-maxScaleRequestHistory:]
+	}
+	return nil
+}
+
+// requester names the requester of a scale request in events
+func requester(request *appsv1.ScaleRequest) string {
+	if request.Requester == "" {
+		return "an unnamed requester"
+	}
+	return request.Requester
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// reasonInvalidSchedule is reported when a schedule's cron expression cannot be parsed
+	reasonInvalidSchedule = "InvalidSchedule"
+
+	// scheduleLookback bounds how far back a schedule's last firing is searched for
+	scheduleLookback = 366 * 24 * time.Hour
+)
+
+// lastFiring returns the most recent time at or before now that the schedule fired, or the zero
+// time when it did not fire within scheduleLookback. The search window grows from an hour so
+// frequent schedules are not stepped through for a whole year.
+func lastFiring(schedule cron.Schedule, now time.Time) time.Time {
+	for window := time.Hour; ; window *= 2 {
+		window = min(window, scheduleLookback)
+		var last time.Time
+		for t := schedule.Next(now.Add(-window)); !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+			last = t
+		}
+		if !last.IsZero() || window == scheduleLookback {
+			return last
+		}
+	}
+}
+
+// scheduledReplicas returns the replicas set for a team member by the schedule that fired last,
+// and false when none of the member's schedules fired. Invalid schedules are ignored.
+func scheduledReplicas(virtSquad *appsv1.VirtSquad, memberName string, now time.Time) (int32, bool) {
+	var replicas int32
+	var firedAt time.Time
+	for _, spec := range virtSquad.Spec.Schedules {
+		if spec.Member != memberName {
+			continue
+		}
+		schedule, err := cron.ParseStandard(spec.Cron)
+		if err != nil {
+			continue
+		}
+		// Later entries win over earlier ones firing at the same time
+		if fired := lastFiring(schedule, now); !fired.IsZero() && !fired.Before(firedAt) {
+			replicas, firedAt = spec.Replicas, fired
+		}
+	}
+	return replicas, !firedAt.IsZero()
+}
+
+// nextScheduledChange returns when the next of the squad's schedules fires, its rotation hands
+// over or one of its hibernation windows opens or closes, or nil when it has none. Cron
+// expressions that cannot be parsed are skipped and described in the returned problems.
+func nextScheduledChange(virtSquad *appsv1.VirtSquad, now time.Time) (*time.Time, []string) {
+	var next *time.Time
+	var problems []string
+	consider := func(schedule cron.Schedule) {
+		if t := schedule.Next(now); !t.IsZero() && (next == nil || t.Before(*next)) {
+			next = &t
+		}
+	}
+
+	for _, spec := range virtSquad.Spec.Schedules {
+		schedule, err := cron.ParseStandard(spec.Cron)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("Ignoring schedule %q for team member %s: %v", spec.Cron, spec.Member, err))
+			continue
+		}
+		consider(schedule)
+	}
+
+	if rotation := virtSquad.Spec.Rotation; rotation != nil {
+		schedule, err := cron.ParseStandard(rotation.Schedule)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("Ignoring rotation schedule %q: %v", rotation.Schedule, err))
+		} else {
+			consider(schedule)
+		}
+	}
+
+	if hibernation := virtSquad.Spec.Hibernation; hibernation != nil {
+		for _, window := range hibernation.Windows {
+			start, end, err := parseHibernationWindow(hibernation.TimeZone, window)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("Ignoring hibernation window %q to %q: %v", window.Start, window.End, err))
+				continue
+			}
+			consider(start)
+			consider(end)
+		}
+	}
+	return next, problems
+}
+
+// updateInvalidSchedule sets the squad's InvalidSchedule condition from the schedules that could
+// not be parsed, and removes it once they all parse. It returns true when the condition has just
+// become true.
+func updateInvalidSchedule(virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus, problems []string) bool {
+	if len(problems) == 0 {
+		meta.RemoveStatusCondition(&status.Conditions, appsv1.ConditionInvalidSchedule)
+		return false
+	}
+	wasInvalid := meta.IsStatusConditionTrue(status.Conditions, appsv1.ConditionInvalidSchedule)
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               appsv1.ConditionInvalidSchedule,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonInvalidSchedule,
+		Message:            strings.Join(problems, "; "),
+		ObservedGeneration: virtSquad.Generation,
+	})
+	return !wasInvalid
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+var _ = Describe("Schedules", func() {
+	// A Wednesday, so weekday schedules have fired earlier the same week
+	now := time.Date(2025, time.March, 12, 10, 30, 0, 0, time.UTC)
+	parse := func(expr string) cron.Schedule {
+		schedule, err := cron.ParseStandard(expr)
+		Expect(err).NotTo(HaveOccurred())
+		return schedule
+	}
+
+	Describe("lastFiring", func() {
+		It("should find firings of frequent and rare schedules", func() {
+			Expect(lastFiring(parse("*/5 * * * *"), now)).To(Equal(now))
+			Expect(lastFiring(parse("0 9 * * *"), now)).To(Equal(time.Date(2025, time.March, 12, 9, 0, 0, 0, time.UTC)))
+			Expect(lastFiring(parse("0 18 * * 5"), now)).To(Equal(time.Date(2025, time.March, 7, 18, 0, 0, 0, time.UTC)))
+			Expect(lastFiring(parse("0 0 1 1 *"), now)).To(Equal(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("should return the zero time for schedules that did not fire within the lookback", func() {
+			// February 30th never comes
+			Expect(lastFiring(parse("0 0 30 2 *"), now)).To(BeZero())
+		})
+	})
+
+	Describe("scheduledReplicas", func() {
+		virtSquad := &appsv1.VirtSquad{Spec: appsv1.VirtSquadSpec{Schedules: []appsv1.ScheduleSpec{
+			{Member: "oksana", Cron: "0 9 * * *", Replicas: 3},
+			{Member: "oksana", Cron: "0 18 * * *", Replicas: 1},
+			{Member: "dmitri", Cron: "0 9 * * *", Replicas: 2},
+			{Member: "dmitri", Cron: "0 9 * * *", Replicas: 4},
+			{Member: "yuri", Cron: "not a cron", Replicas: 5},
+		}}}
+
+		replicas := func(memberName string, now time.Time) int32 {
+			replicas, ok := scheduledReplicas(virtSquad, memberName, now)
+			Expect(ok).To(BeTrue())
+			return replicas
+		}
+
+		It("should use the schedule that fired last", func() {
+			Expect(replicas("oksana", now)).To(Equal(int32(3)))
+			Expect(replicas("oksana", now.Add(8*time.Hour))).To(Equal(int32(1)))
+		})
+
+		It("should let later entries win over earlier ones firing at the same time", func() {
+			Expect(replicas("dmitri", now)).To(Equal(int32(4)))
+		})
+
+		It("should report members without a valid schedule as unscheduled", func() {
+			_, ok := scheduledReplicas(virtSquad, "yuri", now)
+			Expect(ok).To(BeFalse())
+			_, ok = scheduledReplicas(virtSquad, "ivan", now)
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("nextScheduledChange", func() {
+		It("should return the earliest upcoming change", func() {
+			virtSquad := &appsv1.VirtSquad{Spec: appsv1.VirtSquadSpec{
+				Schedules: []appsv1.ScheduleSpec{{Member: "oksana", Cron: "0 18 * * *", Replicas: 1}},
+				Rotation:  &appsv1.RotationSpec{Schedule: "0 12 * * *", Members: []string{"oksana"}},
+				Hibernation: &appsv1.HibernationSpec{
+					TimeZone: "UTC",
+					Windows:  []appsv1.HibernationWindow{{Start: "0 22 * * *", End: "0 11 * * *"}},
+				},
+			}}
+			next, problems := nextScheduledChange(virtSquad, now)
+			Expect(problems).To(BeEmpty())
+			Expect(next).To(HaveValue(Equal(time.Date(2025, time.March, 12, 11, 0, 0, 0, time.UTC))))
+		})
+
+		It("should return nil for squads without schedules", func() {
+			next, problems := nextScheduledChange(&appsv1.VirtSquad{}, now)
+			Expect(next).To(BeNil())
+			Expect(problems).To(BeEmpty())
+		})
+
+		It("should skip and describe expressions that cannot be parsed", func() {
+			virtSquad := &appsv1.VirtSquad{Spec: appsv1.VirtSquadSpec{
+				Schedules: []appsv1.ScheduleSpec{
+					{Member: "oksana", Cron: "not a cron", Replicas: 1},
+					{Member: "oksana", Cron: "0 18 * * *", Replicas: 1},
+				},
+				Rotation: &appsv1.RotationSpec{Schedule: "nope", Members: []string{"oksana"}},
+			}}
+			next, problems := nextScheduledChange(virtSquad, now)
+			Expect(next).To(HaveValue(Equal(time.Date(2025, time.March, 12, 18, 0, 0, 0, time.UTC))))
+			Expect(problems).To(HaveLen(2))
+		})
+	})
+
+	Describe("updateInvalidSchedule", func() {
+		It("should only report the condition becoming true once, and remove it once schedules parse", func() {
+			virtSquad := &appsv1.VirtSquad{}
+			status := &appsv1.VirtSquadStatus{}
+
+			Expect(updateInvalidSchedule(virtSquad, status, nil)).To(BeFalse())
+			Expect(status.Conditions).To(BeEmpty())
+
+			Expect(updateInvalidSchedule(virtSquad, status, []string{"bad"})).To(BeTrue())
+			Expect(updateInvalidSchedule(virtSquad, status, []string{"bad"})).To(BeFalse())
+			Expect(meta.IsStatusConditionTrue(status.Conditions, appsv1.ConditionInvalidSchedule)).To(BeTrue())
+
+			Expect(updateInvalidSchedule(virtSquad, status, nil)).To(BeFalse())
+			Expect(status.Conditions).To(BeEmpty())
+		})
+	})
+})
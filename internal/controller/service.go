@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+// external-dns annotations set on member Services
+const (
+	externalDNSHostnameAnnotation = "external-dns.alpha.kubernetes.io/hostname"
+	externalDNSTTLAnnotation      = "external-dns.alpha.kubernetes.io/ttl"
+)
+
+// memberServiceName returns the name of the Service generated for a team member
+func memberServiceName(virtSquad *appsv1.VirtSquad, memberName string) string {
+	return fmt.Sprintf("%s-%s", virtSquad.Name, memberName)
+}
+
+// reconcileMemberService creates or updates the team member's Service, or deletes it when the
+// member no longer asks for one
+func (r *VirtSquadReconciler) reconcileMemberService(ctx context.Context, virtSquad *appsv1.VirtSquad, memberName string, memberSpec *appsv1.TeamMemberSpec) error {
+	log := logf.FromContext(ctx)
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      memberServiceName(virtSquad, memberName),
+			Namespace: virtSquad.Namespace,
+		},
+	}
+
+	if memberSpec == nil || memberSpec.Name == nil || memberSpec.Service == nil {
+		return r.deleteService(ctx, service)
+	}
+
+	serviceSpec := memberSpec.Service
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, service, func() error {
+		service.Labels = map[string]string{
+			memberLabel: memberName,
+			squadLabel:  virtSquad.Name,
+		}
+
+		if service.Annotations == nil {
+			service.Annotations = map[string]string{}
+		}
+		delete(service.Annotations, externalDNSHostnameAnnotation)
+		delete(service.Annotations, externalDNSTTLAnnotation)
+		if dns := serviceSpec.ExternalDNS; dns != nil {
+			service.Annotations[externalDNSHostnameAnnotation] = dns.Hostname
+			if dns.TTL != nil {
+				service.Annotations[externalDNSTTLAnnotation] = strconv.Itoa(int(*dns.TTL))
+			}
+		}
+
+		service.Spec.Type = serviceSpec.Type
+		if service.Spec.Type == "" {
+			service.Spec.Type = corev1.ServiceTypeClusterIP
+		}
+		service.Spec.Selector = memberLabels(virtSquad, memberName)
+		if usesTrafficRoles(memberSpec) {
+			service.Spec.Selector[trafficLabel] = trafficServing
+		}
+		service.Spec.Ports = servicePorts()
+
+		return controllerutil.SetControllerReference(virtSquad, service, r.Scheme)
+	})
+	if err != nil {
+		log.Error(err, "Failed to reconcile Service", "service", service.Name)
+		return err
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Info("Reconciled Service", "service", service.Name, "operation", result)
+	}
+	return nil
+}
+
+// servicePorts returns the ports exposed by the Services generated for team members
+func servicePorts() []corev1.ServicePort {
+	return []corev1.ServicePort{
+		{
+			Name:       "http",
+			Port:       80,
+			TargetPort: intstr.FromString("http"),
+			Protocol:   corev1.ProtocolTCP,
+		},
+	}
+}
+
+// deleteService deletes a generated Service that is no longer wanted
+func (r *VirtSquadReconciler) deleteService(ctx context.Context, service *corev1.Service) error {
+	// Look the Service up in the cache first to avoid a delete call on every reconcile
+	if err := r.Get(ctx, client.ObjectKeyFromObject(service), service); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if err := r.Delete(ctx, service); err != nil && !errors.IsNotFound(err) {
+		logf.FromContext(ctx).Error(err, "Failed to delete Service", "service", service.Name)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"hash/fnv"
+	"strconv"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+// squadShard returns the shard a squad is assigned to by hashing its namespace and name
+func squadShard(namespace, name string, shardCount int) int {
+	hasher := fnv.New32a()
+	_, _ = hasher.Write([]byte(namespace + "/" + name))
+	return int(hasher.Sum32() % uint32(shardCount))
+}
+
+// ownsSquad reports whether this operator replica's shard is responsible for the squad.
+// The shard label pins a squad to a shard; otherwise the shard is derived from its name.
+func (r *VirtSquadReconciler) ownsSquad(virtSquad *appsv1.VirtSquad) bool {
+	if r.ShardCount <= 1 {
+		return true
+	}
+	if value, ok := virtSquad.Labels[appsv1.ShardLabel]; ok {
+		if shard, err := strconv.Atoi(value); err == nil && shard >= 0 {
+			return shard%r.ShardCount == r.ShardID
+		}
+	}
+	return squadShard(virtSquad.Namespace, virtSquad.Name, r.ShardCount) == r.ShardID
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+	"github.com/mshort55/virtsquad-operator/internal/smoketest"
+)
+
+const (
+	// deleteReasonSmokeTestFailed is passed to the preDelete hook for pods that failed their smoke test
+	deleteReasonSmokeTestFailed = "SmokeTestFailed"
+	// reasonSmokeTestFailed is the event reason used when a pod failed its member's smoke test
+	reasonSmokeTestFailed = "SmokeTestFailed"
+
+	// maxSmokeTestAge is how long the outcome of a smoke test is kept for a pod that went away
+	maxSmokeTestAge = 10 * time.Minute
+)
+
+// smokeTested reports whether a pod passed its member's smoke test or its member has none
+func smokeTested(virtSquad *appsv1.VirtSquad, pod *corev1.Pod) bool {
+	spec := memberSpec(virtSquad, pod.Labels[memberLabel])
+	return spec == nil || spec.SmokeTest == nil || pod.Annotations[appsv1.SmokeTestAnnotation] == appsv1.SmokeTestPassed
+}
+
+// smokeTestRun is a smoke test running in the background against one pod
+type smokeTestRun struct {
+	// done is closed once the test finished, after err is set
+	done    chan struct{}
+	err     error
+	started time.Time
+}
+
+// smokeTestRuns tracks the smoke tests running in the background by pod UID, so a slow or
+// hanging test never holds up a reconcile
+type smokeTestRuns struct {
+	mu   sync.Mutex
+	runs map[types.UID]*smokeTestRun
+}
+
+// start runs the test against the pod in the background unless it is already running. It
+// returns the run, and whether it finished.
+func (s *smokeTestRuns) start(uid types.UID, test func() error) (*smokeTestRun, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.runs == nil {
+		s.runs = map[types.UID]*smokeTestRun{}
+	}
+	if run, ok := s.runs[uid]; ok {
+		select {
+		case <-run.done:
+			return run, true
+		default:
+			return run, false
+		}
+	}
+	// Forget the runs of pods that went away before their outcome was collected
+	for other, run := range s.runs {
+		if time.Since(run.started) > maxSmokeTestAge {
+			delete(s.runs, other)
+		}
+	}
+	run := &smokeTestRun{done: make(chan struct{}), started: time.Now()}
+	s.runs[uid] = run
+	go func() {
+		defer close(run.done)
+		run.err = test()
+	}()
+	return run, false
+}
+
+// forget drops the outcome of a pod's test once it was acted on
+func (s *smokeTestRuns) forget(uid types.UID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.runs, uid)
+}
+
+// runSmokeTests runs the member's smoke test in the background against its pods that became
+// ready but were not tested yet, and acts on the tests that finished. Pods that pass are
+// annotated so they are not tested again; pods that fail are deleted so they get recreated.
+// It returns the pods that were not deleted and, while tests are running, when to check on them.
+func (r *VirtSquadReconciler) runSmokeTests(ctx context.Context, virtSquad *appsv1.VirtSquad, memberName string, memberSpec *appsv1.TeamMemberSpec, pods []corev1.Pod) ([]corev1.Pod, time.Duration, error) {
+	log := logf.FromContext(ctx)
+
+	test := memberSpec.SmokeTest
+	if test == nil {
+		return pods, 0, nil
+	}
+	runner := r.SmokeTests
+	if runner == nil {
+		runner = smoketest.NewRunner(nil)
+	}
+	timeout := 5 * time.Second
+	if test.TimeoutSeconds != nil {
+		timeout = time.Duration(*test.TimeoutSeconds) * time.Second
+	}
+
+	var requeueAfter time.Duration
+	remaining := make([]corev1.Pod, 0, len(pods))
+	for i := range pods {
+		pod := &pods[i]
+		if !isPodReady(pod) || smokeTested(virtSquad, pod) {
+			remaining = append(remaining, *pod)
+			continue
+		}
+
+		// The test outlives the reconcile, so it gets its own copy of the pod and context
+		tested := pod.DeepCopy()
+		run, done := r.smokeTestRuns.start(pod.UID, func() error {
+			testCtx := context.WithoutCancel(ctx)
+			switch {
+			case test.HTTPGet != nil:
+				return runner.HTTPGet(testCtx, tested, memberName, test.HTTPGet, timeout)
+			case test.Exec != nil:
+				return runner.Exec(testCtx, tested, memberName, test.Exec, timeout)
+			}
+			return nil
+		})
+		if !done {
+			requeueAfter = minRequeue(requeueAfter, max(time.Until(run.started.Add(timeout)), 0)+time.Second)
+			remaining = append(remaining, *pod)
+			continue
+		}
+		r.smokeTestRuns.forget(pod.UID)
+
+		if err := run.err; err != nil {
+			log.Info("Pod failed its smoke test", "pod", pod.Name, "member", memberName, "error", err.Error())
+			r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonSmokeTestFailed,
+				"Pod %s of team member %s failed its smoke test and is recreated: %v", pod.Name, memberName, err)
+			if err := r.deletePod(ctx, virtSquad, pod, deleteReasonSmokeTestFailed); err != nil {
+				log.Error(err, "Failed to delete pod that failed its smoke test", "pod", pod.Name)
+				return pods, 0, err
+			}
+			continue
+		}
+
+		patch := client.MergeFrom(pod.DeepCopy())
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[appsv1.SmokeTestAnnotation] = appsv1.SmokeTestPassed
+		if err := r.Patch(ctx, pod, patch); err != nil {
+			log.Error(err, "Failed to record passed smoke test", "pod", pod.Name)
+			return pods, 0, err
+		}
+		log.Info("Pod passed its smoke test", "pod", pod.Name, "member", memberName)
+		remaining = append(remaining, *pod)
+	}
+	return remaining, requeueAfter, nil
+}
@@ -0,0 +1,276 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+// reasonInvalidFleetSquad is the event reason used when the API server rejects the merged spec
+// of an inline squad
+const reasonInvalidFleetSquad = "InvalidSquad"
+
+// SquadFleetReconciler creates the inline squads of SquadFleets and aggregates the status of
+// all their squads
+type SquadFleetReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// Paused halts all creates, updates and deletes of squads while status keeps being refreshed
+	Paused bool
+
+	// ResyncJitter is the window the periodic resync of SquadFleets is spread over; zero resyncs
+	// them all at once
+	ResyncJitter time.Duration
+}
+
+// +kubebuilder:rbac:groups=apps.mshort55.io,resources=squadfleets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.mshort55.io,resources=squadfleets/status,verbs=get;update;patch
+
+// Reconcile creates, updates and deletes the VirtSquads of a SquadFleet's inline squads and
+// aggregates the status of every squad in the fleet
+func (r *SquadFleetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	fleet := &appsv1.SquadFleet{}
+	if err := r.Get(ctx, req.NamespacedName, fleet); err != nil {
+		// The inline squads are garbage collected through their owner reference
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if fleet.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	inline := map[string]bool{}
+	for _, squad := range fleet.Spec.Squads {
+		if squad.Spec != nil {
+			inline[fleetSquadName(fleet, squad.Name)] = true
+		}
+	}
+
+	existing := &appsv1.VirtSquadList{}
+	if err := r.List(ctx, existing, client.InNamespace(fleet.Namespace), client.MatchingLabels{appsv1.SquadFleetLabel: fleet.Name}); err != nil {
+		log.Error(err, "Failed to list fleet squads")
+		return ctrl.Result{}, err
+	}
+	for i := range existing.Items {
+		virtSquad := &existing.Items[i]
+		if !metav1.IsControlledBy(virtSquad, fleet) || inline[virtSquad.Name] || r.Paused {
+			continue
+		}
+		log.Info("Deleting squad removed from the fleet", "virtsquad", virtSquad.Name)
+		if err := r.Delete(ctx, virtSquad); err != nil && !errors.IsNotFound(err) {
+			log.Error(err, "Failed to delete fleet squad", "virtsquad", virtSquad.Name)
+			return ctrl.Result{}, err
+		}
+	}
+
+	squads := map[string]*appsv1.VirtSquad{}
+	for _, squad := range fleet.Spec.Squads {
+		var virtSquad *appsv1.VirtSquad
+		var err error
+		switch {
+		case squad.Spec == nil:
+			virtSquad, err = r.referencedSquad(ctx, fleet, squad.Name)
+		case r.Paused:
+			virtSquad, err = r.referencedSquad(ctx, fleet, fleetSquadName(fleet, squad.Name))
+		default:
+			virtSquad, err = r.reconcileFleetSquad(ctx, fleet, squad)
+		}
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		squads[squad.Name] = virtSquad
+	}
+
+	return ctrl.Result{}, r.updateFleetStatus(ctx, fleet, squads)
+}
+
+// fleetSquadName returns the name of the VirtSquad created for an inline squad of a fleet
+func fleetSquadName(fleet *appsv1.SquadFleet, name string) string {
+	return fmt.Sprintf("%s-%s", fleet.Name, name)
+}
+
+// referencedSquad returns the VirtSquad of the given name in the fleet's namespace, or nil
+// when it does not exist
+func (r *SquadFleetReconciler) referencedSquad(ctx context.Context, fleet *appsv1.SquadFleet, name string) (*appsv1.VirtSquad, error) {
+	virtSquad := &appsv1.VirtSquad{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: fleet.Namespace, Name: name}, virtSquad); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		logf.FromContext(ctx).Error(err, "Failed to get fleet squad", "virtsquad", name)
+		return nil, err
+	}
+	return virtSquad, nil
+}
+
+// reconcileFleetSquad creates or updates the VirtSquad of an inline squad of a fleet. It returns
+// nil without an error when a VirtSquad of the same name exists that the fleet does not own,
+// which is left alone, or when the API server rejects the merged spec.
+func (r *SquadFleetReconciler) reconcileFleetSquad(ctx context.Context, fleet *appsv1.SquadFleet, squad appsv1.FleetSquad) (*appsv1.VirtSquad, error) {
+	log := logf.FromContext(ctx)
+
+	spec, err := mergeSquadSpec(fleet.Spec.Defaults, squad.Spec)
+	if err != nil {
+		log.Error(err, "Failed to merge the fleet defaults", "squad", squad.Name)
+		return nil, err
+	}
+
+	virtSquad := &appsv1.VirtSquad{
+		ObjectMeta: metav1.ObjectMeta{Name: fleetSquadName(fleet, squad.Name), Namespace: fleet.Namespace},
+	}
+	err = r.Get(ctx, client.ObjectKeyFromObject(virtSquad), virtSquad)
+	switch {
+	case errors.IsNotFound(err):
+	case err != nil:
+		log.Error(err, "Failed to get fleet squad", "virtsquad", virtSquad.Name)
+		return nil, err
+	case !metav1.IsControlledBy(virtSquad, fleet):
+		r.Recorder.AnnotatedEventf(fleet, reconcileAnnotations(ctx), corev1.EventTypeWarning, reasonSquadConflict,
+			"A VirtSquad named %s already exists that is not managed by this SquadFleet", virtSquad.Name)
+		return nil, nil
+	}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, virtSquad, func() error {
+		if virtSquad.Labels == nil {
+			virtSquad.Labels = map[string]string{}
+		}
+		virtSquad.Labels[appsv1.SquadFleetLabel] = fleet.Name
+		virtSquad.Spec = *spec
+		return controllerutil.SetControllerReference(fleet, virtSquad, r.Scheme)
+	})
+	if errors.IsInvalid(err) {
+		// The spec only changes with the fleet, so retrying cannot help
+		r.Recorder.AnnotatedEventf(fleet, reconcileAnnotations(ctx), corev1.EventTypeWarning, reasonInvalidFleetSquad,
+			"Squad %s is invalid with the fleet's defaults merged in: %v", squad.Name, err)
+		return nil, nil
+	}
+	if err != nil {
+		log.Error(err, "Failed to reconcile fleet squad", "virtsquad", virtSquad.Name)
+		return nil, err
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Info("Reconciled fleet squad", "virtsquad", virtSquad.Name, "operation", result)
+	}
+	return virtSquad, nil
+}
+
+// updateFleetStatus aggregates the status of the fleet's squads into the SquadFleet's status.
+// Squads that are missing or owned by someone else are reported as not ready.
+func (r *SquadFleetReconciler) updateFleetStatus(ctx context.Context, fleet *appsv1.SquadFleet, squads map[string]*appsv1.VirtSquad) error {
+	status := fleet.Status.DeepCopy()
+	status.Squads = make([]appsv1.FleetSquadStatus, 0, len(fleet.Spec.Squads))
+	status.ReadySquads = 0
+	status.ReadyPods = 0
+	status.DesiredPods = 0
+	var notReady []string
+	for _, squad := range fleet.Spec.Squads {
+		squadStatus := appsv1.FleetSquadStatus{Name: squad.Name}
+		if virtSquad := squads[squad.Name]; virtSquad != nil {
+			squadStatus.VirtSquad = virtSquad.Name
+			squadStatus.ReadyPods = virtSquad.Status.ReadyPods
+			squadStatus.DesiredPods = virtSquad.Status.DesiredPods
+			squadStatus.Ready = meta.IsStatusConditionTrue(virtSquad.Status.Conditions, appsv1.ConditionReady)
+		}
+		status.Squads = append(status.Squads, squadStatus)
+		status.ReadyPods += squadStatus.ReadyPods
+		status.DesiredPods += squadStatus.DesiredPods
+		if squadStatus.Ready {
+			status.ReadySquads++
+		} else {
+			notReady = append(notReady, squad.Name)
+		}
+	}
+	status.TotalSquads = int32(len(status.Squads))
+	status.ObservedGeneration = fleet.Generation
+
+	condition := metav1.Condition{
+		Type:               appsv1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonAllSquadsReady,
+		Message:            "Every squad of the fleet is ready",
+		ObservedGeneration: fleet.Generation,
+	}
+	if len(notReady) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonSquadsNotReady
+		condition.Message = "Squads not ready: " + strings.Join(notReady, ", ")
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+
+	if equality.Semantic.DeepEqual(&fleet.Status, status) {
+		return nil
+	}
+	fleet.Status = *status
+	if err := r.Status().Update(ctx, fleet); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to update SquadFleet status")
+		return err
+	}
+	return nil
+}
+
+// fleetsReferencingSquad returns a reconcile request for every SquadFleet in the squad's
+// namespace that references it by name, since referenced squads carry no owner reference
+func (r *SquadFleetReconciler) fleetsReferencingSquad(ctx context.Context, obj client.Object) []reconcile.Request {
+	fleets := &appsv1.SquadFleetList{}
+	if err := r.List(ctx, fleets, client.InNamespace(obj.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list SquadFleets", "namespace", obj.GetNamespace())
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range fleets.Items {
+		for _, squad := range fleets.Items[i].Spec.Squads {
+			if squad.Spec == nil && squad.Name == obj.GetName() {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&fleets.Items[i])})
+				break
+			}
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SquadFleetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	blder := ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1.SquadFleet{})
+	return withJitteredResync(blder, mgr, &appsv1.SquadFleet{}, r.ResyncJitter).
+		Owns(&appsv1.VirtSquad{}).
+		Watches(&appsv1.VirtSquad{}, handler.EnqueueRequestsFromMapFunc(r.fleetsReferencingSquad)).
+		Named("squadfleet").
+		Complete(r)
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+	"github.com/mshort55/virtsquad-operator/test/crd"
+)
+
+var _ = Describe("SquadFleet Controller", func() {
+	const resourceName = "test-fleet"
+
+	ctx := context.Background()
+
+	newFleet := func(squads ...appsv1.FleetSquad) *appsv1.SquadFleet {
+		return &appsv1.SquadFleet{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec: appsv1.SquadFleetSpec{
+				Defaults: &appsv1.VirtSquadSpec{
+					Oksana:         &appsv1.TeamMemberSpec{Name: ptr.To("oksana-pod"), Replicas: ptr.To(int32(1)), InjectMetadata: ptr.To(true)},
+					ConfirmChanges: ptr.To(true),
+				},
+				Squads: squads,
+			},
+		}
+	}
+	reconcileFleet := func(objs ...client.Object) client.Client {
+		c, scheme := newFakeClient(objs...)
+		controllerReconciler := &SquadFleetReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: "default", Name: resourceName},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return c
+	}
+	fleetSquad := func(c client.Client, name string) *appsv1.VirtSquad {
+		virtSquad := &appsv1.VirtSquad{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: "default", Name: resourceName + "-" + name}, virtSquad)).To(Succeed())
+		return virtSquad
+	}
+
+	It("should create the inline squads merged over the defaults", func() {
+		fleet := newFleet(appsv1.FleetSquad{Name: "frontend", Spec: &appsv1.VirtSquadSpec{
+			Oksana: &appsv1.TeamMemberSpec{Replicas: ptr.To(int32(2))},
+		}})
+		c := reconcileFleet(fleet)
+
+		virtSquad := fleetSquad(c, "frontend")
+		Expect(virtSquad.Labels).To(HaveKeyWithValue(appsv1.SquadFleetLabel, resourceName))
+		Expect(metav1.IsControlledBy(virtSquad, fleet)).To(BeTrue())
+		Expect(virtSquad.Spec.Oksana.Name).To(HaveValue(Equal("oksana-pod")))
+		Expect(virtSquad.Spec.Oksana.Replicas).To(HaveValue(Equal(int32(2))))
+		Expect(virtSquad.Spec.Oksana.InjectMetadata).To(HaveValue(BeTrue()))
+		Expect(virtSquad.Spec.ConfirmChanges).To(HaveValue(BeTrue()))
+	})
+
+	It("should let a squad turn off a setting the defaults turn on", func() {
+		c := reconcileFleet(newFleet(appsv1.FleetSquad{Name: "backend", Spec: &appsv1.VirtSquadSpec{
+			Oksana:         &appsv1.TeamMemberSpec{InjectMetadata: ptr.To(false)},
+			ConfirmChanges: ptr.To(false),
+		}}))
+
+		virtSquad := fleetSquad(c, "backend")
+		Expect(virtSquad.Spec.Oksana.InjectMetadata).To(HaveValue(BeFalse()))
+		Expect(virtSquad.Spec.ConfirmChanges).To(HaveValue(BeFalse()))
+		Expect(virtSquad.Spec.Oksana.Name).To(HaveValue(Equal("oksana-pod")))
+	})
+
+	It("should leave everything but the replicas of inline squads to the defaults", func() {
+		fleet := newFleet(
+			appsv1.FleetSquad{Name: "frontend", Spec: &appsv1.VirtSquadSpec{
+				Oksana: &appsv1.TeamMemberSpec{Replicas: ptr.To(int32(3))},
+			}},
+			appsv1.FleetSquad{Name: "backend", Spec: &appsv1.VirtSquadSpec{Hibernated: ptr.To(true)}},
+		)
+		fleet.TypeMeta = metav1.TypeMeta{APIVersion: appsv1.GroupVersion.String(), Kind: "SquadFleet"}
+		fleet.Spec.Defaults.Oksana.Image = "nginx:1.27"
+		fleet.Spec.Defaults.Oksana.Replicas = ptr.To(int32(2))
+
+		// The fleet is stored as the API server would store it
+		fleets, err := crd.Load("squadfleets")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fleets.Default(fleet)).To(Succeed())
+		Expect(fleets.Validate(fleet)).To(BeEmpty())
+		Expect(fleet.Spec.Squads[0].Spec.Oksana.Image).To(BeEmpty())
+
+		c := reconcileFleet(fleet)
+		virtSquads, err := crd.Load("virtsquads")
+		Expect(err).NotTo(HaveOccurred())
+
+		frontend := fleetSquad(c, "frontend")
+		Expect(frontend.Spec.Oksana.Replicas).To(HaveValue(Equal(int32(3))))
+		Expect(frontend.Spec.Oksana.Image).To(Equal("nginx:1.27"))
+		Expect(frontend.Spec.Oksana.Name).To(HaveValue(Equal("oksana-pod")))
+		Expect(virtSquads.Validate(frontend)).To(BeEmpty())
+
+		backend := fleetSquad(c, "backend")
+		Expect(backend.Spec.Oksana.Replicas).To(HaveValue(Equal(int32(2))))
+		Expect(backend.Spec.Hibernated).To(HaveValue(BeTrue()))
+		Expect(virtSquads.Validate(backend)).To(BeEmpty())
+	})
+
+	It("should delete squads removed from the fleet and report referenced squads", func() {
+		fleet := newFleet(appsv1.FleetSquad{Name: "existing"})
+		_, scheme := newFakeClient()
+		removed := &appsv1.VirtSquad{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default", Name: resourceName + "-removed",
+			Labels: map[string]string{appsv1.SquadFleetLabel: resourceName},
+		}}
+		Expect(controllerutil.SetControllerReference(fleet, removed, scheme)).To(Succeed())
+		existing := &appsv1.VirtSquad{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "existing"},
+			Status:     appsv1.VirtSquadStatus{ReadyPods: 1, DesiredPods: 3},
+		}
+
+		c := reconcileFleet(fleet, removed, existing)
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(removed), &appsv1.VirtSquad{})).NotTo(Succeed())
+
+		stored := &appsv1.SquadFleet{}
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(fleet), stored)).To(Succeed())
+		Expect(stored.Status.Squads).To(ConsistOf(appsv1.FleetSquadStatus{
+			Name: "existing", VirtSquad: "existing", ReadyPods: 1, DesiredPods: 3,
+		}))
+		Expect(stored.Status.TotalSquads).To(Equal(int32(1)))
+		Expect(stored.Status.ReadySquads).To(BeZero())
+	})
+})
@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// trafficLabel tells serving pods, selected by the member's Service, from standby pods
+	trafficLabel = "virtsquad.mshort55.io/traffic"
+	// trafficServing marks pods that receive traffic through the member's Service
+	trafficServing = "serving"
+	// trafficStandby marks pods kept running but excluded from the member's Service
+	trafficStandby = "standby"
+)
+
+// usesTrafficRoles reports whether the member splits its pods into serving and standby pods
+func usesTrafficRoles(memberSpec *appsv1.TeamMemberSpec) bool {
+	return activePassive(memberSpec) ||
+		memberSpec != nil && memberSpec.StandbyReplicas != nil && *memberSpec.StandbyReplicas > 0
+}
+
+// standbyReplicas returns the number of standby pods to run for a team member, within what is
+// left of the squad's pod budget. Hibernated and off-call members keep no standby.
+func (r *VirtSquadReconciler) standbyReplicas(virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus, memberName string, memberSpec *appsv1.TeamMemberSpec, budget *podBudget) int32 {
+	if memberSpec == nil || memberSpec.StandbyReplicas == nil || memberSpec.Name == nil ||
+		hibernating(virtSquad, time.Now()) || offCall(virtSquad, status, memberName) {
+		return 0
+	}
+	return budget.take(*memberSpec.StandbyReplicas)
+}
+
+// assignTraffic labels the member's remaining pods as serving or standby, so that the
+// servingReplicas best pods are selected by the member's Service. Ready pods running the current
+// revision are preferred, and pods already serving keep serving among equals. It returns the
+// names of the pods that serve, best first.
+func (r *VirtSquadReconciler) assignTraffic(ctx context.Context, pods []corev1.Pod, servingReplicas int32, templateHash string) ([]string, error) {
+	log := logf.FromContext(ctx)
+
+	rank := func(pod *corev1.Pod) int {
+		rank := 0
+		if isPodReady(pod) {
+			rank += 4
+		}
+		if isPodUpdated(pod, templateHash) {
+			rank += 2
+		}
+		if pod.Labels[trafficLabel] == trafficServing {
+			rank++
+		}
+		return rank
+	}
+	sorted := append([]corev1.Pod{}, pods...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return rank(&sorted[i]) > rank(&sorted[j])
+	})
+
+	var serving []string
+	for i := range sorted {
+		pod := &sorted[i]
+		traffic := trafficStandby
+		if int32(len(serving)) < servingReplicas {
+			traffic = trafficServing
+			serving = append(serving, pod.Name)
+		}
+		if pod.Labels[trafficLabel] == traffic {
+			continue
+		}
+
+		log.Info("Changing pod traffic role", "pod", pod.Name, "traffic", traffic)
+		if err := r.patchPodLabel(ctx, pod, trafficLabel, traffic); err != nil {
+			return serving, err
+		}
+	}
+	return serving, nil
+}
+
+// patchPodLabel sets a label on a pod, or removes it when value is empty
+func (r *VirtSquadReconciler) patchPodLabel(ctx context.Context, pod *corev1.Pod, key, value string) error {
+	patch := client.MergeFrom(pod.DeepCopy())
+	if value == "" {
+		delete(pod.Labels, key)
+	} else {
+		if pod.Labels == nil {
+			pod.Labels = map[string]string{}
+		}
+		pod.Labels[key] = value
+	}
+	if err := r.Patch(ctx, pod, patch); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to label pod", "pod", pod.Name, "label", key)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// deleteReasonOrphaned is used for pods whose squad or team member no longer exists
+	deleteReasonOrphaned = "Orphaned"
+
+	// minOrphanAge keeps the sweep off pods created so recently that the cache may not have
+	// caught up with their squad yet
+	minOrphanAge = time.Minute
+)
+
+// orphanReason returns why the pod no longer belongs to a squad, or an empty string when it
+// still does. virtSquad is nil when the squad the pod is labeled with does not exist. Pods that
+// are not controlled by a VirtSquad of the name they are labeled with belong to someone else
+// and never count as orphaned, whatever their labels.
+func orphanReason(virtSquad *appsv1.VirtSquad, pod *corev1.Pod) string {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil || owner.APIVersion != appsv1.GroupVersion.String() || owner.Kind != "VirtSquad" ||
+		owner.Name != pod.Labels[squadLabel] {
+		return ""
+	}
+	if virtSquad == nil {
+		return "squad no longer exists"
+	}
+	if owner.UID != virtSquad.UID {
+		return "owned by an earlier squad of the same name"
+	}
+	if spec := memberSpec(virtSquad, pod.Labels[memberLabel]); spec == nil || spec.Name == nil {
+		return "team member no longer in the spec"
+	}
+	return ""
+}
+
+// sweepOrphanedPods deletes the squad pods left behind while the operator was down: pods whose
+// squad was deleted or recreated, and pods of team members removed from the spec. Squads whose
+// template cannot be resolved are skipped, as their members are unknown, and so are squads
+// whose changes await approval, as their spec is not applied yet.
+func (r *VirtSquadReconciler) sweepOrphanedPods(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("orphan-sweep")
+	if r.Paused {
+		return nil
+	}
+
+	squads := map[client.ObjectKey]*appsv1.VirtSquad{}
+	skipped := map[client.ObjectKey]bool{}
+	err := r.forEachPagedPod(ctx, func(pod *corev1.Pod) error {
+		if pod.DeletionTimestamp != nil || time.Since(pod.CreationTimestamp.Time) < minOrphanAge {
+			return nil
+		}
+		key := client.ObjectKey{Namespace: pod.Namespace, Name: pod.Labels[squadLabel]}
+		if skipped[key] {
+			return nil
+		}
+		virtSquad, seen := squads[key]
+		if !seen {
+			var err error
+			if virtSquad, err = r.sweptSquad(ctx, key); err != nil {
+				log.V(1).Info("Skipping pods of squad", "squad", key, "reason", err.Error())
+				skipped[key] = true
+				return nil
+			}
+			squads[key] = virtSquad
+		}
+
+		reason := orphanReason(virtSquad, pod)
+		if reason == "" {
+			return nil
+		}
+		log.Info("Deleting orphaned pod", "pod", client.ObjectKeyFromObject(pod), "reason", reason)
+		if virtSquad == nil {
+			// Without a squad there is no preDelete hook to notify
+			if err := r.Delete(ctx, pod); err != nil && !errors.IsNotFound(err) {
+				log.Error(err, "Failed to delete orphaned pod", "pod", client.ObjectKeyFromObject(pod))
+				return err
+			}
+			countOutcome(outcomeDeleted, deleteReasonOrphaned)
+			return nil
+		}
+		if err := r.deletePod(ctx, virtSquad, pod, deleteReasonOrphaned); err != nil && !errors.IsNotFound(err) {
+			log.Error(err, "Failed to delete orphaned pod", "pod", client.ObjectKeyFromObject(pod))
+			return err
+		}
+		r.eventf(ctx, virtSquad, corev1.EventTypeNormal, deleteReasonOrphaned,
+			"Deleted pod %s: %s", pod.Name, reason)
+		return nil
+	}, client.MatchingLabels{appLabel: appLabelValue}, client.HasLabels{squadLabel, memberLabel})
+	if err != nil {
+		log.Error(err, "Failed to sweep squad pods")
+	}
+	return err
+}
+
+// sweptSquad returns the squad with its template resolved, or nil when it does not exist. It
+// fails for squads of other shards, squads awaiting approval and squads whose members cannot be
+// determined.
+func (r *VirtSquadReconciler) sweptSquad(ctx context.Context, key client.ObjectKey) (*appsv1.VirtSquad, error) {
+	virtSquad := &appsv1.VirtSquad{}
+	if err := r.Get(ctx, key, virtSquad); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+		if r.ShardCount > 1 && squadShard(key.Namespace, key.Name, r.ShardCount) != r.ShardID {
+			return nil, fmt.Errorf("squad belongs to another shard")
+		}
+		return nil, nil
+	}
+	if !r.ownsSquad(virtSquad) {
+		return nil, fmt.Errorf("squad belongs to another shard")
+	}
+	if virtSquad.DeletionTimestamp != nil {
+		return nil, fmt.Errorf("squad is being deleted")
+	}
+	if awaitingApproval(virtSquad) {
+		return nil, fmt.Errorf("squad has changes awaiting approval")
+	}
+	if err := r.resolveTemplate(ctx, virtSquad); err != nil {
+		return nil, err
+	}
+	return virtSquad, nil
+}
+
+// runOrphanSweep sweeps orphaned pods every SweepInterval until the context is done
+func (r *VirtSquadReconciler) runOrphanSweep(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		_ = r.sweepOrphanedPods(ctx)
+	}, r.SweepInterval)
+	return nil
+}
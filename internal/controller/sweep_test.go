@@ -0,0 +1,138 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+var _ = Describe("Orphan sweep", func() {
+	var hookCalls []string
+	var hook *httptest.Server
+
+	BeforeEach(func() {
+		hookCalls = nil
+		hook = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			payload := map[string]any{}
+			_ = json.NewDecoder(req.Body).Decode(&payload)
+			hookCalls = append(hookCalls, payload["pod"].(string))
+		}))
+		DeferCleanup(hook.Close)
+	})
+
+	// squadPod returns a pod controlled by the squad of the given name, whose UID is uid-<squad>
+	squadPod := func(squad, member, name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			Labels:    map[string]string{appLabel: appLabelValue, squadLabel: squad, memberLabel: member},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: appsv1.GroupVersion.String(), Kind: "VirtSquad", Name: squad,
+				UID: types.UID("uid-" + squad), Controller: ptr.To(true),
+			}},
+		}}
+	}
+
+	sweep := func(objs ...client.Object) client.Client {
+		r, c := newFakeReconciler(objs...)
+		Expect(r.sweepOrphanedPods(context.Background())).To(Succeed())
+		return c
+	}
+
+	exists := func(c client.Client, name string) bool {
+		err := c.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: name}, &corev1.Pod{})
+		Expect(client.IgnoreNotFound(err)).To(Succeed())
+		return !apierrors.IsNotFound(err)
+	}
+
+	It("should delete pods of removed members through the preDelete hook", func() {
+		virtSquad := &appsv1.VirtSquad{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "alpha", UID: "uid-alpha"},
+			Spec: appsv1.VirtSquadSpec{
+				Oksana: &appsv1.TeamMemberSpec{Name: ptr.To("oksana")},
+				Hooks:  &appsv1.HooksSpec{PreDelete: &appsv1.HTTPHookSpec{URL: hook.URL}},
+			},
+		}
+		c := sweep(virtSquad,
+			squadPod("alpha", "oksana", "oksana-0"),
+			squadPod("alpha", "kurtis", "kurtis-0"),
+			squadPod("gone", "oksana", "gone-0"),
+		)
+
+		Expect(exists(c, "oksana-0")).To(BeTrue())
+		Expect(exists(c, "kurtis-0")).To(BeFalse())
+		Expect(exists(c, "gone-0")).To(BeFalse())
+		Expect(hookCalls).To(Equal([]string{"kurtis-0"}))
+	})
+
+	It("should delete pods of an earlier squad of the same name", func() {
+		virtSquad := &appsv1.VirtSquad{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "alpha", UID: "uid-recreated"},
+			Spec:       appsv1.VirtSquadSpec{Oksana: &appsv1.TeamMemberSpec{Name: ptr.To("oksana")}},
+		}
+		c := sweep(virtSquad, squadPod("alpha", "oksana", "oksana-0"))
+		Expect(exists(c, "oksana-0")).To(BeFalse())
+	})
+
+	It("should leave labeled pods that no VirtSquad of that name controls alone", func() {
+		foreign := squadPod("gone", "oksana", "foreign-0")
+		foreign.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web", UID: "uid-web", Controller: ptr.To(true),
+		}}
+		unowned := squadPod("gone", "oksana", "unowned-0")
+		unowned.OwnerReferences = nil
+		mislabeled := squadPod("other", "oksana", "mislabeled-0")
+		mislabeled.Labels[squadLabel] = "gone"
+
+		c := sweep(foreign, unowned, mislabeled)
+		Expect(exists(c, "foreign-0")).To(BeTrue())
+		Expect(exists(c, "unowned-0")).To(BeTrue())
+		Expect(exists(c, "mislabeled-0")).To(BeTrue())
+	})
+
+	It("should leave squads with changes awaiting approval alone", func() {
+		virtSquad := &appsv1.VirtSquad{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "alpha", UID: "uid-alpha", Generation: 2},
+			Spec: appsv1.VirtSquadSpec{
+				ConfirmChanges: ptr.To(true),
+				Oksana:         &appsv1.TeamMemberSpec{Name: ptr.To("oksana")},
+			},
+			Status: appsv1.VirtSquadStatus{ObservedGeneration: 1},
+		}
+		c := sweep(virtSquad, squadPod("alpha", "kurtis", "kurtis-0"))
+		Expect(exists(c, "kurtis-0")).To(BeTrue())
+
+		virtSquad.Annotations = map[string]string{appsv1.ApproveGenerationAnnotation: strconv.FormatInt(virtSquad.Generation, 10)}
+		c = sweep(virtSquad, squadPod("alpha", "kurtis", "kurtis-0"))
+		Expect(exists(c, "kurtis-0")).To(BeFalse())
+	})
+})
@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+const (
+	// reasonTemplateNotFound is the event reason used when a squad references a missing VirtSquadTemplate
+	reasonTemplateNotFound = "TemplateNotFound"
+	// reasonInvalidTemplate is reported when a squad's spec is invalid once its template is merged in
+	reasonInvalidTemplate = "InvalidTemplate"
+)
+
+// SpecValidator validates a squad's spec the way the admission webhook does, for specs the
+// webhook never saw
+type SpecValidator interface {
+	// ValidateMergedSpec returns an error describing what is invalid about the squad's spec
+	ValidateMergedSpec(virtSquad *appsv1.VirtSquad) error
+}
+
+// +kubebuilder:rbac:groups=apps.mshort55.io,resources=virtsquadtemplates,verbs=get;list;watch
+
+// mergeSquadSpec merges spec over defaults: fields spec sets take precedence, and lists spec
+// sets replace the defaults' lists
+func mergeSquadSpec(defaults, spec *appsv1.VirtSquadSpec) (*appsv1.VirtSquadSpec, error) {
+	if defaults == nil {
+		return spec.DeepCopy(), nil
+	}
+	original, err := json.Marshal(defaults)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	merged, err := strategicpatch.StrategicMergePatch(original, patch, appsv1.VirtSquadSpec{})
+	if err != nil {
+		return nil, err
+	}
+	result := &appsv1.VirtSquadSpec{}
+	if err := json.Unmarshal(merged, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// resolveTemplate merges the team members of the squad's VirtSquadTemplate under its spec. The
+// merged spec only lives in memory, so the squad must not be written back afterward.
+func (r *VirtSquadReconciler) resolveTemplate(ctx context.Context, virtSquad *appsv1.VirtSquad) error {
+	if virtSquad.Spec.TemplateRef == nil {
+		return nil
+	}
+
+	template := &appsv1.VirtSquadTemplate{}
+	key := client.ObjectKey{Namespace: virtSquad.Namespace, Name: virtSquad.Spec.TemplateRef.Name}
+	if err := r.Get(ctx, key, template); err != nil {
+		if errors.IsNotFound(err) {
+			r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonTemplateNotFound,
+				"VirtSquadTemplate %s does not exist", key.Name)
+			return fmt.Errorf("VirtSquadTemplate %s not found", key.Name)
+		}
+		logf.FromContext(ctx).Error(err, "Failed to get VirtSquadTemplate", "template", key.Name)
+		return err
+	}
+
+	spec, err := mergeSquadSpec(&appsv1.VirtSquadSpec{
+		Oksana: template.Spec.Oksana,
+		Kurtis: template.Spec.Kurtis,
+		Matt:   template.Spec.Matt,
+		Kike:   template.Spec.Kike,
+	}, &virtSquad.Spec)
+	if err != nil {
+		return fmt.Errorf("failed to merge VirtSquadTemplate %s: %w", key.Name, err)
+	}
+	virtSquad.Spec = *spec
+	return nil
+}
+
+// reconcileTemplateValidation validates the squad after its template was merged in, since the
+// webhook only validates the squad's own spec and a template can bring in members it would reject.
+// Invalid squads report the InvalidTemplate condition and are not reconciled any further; it
+// returns whether the squad was held back.
+func (r *VirtSquadReconciler) reconcileTemplateValidation(ctx context.Context, virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus) (bool, error) {
+	if virtSquad.Spec.TemplateRef == nil || r.TemplateValidator == nil {
+		meta.RemoveStatusCondition(&status.Conditions, appsv1.ConditionInvalidTemplate)
+		return false, nil
+	}
+
+	invalid := r.TemplateValidator.ValidateMergedSpec(virtSquad)
+	if invalid == nil {
+		meta.RemoveStatusCondition(&status.Conditions, appsv1.ConditionInvalidTemplate)
+		return false, nil
+	}
+	condition := metav1.Condition{
+		Type:   appsv1.ConditionInvalidTemplate,
+		Status: metav1.ConditionTrue,
+		Reason: reasonInvalidTemplate,
+		Message: fmt.Sprintf("The squad is invalid with the team members of VirtSquadTemplate %s and is not reconciled until it is fixed: %v",
+			virtSquad.Spec.TemplateRef.Name, invalid),
+		ObservedGeneration: virtSquad.Generation,
+	}
+	if !meta.IsStatusConditionTrue(status.Conditions, appsv1.ConditionInvalidTemplate) {
+		r.event(ctx, virtSquad, corev1.EventTypeWarning, condition.Reason, condition.Message)
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+
+	// Refetch the latest version to avoid resource version conflicts
+	latest := &appsv1.VirtSquad{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(virtSquad), latest); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to refetch VirtSquad for status update")
+		return false, err
+	}
+	latest.Status = *status
+	if err := r.Status().Update(ctx, latest); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to update VirtSquad status")
+		return false, err
+	}
+	return true, nil
+}
+
+// squadsUsingTemplate returns a reconcile request for every squad in the template's namespace
+// that references it
+func (r *VirtSquadReconciler) squadsUsingTemplate(ctx context.Context, obj client.Object) []reconcile.Request {
+	squads := &appsv1.VirtSquadList{}
+	if err := r.List(ctx, squads, client.InNamespace(obj.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list squads", "namespace", obj.GetNamespace())
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range squads.Items {
+		if ref := squads.Items[i].Spec.TemplateRef; ref != nil && ref.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&squads.Items[i])})
+		}
+	}
+	return requests
+}
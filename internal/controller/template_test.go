@@ -0,0 +1,176 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+	"github.com/mshort55/virtsquad-operator/test/crd"
+)
+
+// specValidatorFunc adapts a function to SpecValidator
+type specValidatorFunc func(virtSquad *appsv1.VirtSquad) error
+
+func (f specValidatorFunc) ValidateMergedSpec(virtSquad *appsv1.VirtSquad) error {
+	return f(virtSquad)
+}
+
+var _ = Describe("Templates", func() {
+	Describe("mergeSquadSpec", func() {
+		It("should let fields the squad sets take precedence over the template's", func() {
+			defaults := &appsv1.VirtSquadSpec{
+				Oksana: &appsv1.TeamMemberSpec{Name: ptr.To("oksana"), Replicas: ptr.To(int32(2)), Image: "nginx:1.27", Args: []string{"a", "b"}},
+				Kurtis: &appsv1.TeamMemberSpec{Name: ptr.To("kurtis")},
+			}
+			spec := &appsv1.VirtSquadSpec{
+				Oksana: &appsv1.TeamMemberSpec{Replicas: ptr.To(int32(5)), Args: []string{"c"}},
+			}
+
+			merged, err := mergeSquadSpec(defaults, spec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(merged.Oksana.Name).To(Equal(ptr.To("oksana")))
+			Expect(merged.Oksana.Replicas).To(Equal(ptr.To(int32(5))))
+			Expect(merged.Oksana.Image).To(Equal("nginx:1.27"))
+			// Lists are replaced, not appended to
+			Expect(merged.Oksana.Args).To(Equal([]string{"c"}))
+			Expect(merged.Kurtis.Name).To(Equal(ptr.To("kurtis")))
+			Expect(merged.Matt).To(BeNil())
+		})
+
+		It("should leave the inputs alone and copy the spec when there are no defaults", func() {
+			spec := &appsv1.VirtSquadSpec{Oksana: &appsv1.TeamMemberSpec{Name: ptr.To("oksana")}}
+			merged, err := mergeSquadSpec(nil, spec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(merged).To(Equal(spec))
+			merged.Oksana.Name = ptr.To("changed")
+			Expect(spec.Oksana.Name).To(Equal(ptr.To("oksana")))
+		})
+	})
+
+	Describe("resolveTemplate", func() {
+		It("should keep the template's fields a squad's member leaves unset once both are stored", func() {
+			template := &appsv1.VirtSquadTemplate{
+				TypeMeta:   metav1.TypeMeta{APIVersion: appsv1.GroupVersion.String(), Kind: "VirtSquadTemplate"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+				Spec: appsv1.VirtSquadTemplateSpec{
+					Oksana: &appsv1.TeamMemberSpec{Name: ptr.To("oksana"), Image: "nginx:1.27", Replicas: ptr.To(int32(3))},
+				},
+			}
+			virtSquad := &appsv1.VirtSquad{
+				TypeMeta:   metav1.TypeMeta{APIVersion: appsv1.GroupVersion.String(), Kind: "VirtSquad"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "squad"},
+				Spec: appsv1.VirtSquadSpec{
+					TemplateRef: &appsv1.TemplateReference{Name: "web"},
+					Oksana:      &appsv1.TeamMemberSpec{Args: []string{"--debug"}},
+				},
+			}
+			// Apply the defaults the API server applies when the objects are created
+			for plural, obj := range map[string]client.Object{"virtsquadtemplates": template, "virtsquads": virtSquad} {
+				schema, err := crd.Load(plural)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(schema.Default(obj)).To(Succeed())
+			}
+			r, _ := newFakeReconciler(template)
+
+			Expect(r.resolveTemplate(context.Background(), virtSquad)).To(Succeed())
+			Expect(virtSquad.Spec.Oksana.Image).To(Equal("nginx:1.27"))
+			Expect(virtSquad.Spec.Oksana.Replicas).To(Equal(ptr.To(int32(3))))
+			Expect(virtSquad.Spec.Oksana.Args).To(Equal([]string{"--debug"}))
+		})
+	})
+
+	Describe("squadsUsingTemplate", func() {
+		It("should request the squads of the template's namespace that reference it", func() {
+			squad := func(namespace, name, template string) *appsv1.VirtSquad {
+				virtSquad := &appsv1.VirtSquad{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+				if template != "" {
+					virtSquad.Spec.TemplateRef = &appsv1.TemplateReference{Name: template}
+				}
+				return virtSquad
+			}
+			r, _ := newFakeReconciler(
+				squad("default", "uses", "web"),
+				squad("default", "other", "db"),
+				squad("default", "none", ""),
+				squad("elsewhere", "uses", "web"),
+			)
+			template := &appsv1.VirtSquadTemplate{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"}}
+
+			Expect(r.squadsUsingTemplate(context.Background(), template)).To(ConsistOf(
+				reconcile.Request{NamespacedName: client.ObjectKey{Namespace: "default", Name: "uses"}},
+			))
+		})
+	})
+
+	Describe("reconcileTemplateValidation", func() {
+		It("should hold back squads that are invalid with their template, until they are fixed", func() {
+			virtSquad := &appsv1.VirtSquad{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "squad"},
+				Spec:       appsv1.VirtSquadSpec{TemplateRef: &appsv1.TemplateReference{Name: "web"}},
+			}
+			r, _ := newFakeReconciler(virtSquad)
+			invalid := errors.New("spec.oksana.replicas: too many")
+			r.TemplateValidator = specValidatorFunc(func(*appsv1.VirtSquad) error { return invalid })
+			recorder := r.Recorder.(*record.FakeRecorder)
+			status := &appsv1.VirtSquadStatus{}
+
+			held, err := r.reconcileTemplateValidation(context.Background(), virtSquad, status)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(held).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(status.Conditions, appsv1.ConditionInvalidTemplate)).To(BeTrue())
+			Expect(recorder.Events).To(HaveLen(1))
+
+			stored := &appsv1.VirtSquad{}
+			Expect(r.Get(context.Background(), client.ObjectKeyFromObject(virtSquad), stored)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(stored.Status.Conditions, appsv1.ConditionInvalidTemplate)).To(BeTrue())
+
+			// The event is only emitted when the squad becomes invalid
+			held, err = r.reconcileTemplateValidation(context.Background(), virtSquad, status)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(held).To(BeTrue())
+			Expect(recorder.Events).To(HaveLen(1))
+
+			invalid = nil
+			held, err = r.reconcileTemplateValidation(context.Background(), virtSquad, status)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(held).To(BeFalse())
+			Expect(meta.FindStatusCondition(status.Conditions, appsv1.ConditionInvalidTemplate)).To(BeNil())
+		})
+
+		It("should not validate squads without a template", func() {
+			r, _ := newFakeReconciler()
+			r.TemplateValidator = specValidatorFunc(func(*appsv1.VirtSquad) error {
+				Fail("squads without a template were validated")
+				return nil
+			})
+			held, err := r.reconcileTemplateValidation(context.Background(), &appsv1.VirtSquad{}, &appsv1.VirtSquadStatus{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(held).To(BeFalse())
+		})
+	})
+})
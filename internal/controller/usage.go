@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+var (
+	// memberCPUUsage exposes the CPU used by each team member's pods
+	memberCPUUsage = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "virtsquad_member_cpu_usage_cores",
+		Help: "CPU used by a team member's pods, in cores, as reported by the metrics API",
+	}, []string{"namespace", "squad", "member"})
+
+	// memberMemoryUsage exposes the memory used by each team member's pods
+	memberMemoryUsage = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "virtsquad_member_memory_usage_bytes",
+		Help: "Memory used by a team member's pods, in bytes, as reported by the metrics API",
+	}, []string{"namespace", "squad", "member"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(memberCPUUsage, memberMemoryUsage)
+}
+
+// deleteUsageMetrics drops the usage gauges of a squad
+func deleteUsageMetrics(virtSquad *appsv1.VirtSquad) {
+	labels := prometheus.Labels{"namespace": virtSquad.Namespace, "squad": virtSquad.Name}
+	memberCPUUsage.DeletePartialMatch(labels)
+	memberMemoryUsage.DeletePartialMatch(labels)
+}
+
+// reconcileUsage refreshes the members' CPU and memory usage from the metrics API once
+// UsageInterval has passed since the last refresh, and returns when to refresh it next.
+// Clusters without metrics-server keep reconciling; the usage is just left unreported.
+func (r *VirtSquadReconciler) reconcileUsage(ctx context.Context, virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus) time.Duration {
+	log := logf.FromContext(ctx)
+
+	if r.MetricsReader == nil || r.UsageInterval <= 0 {
+		return 0
+	}
+	if status.LastUsageTime != nil {
+		if wait := time.Until(status.LastUsageTime.Add(r.UsageInterval)); wait > 0 {
+			return wait
+		}
+	}
+
+	podMetrics := &metricsv1beta1.PodMetricsList{}
+	if err := r.MetricsReader.List(ctx, podMetrics,
+		client.InNamespace(virtSquad.Namespace),
+		client.MatchingLabels{squadLabel: virtSquad.Name},
+	); err != nil {
+		log.V(1).Info("Failed to read pod metrics", "error", err.Error())
+		return r.UsageInterval
+	}
+
+	usage := map[string]corev1.ResourceList{}
+	for _, pod := range podMetrics.Items {
+		memberName := pod.Labels[memberLabel]
+		if memberName == "" {
+			continue
+		}
+		if usage[memberName] == nil {
+			usage[memberName] = corev1.ResourceList{
+				corev1.ResourceCPU:    resource.Quantity{},
+				corev1.ResourceMemory: resource.Quantity{},
+			}
+		}
+		for _, container := range pod.Containers {
+			for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+				total := usage[memberName][name]
+				total.Add(container.Usage[name])
+				usage[memberName][name] = total
+			}
+		}
+	}
+
+	deleteUsageMetrics(virtSquad)
+	for i := range status.Members {
+		member := &status.Members[i]
+		member.Usage = usage[member.Name]
+		if member.Usage == nil {
+			continue
+		}
+		cpu := member.Usage[corev1.ResourceCPU]
+		memory := member.Usage[corev1.ResourceMemory]
+		memberCPUUsage.WithLabelValues(virtSquad.Namespace, virtSquad.Name, member.Name).Set(cpu.AsApproximateFloat64())
+		memberMemoryUsage.WithLabelValues(virtSquad.Namespace, virtSquad.Name, member.Name).Set(memory.AsApproximateFloat64())
+	}
+
+	now := metav1.Now()
+	status.LastUsageTime = &now
+	return r.UsageInterval
+}
@@ -19,37 +19,225 @@ package controller
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+	"github.com/mshort55/virtsquad-operator/internal/hooks"
+	"github.com/mshort55/virtsquad-operator/internal/notifications"
+	"github.com/mshort55/virtsquad-operator/internal/placeholders"
+	"github.com/mshort55/virtsquad-operator/internal/quota"
+	"github.com/mshort55/virtsquad-operator/internal/registry"
+	"github.com/mshort55/virtsquad-operator/internal/smoketest"
 )
 
+// tracer creates the reconcile spans; they are dropped unless the manager installs a tracer provider
+var tracer = otel.Tracer("github.com/mshort55/virtsquad-operator/internal/controller")
+
 // VirtSquadReconciler reconciles a VirtSquad object
 type VirtSquadReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// DebugImage is the default image for debug containers injected on request
+	DebugImage string
+
+	// Hooks calls the HTTP hooks configured on squads; a default client is used when nil
+	Hooks *hooks.Client
+
+	// SmokeTests runs the smoke tests of team members; a default runner that cannot exec into
+	// pods is used when nil
+	SmokeTests *smoketest.Runner
+
+	// Registry looks up the architectures of member images; architectures are not validated when nil
+	Registry *registry.Client
+
+	// Notifier delivers squad notifications; a default client is used when nil
+	Notifier *notifications.Client
+
+	// TemplateValidator validates squads with their VirtSquadTemplate merged in; squads using
+	// templates are not validated when nil
+	TemplateValidator SpecValidator
+
+	// SlackWebhookURL is the Slack incoming webhook used for squads that configure none
+	SlackWebhookURL string
+
+	// MaxReplicasPerMember caps the replicas run for a single team member; zero means no limit
+	MaxReplicasPerMember int32
+
+	// MaxPodsPerSquad caps the replicas run across a squad's team members; zero means no limit
+	MaxPodsPerSquad int32
+
+	// Paused halts all creates, updates and deletes of squad resources while status keeps being refreshed
+	Paused bool
+
+	// RateLimiter paces retries of failed reconciles; controller-runtime's default is used when nil
+	RateLimiter workqueue.TypedRateLimiter[reconcile.Request]
+
+	// ShardCount is the number of shards squads are split across; zero or one disables sharding
+	ShardCount int
+
+	// ShardID is the shard this operator replica is responsible for, from 0 to ShardCount-1
+	ShardID int
+
+	// InPlacePodResize changes the resources of running pods instead of recreating them when
+	// nothing else changed; the cluster must have InPlacePodVerticalScaling enabled
+	InPlacePodResize bool
+
+	// AllowDedicatedNodes lets squads claim dedicated nodes. Only nodes an administrator labeled
+	// as dedicatable are ever claimed.
+	AllowDedicatedNodes bool
+
+	// MetricsReader reads pod usage from the metrics API; usage reporting is disabled when nil
+	MetricsReader client.Reader
+
+	// UsageInterval is how often the members' resource usage is refreshed
+	UsageInterval time.Duration
+
+	// AvailabilityWindow is the period squad availability is computed over; zero disables it
+	AvailabilityWindow time.Duration
+
+	// APIReader reads from the API server directly, bypassing the cache
+	APIReader client.Reader
+
+	// PodListPageSize is the number of pods read per page when the orphan sweep lists the pods
+	// of every squad from the API server; zero lists them from the cache in one go. Reconciles
+	// always read a squad's pods from the cache.
+	PodListPageSize int64
+
+	// smokeTestRuns tracks the smoke tests running in the background
+	smokeTestRuns smokeTestRuns
+
+	// reconcileStreaks tracks the consecutive failed reconciles of squads
+	reconcileStreaks reconcileStreaks
+
+	// ownerIndexed is set once pods are indexed by their controlling squad in the cache
+	ownerIndexed bool
+
+	// UsePriorityQueue reconciles new and deleted squads ahead of other changes and of resyncs
+	UsePriorityQueue bool
+
+	// ResyncJitter is the window the periodic resync of squads is spread over; zero resyncs
+	// them all at once
+	ResyncJitter time.Duration
+
+	// SweepInterval is how often pods left behind by deleted squads and removed team members
+	// are swept; zero disables the sweep
+	SweepInterval time.Duration
+
+	// PriceTable is the ConfigMap holding the monthly prices costs are estimated from; costs
+	// are not estimated when its name is empty
+	PriceTable types.NamespacedName
 }
 
 // +kubebuilder:rbac:groups=apps.mshort55.io,resources=virtsquads,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.mshort55.io,resources=virtsquads/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps.mshort55.io,resources=virtsquads/finalizers,verbs=update
+// +kubebuilder:rbac:groups=apps.mshort55.io,resources=virtsquadquotas,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=pods/ephemeralcontainers,verbs=update
+// +kubebuilder:rbac:groups="",resources=pods/resize,verbs=patch
+// +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
+// +kubebuilder:rbac:groups=metrics.k8s.io,resources=pods,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=node.k8s.io,resources=runtimeclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 
 const (
 	virtSquadFinalizer = "virtsquad.mshort55.io/finalizer"
+
+	// Labels identifying the pods managed by a VirtSquad
+	appLabel         = "app"
+	appLabelValue    = "virtsquad"
+	memberLabel      = "virtsquad.mshort55.io/member"
+	squadLabel       = "virtsquad.mshort55.io/squad"
+	quarantinedLabel = "virtsquad.mshort55.io/quarantined"
+
+	// templateHashLabel records the hash of the template an object was rendered from
+	templateHashLabel = "virtsquad.mshort55.io/template-hash"
+
+	// minResyncInterval is the shortest periodic reconcile interval a squad can request
+	minResyncInterval = 10 * time.Second
+
+	// reasonInvalidResyncInterval is the event reason used when the resync-interval annotation cannot be parsed
+	reasonInvalidResyncInterval = "InvalidResyncInterval"
 )
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 func (r *VirtSquadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracer.Start(ctx, "Reconcile", trace.WithAttributes(
+		attribute.String("virtsquad.namespace", req.Namespace),
+		attribute.String("virtsquad.name", req.Name),
+		attribute.String("reconcile.id", string(controller.ReconcileIDFromContext(ctx))),
+	))
+	defer span.End()
+
+	// Squads whose reconciles keep failing are backed off instead of hot-looping
+	virtSquad := &appsv1.VirtSquad{}
+	if err := r.Get(ctx, req.NamespacedName, virtSquad); err == nil {
+		// Requests from owned objects are not filtered by the shard predicate
+		if !r.ownsSquad(virtSquad) {
+			countOutcome(outcomeSkipped, skipReasonOtherShard)
+			return ctrl.Result{}, nil
+		}
+		if wait := r.reconcileStreaks.wait(virtSquad, time.Now()); wait > 0 {
+			countOutcome(outcomeSkipped, skipReasonReconcileBlocked)
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
+		if !r.Paused {
+			var history *historyRecorder
+			ctx, history = withHistoryRecorder(ctx)
+			defer r.recordHistory(ctx, virtSquad, history)
+		}
+	}
+
+	result, err := r.reconcileSquad(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		countError(err)
+		return r.recordReconcileFailure(ctx, req, err)
+	}
+	r.resetReconcileFailures(ctx, req)
+	return result, nil
+}
+
+// reconcileSquad moves a single VirtSquad toward its desired state
+func (r *VirtSquadReconciler) reconcileSquad(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
 	// Fetch the VirtSquad instance
@@ -58,26 +246,48 @@ func (r *VirtSquadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	if err != nil {
 		if errors.IsNotFound(err) {
 			log.Info("VirtSquad resource not found. Ignoring since object must be deleted")
+			countOutcome(outcomeSkipped, skipReasonNotFound)
 			return ctrl.Result{}, nil
 		}
 		log.Error(err, "Failed to get VirtSquad")
 		return ctrl.Result{}, err
 	}
 
+	// While the operator is paused only the status is refreshed
+	if r.Paused {
+		countOutcome(outcomeSkipped, skipReasonPaused)
+		return ctrl.Result{}, r.reconcilePaused(ctx, virtSquad)
+	}
+
 	// Check if the VirtSquad instance is marked to be deleted
 	if virtSquad.GetDeletionTimestamp() != nil {
 		if controllerutil.ContainsFinalizer(virtSquad, virtSquadFinalizer) {
+			// Run the teardown Job, if any, before the squad's pods are removed
+			done, requeueAfter, err := r.reconcileFinalizeJob(ctx, virtSquad)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if !done {
+				return ctrl.Result{RequeueAfter: requeueAfter}, nil
+			}
+
 			// Run finalization logic for virtSquadFinalizer
 			if err := r.finalizeVirtSquad(ctx, virtSquad); err != nil {
 				return ctrl.Result{}, err
 			}
+			// Resolve any incident left open; the squad's status goes away with it
+			r.reconcileAlert(ctx, virtSquad, virtSquad.Status.DeepCopy())
+			deleteUsageMetrics(virtSquad)
+			deleteRestartMetrics(virtSquad, "")
+			deleteMemberMetrics(virtSquad, "")
 
 			// Remove virtSquadFinalizer
 			controllerutil.RemoveFinalizer(virtSquad, virtSquadFinalizer)
-			err := r.Update(ctx, virtSquad)
+			err = r.Update(ctx, virtSquad)
 			if err != nil {
 				return ctrl.Result{}, err
 			}
+			r.notify(ctx, virtSquad, notifications.EventDeleted, "The squad and its pods were deleted")
 		}
 		return ctrl.Result{}, nil
 	}
@@ -91,35 +301,174 @@ func (r *VirtSquadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}
 
-	// Reconcile each team member
-	status := &appsv1.VirtSquadStatus{}
+	// Recreate the squad from a backup on request
+	if restored, err := r.restoreFromBackup(ctx, virtSquad); err != nil || restored {
+		return ctrl.Result{}, err
+	}
+
+	// Take over the workloads listed for import
+	if imported, err := r.reconcileImports(ctx, virtSquad); err != nil || imported {
+		return ctrl.Result{}, err
+	}
+
+	// Reconcile each team member, starting from the previous status so that
+	// condition transition times are preserved
+	status := virtSquad.Status.DeepCopy()
+
+	// Give members marked Failed a fresh set of recreate attempts on request
+	if _, ok := virtSquad.Annotations[appsv1.ResetFailuresAnnotation]; ok {
+		for i := range status.Members {
+			resetRecreateAttempts(&status.Members[i])
+		}
+		delete(virtSquad.Annotations, appsv1.ResetFailuresAnnotation)
+		if err := r.Update(ctx, virtSquad); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Inject a debug container into a squad pod on request
+	if err := r.injectDebugContainer(ctx, virtSquad, status); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Apply a member scale change requested through the status API
+	if err := r.reconcileScaleRequest(ctx, virtSquad, status); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Backups hold the spec as stored, before the template and overrides are applied
+	stored := virtSquad.DeepCopy()
 
-	if err := r.reconcileTeamMember(ctx, virtSquad, "oksana", virtSquad.Spec.Oksana, &status.OksanaPods); err != nil {
+	// From here on the squad is only read, so its spec can take on the template's members
+	if err := r.resolveTemplate(ctx, virtSquad); err != nil {
+		return ctrl.Result{}, err
+	}
+	if invalid, err := r.reconcileTemplateValidation(ctx, virtSquad, status); err != nil || invalid {
+		return ctrl.Result{}, err
+	}
+	// Replicas in other namespaces get the squad's own spec; overrides only apply locally
+	unoverridden := virtSquad.DeepCopy()
+	if err := r.applyMemberOverrides(ctx, virtSquad); err != nil {
 		return ctrl.Result{}, err
 	}
+	applyMemoryAdjustments(virtSquad, status)
 
-	if err := r.reconcileTeamMember(ctx, virtSquad, "kurtis", virtSquad.Spec.Kurtis, &status.KurtisPods); err != nil {
+	// Leave pods that collide with those of an older squad to that squad
+	if blocked, err := r.reconcileConflicts(ctx, virtSquad, status); err != nil || blocked {
+		return ctrl.Result{}, err
+	}
+
+	// Hold spec changes the squad asks to confirm until they are approved
+	if held, err := r.reconcileConfirmation(ctx, virtSquad, status); err != nil || held {
+		return ctrl.Result{}, err
+	}
+
+	// List the squad's pods once; each team member works on its share of them. Quarantined
+	// pods no longer belong to a team member, but their names stay taken.
+	pods, err := r.listSquadPods(ctx, virtSquad)
+	if err != nil {
+		log.Error(err, "Failed to list squad pods")
+		return ctrl.Result{}, err
+	}
+	status.QuarantinedPods = pods.quarantined
+
+	// Claim the squad's dedicated nodes before its pods are pinned to them
+	if err := r.reconcileDedicatedNodes(ctx, virtSquad, status); err != nil {
+		return ctrl.Result{}, err
+	}
+	// Create the ServiceAccount of an isolated squad before pods run under it
+	if err := r.reconcileIsolation(ctx, virtSquad); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	if err := r.reconcileTeamMember(ctx, virtSquad, "matt", virtSquad.Spec.Matt, &status.MattPods); err != nil {
+	result := ctrl.Result{}
+	meta.RemoveStatusCondition(&status.Conditions, appsv1.ConditionPaused)
+	r.reconcileRotation(ctx, virtSquad, status, time.Now())
+	budget, err := r.newPodBudget(ctx, virtSquad)
+	if err != nil {
+		log.Error(err, "Failed to evaluate the pod budget")
 		return ctrl.Result{}, err
 	}
+	status.DesiredPods = 0
+	prices := r.loadPriceTable(ctx)
+	totalCost := 0.0
+	footprint := corev1.ResourceList{}
+	for _, member := range squadMembers(virtSquad, status) {
+		desiredReplicas := r.desiredReplicas(virtSquad, status, member.name, member.spec, budget)
+		if member.spec != nil && member.spec.Name != nil {
+			trackHibernatedReplicas(virtSquad, memberStatus(status, member.name),
+				int32(len(*member.statusPods)), desiredReplicas)
+		}
+		standbyReplicas := r.standbyReplicas(virtSquad, status, member.name, member.spec, budget)
+		status.DesiredPods += desiredReplicas + standbyReplicas
+		if member.spec != nil && member.spec.Name != nil {
+			overhead, err := quota.PodOverhead(ctx, r.Client, member.spec)
+			if err != nil {
+				log.Error(err, "Failed to get the pod overhead", "member", member.name)
+				return ctrl.Result{}, err
+			}
+			memberStatus(status, member.name).PodOverhead = overhead
+			addMemberFootprint(footprint, virtSquad, member.spec, overhead, desiredReplicas+standbyReplicas)
+			memberStatus(status, member.name).EstimatedMonthlyCost = ""
+			if prices != nil {
+				cost := prices.monthlyCost(member.spec, overhead, desiredReplicas+standbyReplicas)
+				memberStatus(status, member.name).EstimatedMonthlyCost = prices.formatCost(cost)
+				totalCost += cost
+			}
+		}
+		requeueAfter, err := r.reconcileTeamMember(ctx, virtSquad, status, member.name, member.spec, desiredReplicas, standbyReplicas, member.statusPods, pods.members[member.name])
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		result.RequeueAfter = minRequeue(result.RequeueAfter, requeueAfter)
+	}
 
-	if err := r.reconcileTeamMember(ctx, virtSquad, "kike", virtSquad.Spec.Kike, &status.KikePods); err != nil {
+	if updateQuotaExceeded(virtSquad, status, budget) {
+		condition := meta.FindStatusCondition(status.Conditions, appsv1.ConditionQuotaExceeded)
+		r.event(ctx, virtSquad, corev1.EventTypeWarning, condition.Reason, condition.Message)
+	}
+	status.EstimatedMonthlyCost = ""
+	if prices != nil {
+		status.EstimatedMonthlyCost = prices.formatCost(totalCost)
+	}
+	if err := r.reconcileReplicas(ctx, unoverridden, status); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.reconcileDashboard(ctx, virtSquad); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.reconcileNamespaceQuota(ctx, virtSquad, status, footprint); err != nil {
 		return ctrl.Result{}, err
 	}
 
 	// Update status
+	becameDegraded := updateSquadDegraded(virtSquad, status)
 	status.TotalPods = int32(len(status.OksanaPods) + len(status.KurtisPods) + len(status.MattPods) + len(status.KikePods))
+	status.MemberCount = int32(len(status.Members))
 
-	// Count ready pods
-	readyCount, err := r.countReadyPods(ctx, virtSquad)
+	status.ReadyPods = pods.ready(virtSquad)
+	status.NextScheduledChange = nil
+	next, scheduleProblems := nextScheduledChange(virtSquad, time.Now())
+	if next != nil {
+		status.NextScheduledChange = &metav1.Time{Time: *next}
+	}
+	if updateInvalidSchedule(virtSquad, status, scheduleProblems) {
+		condition := meta.FindStatusCondition(status.Conditions, appsv1.ConditionInvalidSchedule)
+		r.event(ctx, virtSquad, corev1.EventTypeWarning, condition.Reason, condition.Message)
+	}
+	updateHibernated(virtSquad, status, time.Now())
+	updateSquadReady(virtSquad, status)
+	updateAvailability(virtSquad, status, r.AvailabilityWindow, time.Now())
+	result.RequeueAfter = minRequeue(result.RequeueAfter, r.reconcileAlert(ctx, virtSquad, status))
+	result.RequeueAfter = minRequeue(result.RequeueAfter, r.reconcileUsage(ctx, virtSquad, status))
+	result.RequeueAfter = minRequeue(result.RequeueAfter, r.reconcileBackup(ctx, stored, status))
+	chaosRequeue, err := r.reconcileChaos(ctx, virtSquad, status)
 	if err != nil {
-		log.Error(err, "Failed to count ready pods")
-	} else {
-		status.ReadyPods = readyCount
+		return ctrl.Result{}, err
 	}
+	result.RequeueAfter = minRequeue(result.RequeueAfter, chaosRequeue)
+	clearReconcileBlocked(status)
+	status.ObservedGeneration = virtSquad.Generation
 
 	// Refetch the latest version to avoid resource version conflicts
 	latest := &appsv1.VirtSquad{}
@@ -134,103 +483,425 @@ func (r *VirtSquadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		log.Error(err, "Failed to update VirtSquad status")
 		return ctrl.Result{}, err
 	}
+	r.notifyConditionTransitions(ctx, virtSquad, virtSquad.Status.Conditions, status.Conditions)
+	if becameDegraded {
+		condition := meta.FindStatusCondition(status.Conditions, appsv1.ConditionDegraded)
+		r.notify(ctx, virtSquad, notifications.EventDegraded, condition.Message)
+	}
 
-	return ctrl.Result{}, nil
+	result.RequeueAfter = minRequeue(result.RequeueAfter, r.resyncInterval(ctx, virtSquad))
+	if status.NextScheduledChange != nil {
+		// Wake up just after the schedule fires so it is not evaluated a moment too early
+		result.RequeueAfter = minRequeue(result.RequeueAfter, time.Until(status.NextScheduledChange.Time)+time.Second)
+	}
+	return result, nil
+}
+
+// squadMember pairs a team member's spec with the status field tracking its pods
+type squadMember struct {
+	name       string
+	spec       *appsv1.TeamMemberSpec
+	statusPods *[]string
 }
 
-// reconcileTeamMember handles pod reconciliation for a single team member
-func (r *VirtSquadReconciler) reconcileTeamMember(ctx context.Context, virtSquad *appsv1.VirtSquad, memberName string, memberSpec *appsv1.TeamMemberSpec, statusPods *[]string) error {
+// squadMembers lists the team members of a VirtSquad in reconciliation order
+func squadMembers(virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus) []squadMember {
+	return []squadMember{
+		{"oksana", virtSquad.Spec.Oksana, &status.OksanaPods},
+		{"kurtis", virtSquad.Spec.Kurtis, &status.KurtisPods},
+		{"matt", virtSquad.Spec.Matt, &status.MattPods},
+		{"kike", virtSquad.Spec.Kike, &status.KikePods},
+	}
+}
+
+// memberSpec returns the spec of the named team member, or nil when the member is unknown or unset
+func memberSpec(virtSquad *appsv1.VirtSquad, memberName string) *appsv1.TeamMemberSpec {
+	for _, member := range squadMembers(virtSquad, &appsv1.VirtSquadStatus{}) {
+		if member.name == memberName {
+			return member.spec
+		}
+	}
+	return nil
+}
+
+// reconcileTeamMember handles pod reconciliation for a single team member, running
+// desiredReplicas serving pods plus standbyReplicas standby pods.
+// existingPods are the member's pods as listed at the start of the reconcile.
+// It returns a non-zero duration when the member needs to be reconciled again later.
+func (r *VirtSquadReconciler) reconcileTeamMember(ctx context.Context, virtSquad *appsv1.VirtSquad, status *appsv1.VirtSquadStatus, memberName string, memberSpec *appsv1.TeamMemberSpec, desiredReplicas, standbyReplicas int32, statusPods *[]string, existingPods []corev1.Pod) (time.Duration, error) {
+	ctx, span := tracer.Start(ctx, "reconcileTeamMember", trace.WithAttributes(
+		attribute.String("virtsquad.member", memberName),
+		attribute.Int("virtsquad.desired_replicas", int(desiredReplicas)),
+		attribute.Int("virtsquad.standby_replicas", int(standbyReplicas)),
+	))
+	defer span.End()
+
 	log := logf.FromContext(ctx)
 
+	if err := r.reconcileMemberService(ctx, virtSquad, memberName, memberSpec); err != nil {
+		return 0, err
+	}
+	if err := r.reconcileLeaderService(ctx, virtSquad, memberName, memberSpec); err != nil {
+		return 0, err
+	}
+
 	if memberSpec == nil || memberSpec.Name == nil {
 		// Team member not specified, delete any existing pods
-		return r.deleteTeamMemberPods(ctx, virtSquad, memberName, statusPods)
+		removeMemberStatus(status, memberName)
+		deleteRestartMetrics(virtSquad, memberName)
+		deleteMemberMetrics(virtSquad, memberName)
+		if err := r.deleteMemberRevisions(ctx, virtSquad, memberName); err != nil {
+			return 0, err
+		}
+		return 0, r.deleteTeamMemberPods(ctx, virtSquad, existingPods, statusPods)
 	}
 
-	// Determine desired replica count
-	desiredReplicas := int32(1)
-	if memberSpec.Replicas != nil {
-		desiredReplicas = *memberSpec.Replicas
+	// In active/passive mode a single pod serves and the other replicas wait as passive pods
+	if activePassive(memberSpec) && desiredReplicas > 1 {
+		standbyReplicas += desiredReplicas - 1
+		desiredReplicas = 1
 	}
 
-	// Get existing pods for this team member
-	existingPods := &corev1.PodList{}
-	listOpts := []client.ListOption{
-		client.InNamespace(virtSquad.Namespace),
-		client.MatchingLabels{
-			"app":                          "virtsquad",
-			"virtsquad.mshort55.io/member": memberName,
-			"virtsquad.mshort55.io/squad":  virtSquad.Name,
-		},
+	// Pods that are already being deleted no longer count toward the replica total,
+	// but their names stay reserved until they are gone
+	usedNames := make(map[string]bool, len(existingPods)+len(status.QuarantinedPods))
+	for _, podName := range status.QuarantinedPods {
+		usedNames[podName] = true
+	}
+	pods := make([]corev1.Pod, 0, len(existingPods))
+	for _, pod := range existingPods {
+		usedNames[pod.Name] = true
+		if pod.DeletionTimestamp == nil {
+			pods = append(pods, pod)
+		}
 	}
 
-	if err := r.List(ctx, existingPods, listOpts...); err != nil {
-		log.Error(err, "Failed to list existing pods", "member", memberName)
-		return err
+	// Detach pods that on-call engineers asked to quarantine; they keep running
+	// for debugging while a replacement is created
+	pods, err := r.quarantinePods(ctx, virtSquad, pods)
+	if err != nil {
+		return 0, err
+	}
+
+	// Replace interrupted spot pods before they are gone, so the member keeps its capacity
+	member := memberStatus(status, memberName)
+	if pods, err = r.backfillSpotInterruptions(ctx, virtSquad, member, memberSpec.CapacityPolicy, pods); err != nil {
+		return 0, err
 	}
 
-	currentReplicas := int32(len(existingPods.Items))
+	// Surface pods that are stuck crash-looping or unable to pull their image
+	if updateMemberDegraded(virtSquad, member, pods) {
+		condition := meta.FindStatusCondition(member.Conditions, appsv1.ConditionDegraded)
+		r.eventf(ctx, virtSquad, corev1.EventTypeWarning, condition.Reason,
+			"Team member %s is degraded: %s", memberName, condition.Message)
+	}
+	updateMemberRestarts(virtSquad, member, pods)
+	r.reconcileOOMPolicy(ctx, virtSquad, member, memberSpec, pods)
+	updateMemberFirstReady(member, pods, desiredReplicas)
+	updateMemberReplicaMetrics(virtSquad, memberName, pods, desiredReplicas+standbyReplicas)
 
-	// Scale up if needed
-	if currentReplicas < desiredReplicas {
-		for i := currentReplicas; i < desiredReplicas; i++ {
-			if err := r.createPodForMember(ctx, virtSquad, memberName, *memberSpec.Name, i); err != nil {
-				return err
+	// Replace pods that keep failing, within the limits of the failure policy
+	activePods, requeueAfter, err := r.applyFailurePolicy(ctx, virtSquad, member, memberSpec.FailurePolicy, pods)
+	if err != nil {
+		return 0, err
+	}
+
+	// Pods that have exited are replaced rather than counted, unless the member
+	// has exhausted its failure policy and must not be recreated anymore
+	if !meta.IsStatusConditionTrue(member.Conditions, appsv1.ConditionFailed) {
+		if activePods, err = r.collectTerminatedPods(ctx, virtSquad, activePods); err != nil {
+			return 0, err
+		}
+	}
+
+	// Test newly ready pods before they count toward the squad's readiness
+	activePods, smokeTestRequeue, err := r.runSmokeTests(ctx, virtSquad, memberName, memberSpec, activePods)
+	if err != nil {
+		return 0, err
+	}
+	requeueAfter = minRequeue(requeueAfter, smokeTestRequeue)
+
+	// Replace pods on cordoned nodes before they are evicted, so the member keeps its capacity
+	// while nodes are drained
+	if activePods, err = r.replaceDrainingPods(ctx, virtSquad, memberName, activePods, desiredReplicas+standbyReplicas); err != nil {
+		return 0, err
+	}
+	if err := r.reconcileEvictionAnnotations(ctx, memberSpec, activePods); err != nil {
+		return 0, err
+	}
+
+	// Wait for the members this one depends on, check the image architecture, then run the
+	// pre-start Job, before any of the member's pods are created
+	ready, err := r.reconcileDependencies(ctx, virtSquad, member, memberSpec)
+	if err != nil {
+		return 0, err
+	}
+	if ready {
+		ready = r.reconcileArchitecture(ctx, virtSquad, member, memberSpec)
+	}
+	if ready {
+		if ready, err = r.reconcilePreStartJob(ctx, virtSquad, member, memberSpec.PreStartJob); err != nil {
+			return 0, err
+		}
+	}
+
+	// Record the rendered template and replace pods rendered from an older revision
+	// according to the rollout strategy
+	podSpec, templateHash, err := r.reconcileRevisions(ctx, virtSquad, member, memberName, memberSpec)
+	if err != nil {
+		return 0, err
+	}
+	if err := r.adoptUnlabeledPods(ctx, activePods, &podSpec, templateHash); err != nil {
+		return 0, err
+	}
+	if r.InPlacePodResize {
+		if err := r.resizePodsInPlace(ctx, virtSquad, memberName, activePods, podSpec, templateHash); err != nil {
+			return 0, err
+		}
+	}
+	totalReplicas := desiredReplicas + standbyReplicas
+	activePods, rolloutRequeue, err := r.reconcileRollout(ctx, virtSquad, member, memberSpec, activePods, totalReplicas, templateHash)
+	if err != nil {
+		return 0, err
+	}
+	requeueAfter = minRequeue(requeueAfter, rolloutRequeue)
+
+	currentReplicas := int32(len(activePods))
+
+	// Scale down if needed, removing outdated and unready pods first
+	remainingPods := activePods
+	if currentReplicas > totalReplicas {
+		sortPodsForDeletion(activePods, templateHash)
+		podsToDelete := currentReplicas - totalReplicas
+		for i := int32(0); i < podsToDelete && i < int32(len(activePods)); i++ {
+			if err := r.deletePod(ctx, virtSquad, &activePods[i], deleteReasonScaleDown); err != nil {
+				log.Error(err, "Failed to delete pod", "pod", activePods[i].Name)
+				return 0, err
 			}
 		}
+		remainingPods = activePods[min(podsToDelete, currentReplicas):]
 	}
 
-	// Scale down if needed
-	if currentReplicas > desiredReplicas {
-		podsToDelete := currentReplicas - desiredReplicas
-		for i := int32(0); i < podsToDelete && i < int32(len(existingPods.Items)); i++ {
-			if err := r.Delete(ctx, &existingPods.Items[i]); err != nil {
-				log.Error(err, "Failed to delete pod", "pod", existingPods.Items[i].Name)
-				return err
+	// Promote standby pods into the Service before creating replacements for them
+	var servingPods []string
+	if usesTrafficRoles(memberSpec) {
+		if servingPods, err = r.assignTraffic(ctx, remainingPods, desiredReplicas, templateHash); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := r.electLeader(ctx, virtSquad, member, memberSpec, remainingPods); err != nil {
+		return 0, err
+	}
+
+	// Scale up if needed
+	if ready && currentReplicas < totalReplicas {
+		zoneCounts := podsPerZone(remainingPods)
+		capacityCounts := podsPerCapacity(remainingPods)
+		for i := currentReplicas; i < totalReplicas; i++ {
+			traffic := ""
+			if usesTrafficRoles(memberSpec) {
+				traffic = trafficStandby
+				if int32(len(servingPods)) < desiredReplicas {
+					traffic = trafficServing
+				}
+			}
+			podName := nextPodName(*memberSpec.Name, usedNames)
+			capacity := nextCapacity(member, memberSpec.CapacityPolicy, totalReplicas, capacityCounts)
+			pinned := pinToCapacity(pinToZone(podSpec, nextZone(memberSpec.Zones, zoneCounts)), memberSpec.CapacityPolicy, capacity)
+			if err := r.createPodForMember(ctx, virtSquad, memberName, podName, pinned, templateHash, traffic, capacity); err != nil {
+				return 0, err
+			}
+			if traffic == trafficServing {
+				servingPods = append(servingPods, podName)
 			}
 		}
 	}
 
+	if activePassive(memberSpec) {
+		r.reconcileActivePod(ctx, virtSquad, member, remainingPods, servingPods)
+	} else {
+		member.ActivePod = ""
+	}
+
 	// Update status with current pod names
-	*statusPods = make([]string, 0, len(existingPods.Items))
-	for _, pod := range existingPods.Items {
+	*statusPods = make([]string, 0, len(activePods))
+	for _, pod := range activePods {
 		*statusPods = append(*statusPods, pod.Name)
 	}
 
-	return nil
+	return requeueAfter, nil
+}
+
+// minRequeue returns the shorter of two requeue delays, where zero means no requeue
+func minRequeue(a, b time.Duration) time.Duration {
+	if a == 0 || (b > 0 && b < a) {
+		return b
+	}
+	return a
 }
 
-// createPodForMember creates a new pod for a team member
-func (r *VirtSquadReconciler) createPodForMember(ctx context.Context, virtSquad *appsv1.VirtSquad, memberName, podBaseName string, replica int32) error {
+// resyncInterval returns the periodic reconcile interval requested by the resync-interval
+// annotation, or zero when none is set. Invalid values are reported and ignored.
+func (r *VirtSquadReconciler) resyncInterval(ctx context.Context, virtSquad *appsv1.VirtSquad) time.Duration {
+	value, ok := virtSquad.Annotations[appsv1.ResyncIntervalAnnotation]
+	if !ok {
+		return 0
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 {
+		r.eventf(ctx, virtSquad, corev1.EventTypeWarning, reasonInvalidResyncInterval,
+			"Ignoring %s annotation %q: expected a positive duration such as 5m", appsv1.ResyncIntervalAnnotation, value)
+		return 0
+	}
+	// Keep a misconfigured squad from keeping the controller busy
+	return max(interval, minResyncInterval)
+}
+
+// nextPodName returns the lowest-ordinal pod name for the base name that is not in use
+// and reserves it
+func nextPodName(podBaseName string, usedNames map[string]bool) string {
+	for i := 0; ; i++ {
+		podName := fmt.Sprintf("%s-%d", podBaseName, i)
+		if !usedNames[podName] {
+			usedNames[podName] = true
+			return podName
+		}
+	}
+}
+
+// memberLabels returns the labels that identify the pods of a team member
+func memberLabels(virtSquad *appsv1.VirtSquad, memberName string) map[string]string {
+	return map[string]string{
+		appLabel:    appLabelValue,
+		memberLabel: memberName,
+		squadLabel:  virtSquad.Name,
+	}
+}
+
+// memberImage returns the image a team member's pods run. The CRD does not default the image,
+// so that a member set in the squad does not override the image of its template.
+func memberImage(memberSpec *appsv1.TeamMemberSpec) string {
+	if memberSpec.Image == "" {
+		return appsv1.DefaultMemberImage
+	}
+	return memberSpec.Image
+}
+
+// memberPodSpec renders the pod spec for a team member's pods
+func memberPodSpec(virtSquad *appsv1.VirtSquad, memberName string, memberSpec *appsv1.TeamMemberSpec) corev1.PodSpec {
+	image := memberImage(memberSpec)
+
+	podSpec := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name:    memberName,
+				Image:   image,
+				Command: slices.Clone(memberSpec.Command),
+				Args:    slices.Clone(memberSpec.Args),
+				Ports: []corev1.ContainerPort{
+					{
+						ContainerPort: 80,
+						Name:          "http",
+					},
+				},
+			},
+		},
+	}
+	podSpec.Containers[0].Env = slices.Clone(memberSpec.Env)
+	if ptr.Deref(memberSpec.InjectMetadata, false) {
+		podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, metadataEnv()...)
+	}
+	if memberSpec.Resources != nil {
+		podSpec.Containers[0].Resources = *memberSpec.Resources.DeepCopy()
+	}
+	if memberSpec.Architecture != "" {
+		podSpec.NodeSelector = map[string]string{corev1.LabelArchStable: memberSpec.Architecture}
+	}
+	if memberSpec.RuntimeClassName != nil {
+		runtimeClassName := *memberSpec.RuntimeClassName
+		podSpec.RuntimeClassName = &runtimeClassName
+	}
+	if memberSpec.OS != "" {
+		if podSpec.NodeSelector == nil {
+			podSpec.NodeSelector = map[string]string{}
+		}
+		podSpec.NodeSelector[corev1.LabelOSStable] = memberSpec.OS
+		podSpec.OS = &corev1.PodOS{Name: corev1.OSName(memberSpec.OS)}
+	}
+	if memberSpec.WindowsOptions != nil {
+		podSpec.SecurityContext = &corev1.PodSecurityContext{WindowsOptions: memberSpec.WindowsOptions.DeepCopy()}
+	}
+	if memberSpec.Performance != nil {
+		applyPerformance(&podSpec, memberSpec.Performance)
+	}
+	applyIsolation(virtSquad, memberSpec, &podSpec)
+	dedicatedNodePlacement(virtSquad, &podSpec)
+	podSpec.Affinity = placementAffinity(virtSquad, memberName)
+	if len(memberSpec.Zones) > 0 {
+		if podSpec.Affinity == nil {
+			podSpec.Affinity = &corev1.Affinity{}
+		}
+		podSpec.Affinity.NodeAffinity = zoneNodeAffinity(memberSpec.Zones)
+	}
+	return podSpec
+}
+
+// createPodForMember creates a new pod for a team member from the rendered pod spec, labeled
+// with its traffic role and capacity type unless those are empty
+func (r *VirtSquadReconciler) createPodForMember(ctx context.Context, virtSquad *appsv1.VirtSquad, memberName, podName string, podSpec corev1.PodSpec, templateHash, traffic, capacity string) error {
 	log := logf.FromContext(ctx)
 
-	podName := fmt.Sprintf("%s-%d", podBaseName, replica)
+	// The operator's own labels take precedence over the member's pod labels
+	labels := map[string]string{}
+	if spec := memberSpec(virtSquad, memberName); spec != nil {
+		maps.Copy(labels, spec.PodLabels)
+	}
+	maps.Copy(labels, memberLabels(virtSquad, memberName))
 
 	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      podName,
 			Namespace: virtSquad.Namespace,
-			Labels: map[string]string{
-				"app":                          "virtsquad",
-				"virtsquad.mshort55.io/member": memberName,
-				"virtsquad.mshort55.io/squad":  virtSquad.Name,
-			},
-		},
-		Spec: corev1.PodSpec{
-			Containers: []corev1.Container{
-				{
-					Name:  memberName,
-					Image: "nginx:latest",
-					Ports: []corev1.ContainerPort{
-						{
-							ContainerPort: 80,
-							Name:          "http",
-						},
-					},
-				},
-			},
+			Labels:    labels,
 		},
+		Spec: *podSpec.DeepCopy(),
 	}
+	pod.Labels[templateHashLabel] = templateHash
+	setOrdinalLabel(pod)
+
+	// Placeholders are resolved per pod, after hashing, so replicas share the member's template
+	values := placeholders.Values{
+		Squad:   placeholders.Squad{Name: virtSquad.Name, Namespace: virtSquad.Namespace},
+		Member:  placeholders.Member{Name: memberName},
+		Ordinal: max(podOrdinal(pod), 0),
+	}
+	if err := placeholders.ExpandPodSpec(&pod.Spec, values); err != nil {
+		return fmt.Errorf("failed to resolve placeholders for pod %s: %w", podName, err)
+	}
+	if spec := memberSpec(virtSquad, memberName); spec != nil && len(spec.PodAnnotations) > 0 {
+		pod.Annotations = make(map[string]string, len(spec.PodAnnotations))
+		for key, value := range spec.PodAnnotations {
+			expanded, err := placeholders.Expand(value, values)
+			if err != nil {
+				return fmt.Errorf("failed to resolve placeholders in annotation %s: %w", key, err)
+			}
+			pod.Annotations[key] = expanded
+		}
+	}
+	if traffic != "" {
+		pod.Labels[trafficLabel] = traffic
+	}
+	if capacity != "" {
+		pod.Labels[capacityLabel] = capacity
+	}
+	if annotations := reconcileAnnotations(ctx); annotations != nil {
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		maps.Copy(pod.Annotations, annotations)
+	}
+	applyEvictionAnnotations(pod, evictionAnnotations(memberSpec(virtSquad, memberName)))
 
 	// Set VirtSquad instance as the owner and controller
 	if err := controllerutil.SetControllerReference(virtSquad, pod, r.Scheme); err != nil {
@@ -238,33 +909,23 @@ func (r *VirtSquadReconciler) createPodForMember(ctx context.Context, virtSquad
 	}
 
 	log.Info("Creating pod", "pod", podName, "member", memberName)
-	return r.Create(ctx, pod)
+	if err := r.Create(ctx, pod); err != nil {
+		return err
+	}
+	countOutcome(outcomeCreated, createReasonMissingReplica)
+	return nil
 }
 
-// deleteTeamMemberPods deletes all pods for a team member
-func (r *VirtSquadReconciler) deleteTeamMemberPods(ctx context.Context, virtSquad *appsv1.VirtSquad, memberName string, statusPods *[]string) error {
+// deleteTeamMemberPods deletes all pods of a team member that is no longer configured
+func (r *VirtSquadReconciler) deleteTeamMemberPods(ctx context.Context, virtSquad *appsv1.VirtSquad, pods []corev1.Pod, statusPods *[]string) error {
 	log := logf.FromContext(ctx)
 
-	// Get existing pods for this team member
-	existingPods := &corev1.PodList{}
-	listOpts := []client.ListOption{
-		client.InNamespace(virtSquad.Namespace),
-		client.MatchingLabels{
-			"app":                          "virtsquad",
-			"virtsquad.mshort55.io/member": memberName,
-			"virtsquad.mshort55.io/squad":  virtSquad.Name,
-		},
-	}
-
-	if err := r.List(ctx, existingPods, listOpts...); err != nil {
-		log.Error(err, "Failed to list existing pods for deletion", "member", memberName)
-		return err
-	}
-
-	// Delete all pods for this member
-	for _, pod := range existingPods.Items {
-		if err := r.Delete(ctx, &pod); err != nil {
-			log.Error(err, "Failed to delete pod", "pod", pod.Name)
+	for i := range pods {
+		if pods[i].DeletionTimestamp != nil {
+			continue
+		}
+		if err := r.deletePod(ctx, virtSquad, &pods[i], deleteReasonMemberRemoved); err != nil {
+			log.Error(err, "Failed to delete pod", "pod", pods[i].Name)
 			return err
 		}
 	}
@@ -275,31 +936,6 @@ func (r *VirtSquadReconciler) deleteTeamMemberPods(ctx context.Context, virtSqua
 	return nil
 }
 
-// countReadyPods counts the number of ready pods managed by this VirtSquad
-func (r *VirtSquadReconciler) countReadyPods(ctx context.Context, virtSquad *appsv1.VirtSquad) (int32, error) {
-	pods := &corev1.PodList{}
-	listOpts := []client.ListOption{
-		client.InNamespace(virtSquad.Namespace),
-		client.MatchingLabels{
-			"app":                         "virtsquad",
-			"virtsquad.mshort55.io/squad": virtSquad.Name,
-		},
-	}
-
-	if err := r.List(ctx, pods, listOpts...); err != nil {
-		return 0, err
-	}
-
-	readyCount := int32(0)
-	for _, pod := range pods.Items {
-		if isPodReady(&pod) {
-			readyCount++
-		}
-	}
-
-	return readyCount, nil
-}
-
 // isPodReady checks if a pod is ready
 func isPodReady(pod *corev1.Pod) bool {
 	for _, condition := range pod.Status.Conditions {
@@ -314,26 +950,27 @@ func isPodReady(pod *corev1.Pod) bool {
 func (r *VirtSquadReconciler) finalizeVirtSquad(ctx context.Context, virtSquad *appsv1.VirtSquad) error {
 	log := logf.FromContext(ctx)
 
-	// Delete all pods managed by this VirtSquad
-	pods := &corev1.PodList{}
-	listOpts := []client.ListOption{
-		client.InNamespace(virtSquad.Namespace),
-		client.MatchingLabels{
-			"app":                         "virtsquad",
-			"virtsquad.mshort55.io/squad": virtSquad.Name,
-		},
+	// Delete the copies of the squad in other namespaces, which no owner reference can cover
+	if err := r.deleteReplicas(ctx, virtSquad); err != nil {
+		return err
 	}
 
-	if err := r.List(ctx, pods, listOpts...); err != nil {
-		log.Error(err, "Failed to list pods for cleanup")
+	// Give the squad's dedicated nodes back to the cluster
+	if err := r.releaseDedicatedNodes(ctx, virtSquad); err != nil {
 		return err
 	}
 
-	for _, pod := range pods.Items {
-		if err := r.Delete(ctx, &pod); err != nil {
+	// Delete all pods managed by this VirtSquad, including quarantined ones
+	err := r.forEachSquadPod(ctx, virtSquad, nil, func(pod *corev1.Pod) error {
+		if err := r.deletePod(ctx, virtSquad, pod, deleteReasonFinalize); err != nil {
 			log.Error(err, "Failed to delete pod during cleanup", "pod", pod.Name)
 			return err
 		}
+		return nil
+	})
+	if err != nil {
+		log.Error(err, "Failed to clean up pods")
+		return err
 	}
 
 	log.Info("Successfully finalized VirtSquad", "virtsquad", virtSquad.Name)
@@ -342,9 +979,62 @@ func (r *VirtSquadReconciler) finalizeVirtSquad(ctx context.Context, virtSquad *
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *VirtSquadReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&appsv1.VirtSquad{}).
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, podOwnerKey, indexPodOwner); err != nil {
+		return err
+	}
+	r.ownerIndexed = true
+	if r.SweepInterval > 0 {
+		// Only the leader sweeps, like it reconciles
+		if err := mgr.Add(manager.RunnableFunc(r.runOrphanSweep)); err != nil {
+			return err
+		}
+	}
+	ownedSquads := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		virtSquad, ok := obj.(*appsv1.VirtSquad)
+		return ok && r.ownsSquad(virtSquad)
+	})
+	blder := ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1.VirtSquad{}, builder.WithPredicates(ownedSquads))
+	if r.UsePriorityQueue {
+		// New and deleted squads jump ahead of the queue
+		blder = blder.WatchesRawSource(source.Kind(mgr.GetCache(), client.Object(&appsv1.VirtSquad{}), prioritizeLifecycle, ownedSquads))
+	}
+	return withJitteredResync(blder, mgr, &appsv1.VirtSquad{}, r.ResyncJitter, ownedSquads).
 		Owns(&corev1.Pod{}).
+		Owns(&corev1.Service{}).
+		Owns(&batchv1.Job{}).
+		// A quota change can free up or take away pods from every squad in the namespace
+		Watches(&appsv1.VirtSquadQuota{}, handler.EnqueueRequestsFromMapFunc(r.squadsInNamespace)).
+		Watches(&appsv1.VirtSquadTemplate{}, handler.EnqueueRequestsFromMapFunc(r.squadsUsingTemplate)).
+		Watches(&appsv1.VirtSquadMemberOverride{}, handler.EnqueueRequestsFromMapFunc(r.squadForOverride)).
+		// Replicas in other namespaces report back to the squad they were copied from
+		Watches(&appsv1.VirtSquad{}, handler.EnqueueRequestsFromMapFunc(r.replicaSource)).
+		// Squads whose pods collide are told when the other squad's spec changes or it goes away
+		Watches(&appsv1.VirtSquad{}, handler.EnqueueRequestsFromMapFunc(r.conflictingSquads),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Squads joining or leaving a namespace decide whether the others may have a namespace quota
+		Watches(&appsv1.VirtSquad{}, handler.EnqueueRequestsFromMapFunc(r.squadsSharingNamespaceQuota),
+			builder.WithPredicates(squadJoinedOrLeft)).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.squadsReplicatedIntoNamespace)).
+		// Cordoning a node ahead of a drain replaces the squad pods running on it
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.squadsOnNode), builder.WithPredicates(nodeCordoned)).
+		// Nodes joining or leaving a dedicated node pool are claimed or released
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.squadsDedicatingNode), builder.WithPredicates(nodeLabelsChanged)).
+		WithOptions(controller.Options{RateLimiter: r.RateLimiter, UsePriorityQueue: ptr.To(r.UsePriorityQueue)}).
 		Named("virtsquad").
 		Complete(r)
 }
+
+// squadsInNamespace returns a reconcile request for every squad in the namespace of obj
+func (r *VirtSquadReconciler) squadsInNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
+	squads := &appsv1.VirtSquadList{}
+	if err := r.List(ctx, squads, client.InNamespace(obj.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list squads", "namespace", obj.GetNamespace())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(squads.Items))
+	for i := range squads.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&squads.Items[i])})
+	}
+	return requests
+}
@@ -21,8 +21,12 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -51,7 +55,9 @@ var _ = Describe("VirtSquad Controller", func() {
 						Name:      resourceName,
 						Namespace: "default",
 					},
-					// TODO(user): Specify other spec details if needed.
+					Spec: appsv1.VirtSquadSpec{
+						Oksana: &appsv1.TeamMemberSpec{Name: ptr.To("oksana-pod"), Replicas: ptr.To(int32(1))},
+					},
 				}
 				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
 			}
@@ -69,8 +75,9 @@ var _ = Describe("VirtSquad Controller", func() {
 		It("should successfully reconcile the resource", func() {
 			By("Reconciling the created resource")
 			controllerReconciler := &VirtSquadReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(10),
 			}
 
 			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
@@ -81,4 +88,179 @@ var _ = Describe("VirtSquad Controller", func() {
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
 	})
+
+	Context("When pods are failing to start", func() {
+		It("should mark the member and squad as degraded", func() {
+			virtSquad := &appsv1.VirtSquad{ObjectMeta: metav1.ObjectMeta{Name: "degraded", Generation: 2}}
+			status := &appsv1.VirtSquadStatus{}
+			member := memberStatus(status, "oksana")
+
+			pods := []corev1.Pod{{
+				ObjectMeta: metav1.ObjectMeta{Name: "oksana-pod-0"},
+				Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name: "oksana",
+						State: corev1.ContainerState{
+							Waiting: &corev1.ContainerStateWaiting{Reason: reasonCrashLoopBackOff},
+						},
+					}},
+				},
+			}}
+
+			Expect(updateMemberDegraded(virtSquad, member, pods)).To(BeTrue())
+			Expect(updateMemberDegraded(virtSquad, member, pods)).To(BeFalse())
+			updateSquadDegraded(virtSquad, status)
+
+			condition := meta.FindStatusCondition(status.Conditions, appsv1.ConditionDegraded)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(reasonCrashLoopBackOff))
+			Expect(condition.Message).To(ContainSubstring("oksana-pod-0"))
+		})
+	})
+
+	Context("When reporting health for GitOps tools", func() {
+		It("should report Ready once all desired pods are ready", func() {
+			virtSquad := &appsv1.VirtSquad{ObjectMeta: metav1.ObjectMeta{Name: "healthy", Generation: 3}}
+			status := &appsv1.VirtSquadStatus{DesiredPods: 2, TotalPods: 2, ReadyPods: 1}
+
+			updateSquadReady(virtSquad, status)
+			Expect(meta.IsStatusConditionFalse(status.Conditions, appsv1.ConditionReady)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(status.Conditions, appsv1.ConditionReconciling)).To(BeTrue())
+
+			status.ReadyPods = 2
+			updateSquadReady(virtSquad, status)
+			Expect(meta.IsStatusConditionTrue(status.Conditions, appsv1.ConditionReady)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(status.Conditions, appsv1.ConditionReconciling)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(status.Conditions, appsv1.ConditionStalled)).To(BeTrue())
+		})
+
+		It("should report Stalled when a member exhausted its failure policy", func() {
+			virtSquad := &appsv1.VirtSquad{ObjectMeta: metav1.ObjectMeta{Name: "stalled", Generation: 3}}
+			status := &appsv1.VirtSquadStatus{DesiredPods: 1, TotalPods: 1}
+			member := memberStatus(status, "oksana")
+			meta.SetStatusCondition(&member.Conditions, metav1.Condition{
+				Type:    appsv1.ConditionFailed,
+				Status:  metav1.ConditionTrue,
+				Reason:  reasonRecreateLimitExceeded,
+				Message: "Pods oksana-pod-0 kept failing",
+			})
+
+			updateSquadReady(virtSquad, status)
+			condition := meta.FindStatusCondition(status.Conditions, appsv1.ConditionStalled)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(reasonRecreateLimitExceeded))
+			Expect(meta.IsStatusConditionFalse(status.Conditions, appsv1.ConditionReconciling)).To(BeTrue())
+		})
+	})
+
+	Context("When recording a squad's history", func() {
+		It("should list the changed spec fields down to team member fields", func() {
+			previous := map[string]any{
+				"oksana": map[string]any{"name": "oksana", "replicas": float64(1)},
+				"matt":   map[string]any{"name": "matt"},
+			}
+			current := map[string]any{
+				"oksana":                 map[string]any{"name": "oksana", "replicas": float64(3)},
+				"terminatedPodRetention": float64(2),
+			}
+
+			Expect(specChanges(previous, current)).To(Equal([]string{"matt", "oksana.replicas", "terminatedPodRetention"}))
+		})
+
+		It("should record alternating repeated events once until the spec changes", func() {
+			action := func(reason string) historyEntry {
+				return historyEntry{Kind: historyAction, Reason: reason, Message: reason + " happened"}
+			}
+			entries := []historyEntry{action("ReplicaCeilingExceeded"), action("InvalidSchedule")}
+			Expect(repeatedAction(entries, action("ReplicaCeilingExceeded"))).To(BeTrue())
+			Expect(repeatedAction(entries, action("InvalidSchedule"))).To(BeTrue())
+			Expect(repeatedAction(entries, action("Scaled"))).To(BeFalse())
+
+			entries = append(entries, historyEntry{Kind: historySpecChange})
+			Expect(repeatedAction(entries, action("InvalidSchedule"))).To(BeFalse())
+
+			entries = []historyEntry{action("Restarted")}
+			for range historyRepeatWindow {
+				entries = append(entries, action("Scaled"))
+			}
+			Expect(repeatedAction(entries, action("Restarted"))).To(BeFalse())
+		})
+	})
+
+	Context("When a team member is renamed", func() {
+		It("should treat pods named after the previous name as outdated", func() {
+			named := func(name string) *corev1.Pod {
+				return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			}
+			Expect(isPodNamedAfter(named("web-0"), "web")).To(BeTrue())
+			Expect(isPodNamedAfter(named("web-api-12"), "web-api")).To(BeTrue())
+			Expect(isPodNamedAfter(named("web-api-12"), "web")).To(BeFalse())
+			Expect(isPodNamedAfter(named("old-0"), "web")).To(BeFalse())
+			Expect(isPodNamedAfter(named("web"), "web")).To(BeFalse())
+		})
+	})
+
+	Context("When pods were created before pods carried a template hash", func() {
+		virtSquad := &appsv1.VirtSquad{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "squad"}}
+		memberSpec := &appsv1.TeamMemberSpec{Name: ptr.To("web"), Image: "nginx:1.27"}
+
+		// running returns a pod created from the spec, as the API server stores it
+		running := func(podSpec corev1.PodSpec) *corev1.Pod {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-0", Labels: map[string]string{memberLabel: "oksana"}},
+				Spec:       *podSpec.DeepCopy(),
+			}
+			pod.Spec.DNSPolicy = corev1.DNSClusterFirst
+			pod.Spec.SecurityContext = &corev1.PodSecurityContext{}
+			pod.Spec.Tolerations = append(pod.Spec.Tolerations, corev1.Toleration{
+				Key: corev1.TaintNodeNotReady, Operator: corev1.TolerationOpExists,
+				Effect: corev1.TaintEffectNoExecute, TolerationSeconds: ptr.To(int64(300)),
+			})
+			pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{Name: "kube-api-access"})
+			for i := range pod.Spec.Containers {
+				container := &pod.Spec.Containers[i]
+				container.ImagePullPolicy = corev1.PullIfNotPresent
+				container.TerminationMessagePath = corev1.TerminationMessagePathDefault
+				container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: "kube-api-access"})
+				for p := range container.Ports {
+					container.Ports[p].Protocol = corev1.ProtocolTCP
+				}
+			}
+			return pod
+		}
+
+		It("should match pods running the template despite the API server's defaults", func() {
+			podSpec := memberPodSpec(virtSquad, "oksana", memberSpec)
+			Expect(podMatchesTemplate(running(podSpec), &podSpec)).To(BeTrue())
+
+			changed := memberPodSpec(virtSquad, "oksana", &appsv1.TeamMemberSpec{Name: ptr.To("web"), Image: "nginx:1.28"})
+			Expect(podMatchesTemplate(running(podSpec), &changed)).To(BeFalse())
+
+			changed = memberPodSpec(virtSquad, "oksana", &appsv1.TeamMemberSpec{Name: ptr.To("web"), Image: "nginx:1.27", Args: []string{"-g"}})
+			Expect(podMatchesTemplate(running(podSpec), &changed)).To(BeFalse())
+		})
+
+		It("should label matching pods with the current hash and leave the others outdated", func() {
+			podSpec := memberPodSpec(virtSquad, "oksana", memberSpec)
+			matching := running(podSpec)
+			outdated := running(memberPodSpec(virtSquad, "oksana", &appsv1.TeamMemberSpec{Name: ptr.To("web")}))
+			outdated.Name = "web-1"
+			labeled := running(podSpec)
+			labeled.Name = "web-2"
+			labeled.Labels[templateHashLabel] = "older"
+
+			r, c := newFakeReconciler(matching, outdated, labeled)
+			pods := []corev1.Pod{*matching, *outdated, *labeled}
+			Expect(r.adoptUnlabeledPods(context.Background(), pods, &podSpec, "current")).To(Succeed())
+			Expect(isPodUpdated(&pods[0], "current")).To(BeTrue())
+			Expect(isPodUpdated(&pods[1], "current")).To(BeFalse())
+			Expect(pods[2].Labels[templateHashLabel]).To(Equal("older"))
+
+			stored := &corev1.Pod{}
+			Expect(c.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "web-0"}, stored)).To(Succeed())
+			Expect(stored.Labels[templateHashLabel]).To(Equal("current"))
+		})
+	})
 })
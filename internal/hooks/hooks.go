@@ -0,0 +1,83 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hooks invokes the external HTTP callbacks configured on a VirtSquad.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// PreDeletePayload is the JSON body sent to a preDelete hook before a pod is deleted
+type PreDeletePayload struct {
+	Squad     string            `json:"squad"`
+	Namespace string            `json:"namespace"`
+	Member    string            `json:"member"`
+	Pod       string            `json:"pod"`
+	PodIP     string            `json:"podIP,omitempty"`
+	NodeName  string            `json:"nodeName,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Reason    string            `json:"reason"`
+}
+
+// Client posts hook payloads to their configured endpoints
+type Client struct {
+	HTTPClient *http.Client
+}
+
+// NewClient returns a Client using a dedicated HTTP client
+func NewClient() *Client {
+	return &Client{HTTPClient: &http.Client{}}
+}
+
+// Post sends payload as JSON to url, authenticating with token as a bearer token when it is
+// set. The call fails if the endpoint does not answer with a 2xx status within timeout.
+func (c *Client) Post(ctx context.Context, url, token string, timeout time.Duration, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding hook payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("building hook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling hook %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("hook %s returned status %d", url, resp.StatusCode)
+	}
+	return nil
+}
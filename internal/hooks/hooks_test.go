@@ -0,0 +1,56 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Client", func() {
+	It("should post the payload with the bearer token", func() {
+		var received PreDeletePayload
+		var authorization string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			authorization = req.Header.Get("Authorization")
+			Expect(json.NewDecoder(req.Body).Decode(&received)).To(Succeed())
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		payload := PreDeletePayload{Squad: "squad", Namespace: "default", Member: "oksana", Pod: "oksana-pod-0", Reason: "ScaleDown"}
+		Expect(NewClient().Post(context.Background(), server.URL, "secret", time.Second, payload)).To(Succeed())
+		Expect(authorization).To(Equal("Bearer secret"))
+		Expect(received).To(Equal(payload))
+	})
+
+	It("should fail when the endpoint does not return a 2xx status", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		err := NewClient().Post(context.Background(), server.URL, "", time.Second, PreDeletePayload{})
+		Expect(err).To(MatchError(ContainSubstring("503")))
+	})
+})
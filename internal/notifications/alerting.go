@@ -0,0 +1,153 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+const (
+	// defaultPagerDutyURL is the PagerDuty Events API v2 endpoint
+	defaultPagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+	// defaultOpsgenieURL is the base URL of the Opsgenie Alert API
+	defaultOpsgenieURL = "https://api.opsgenie.com/v2/alerts"
+)
+
+// Alert describes an incident about a degraded squad
+type Alert struct {
+	Squad     string
+	Namespace string
+	Summary   string
+}
+
+// DedupKey identifies the squad's incident, so repeated triggers update the same incident
+func (a Alert) DedupKey() string {
+	return fmt.Sprintf("virtsquad/%s/%s", a.Namespace, a.Squad)
+}
+
+// pagerDutyEvent is the body accepted by the PagerDuty Events API v2
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+// pagerDutyPayload describes the incident opened by a PagerDuty trigger event
+type pagerDutyPayload struct {
+	Summary  string `json:"summary"`
+	Source   string `json:"source"`
+	Severity string `json:"severity"`
+}
+
+// opsgenieAlert is the body accepted when creating an Opsgenie alert
+type opsgenieAlert struct {
+	Message     string `json:"message"`
+	Alias       string `json:"alias"`
+	Description string `json:"description"`
+	Source      string `json:"source"`
+}
+
+// TriggerPagerDuty opens, or updates, the squad's PagerDuty incident
+func (c *Client) TriggerPagerDuty(ctx context.Context, routingKey string, alert Alert) error {
+	return c.postAlert(ctx, c.pagerDutyURL(), "", pagerDutyEvent{
+		RoutingKey:  routingKey,
+		EventAction: "trigger",
+		DedupKey:    alert.DedupKey(),
+		Payload: &pagerDutyPayload{
+			Summary:  alert.Summary,
+			Source:   alert.DedupKey(),
+			Severity: "error",
+		},
+	})
+}
+
+// ResolvePagerDuty resolves the squad's PagerDuty incident
+func (c *Client) ResolvePagerDuty(ctx context.Context, routingKey string, alert Alert) error {
+	return c.postAlert(ctx, c.pagerDutyURL(), "", pagerDutyEvent{
+		RoutingKey:  routingKey,
+		EventAction: "resolve",
+		DedupKey:    alert.DedupKey(),
+	})
+}
+
+// TriggerOpsgenie opens the squad's Opsgenie alert; Opsgenie deduplicates open alerts by alias
+func (c *Client) TriggerOpsgenie(ctx context.Context, apiKey string, alert Alert) error {
+	return c.postAlert(ctx, c.opsgenieURL(), "GenieKey "+apiKey, opsgenieAlert{
+		Message:     fmt.Sprintf("VirtSquad %s/%s is degraded", alert.Namespace, alert.Squad),
+		Alias:       alert.DedupKey(),
+		Description: alert.Summary,
+		Source:      "virtsquad-operator",
+	})
+}
+
+// ResolveOpsgenie closes the squad's Opsgenie alert
+func (c *Client) ResolveOpsgenie(ctx context.Context, apiKey string, alert Alert) error {
+	endpoint := fmt.Sprintf("%s/%s/close?identifierType=alias", c.opsgenieURL(), url.PathEscape(alert.DedupKey()))
+	return c.postAlert(ctx, endpoint, "GenieKey "+apiKey, map[string]string{"source": "virtsquad-operator"})
+}
+
+// pagerDutyURL returns the PagerDuty endpoint, which tests may override
+func (c *Client) pagerDutyURL() string {
+	if c.PagerDutyURL != "" {
+		return c.PagerDutyURL
+	}
+	return defaultPagerDutyURL
+}
+
+// opsgenieURL returns the Opsgenie endpoint, which tests may override
+func (c *Client) opsgenieURL() string {
+	if c.OpsgenieURL != "" {
+		return c.OpsgenieURL
+	}
+	return defaultOpsgenieURL
+}
+
+// postAlert posts body as JSON to an alerting API
+func (c *Client) postAlert(ctx context.Context, endpoint, authorization string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("encoding alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("building alert request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling alerting API: %w", redactURL(err))
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("alerting API returned status %d", resp.StatusCode)
+	}
+	return nil
+}
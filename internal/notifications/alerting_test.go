@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Alerting", func() {
+	alert := Alert{Squad: "squad", Namespace: "default", Summary: "oksana: CrashLoopBackOff"}
+
+	It("should trigger and resolve PagerDuty incidents under the same dedup key", func() {
+		var events []pagerDutyEvent
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var event pagerDutyEvent
+			Expect(json.NewDecoder(req.Body).Decode(&event)).To(Succeed())
+			events = append(events, event)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		client := NewClient()
+		client.PagerDutyURL = server.URL
+		Expect(client.TriggerPagerDuty(context.Background(), "routing-key", alert)).To(Succeed())
+		Expect(client.ResolvePagerDuty(context.Background(), "routing-key", alert)).To(Succeed())
+
+		Expect(events).To(HaveLen(2))
+		Expect(events[0].EventAction).To(Equal("trigger"))
+		Expect(events[0].RoutingKey).To(Equal("routing-key"))
+		Expect(events[0].Payload.Summary).To(Equal(alert.Summary))
+		Expect(events[1].EventAction).To(Equal("resolve"))
+		Expect(events[1].DedupKey).To(Equal(events[0].DedupKey))
+	})
+
+	It("should close Opsgenie alerts by alias with the API key", func() {
+		var path, query, authorization string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			path, query = req.URL.EscapedPath(), req.URL.RawQuery
+			authorization = req.Header.Get("Authorization")
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		client := NewClient()
+		client.OpsgenieURL = server.URL + "/v2/alerts"
+		Expect(client.ResolveOpsgenie(context.Background(), "api-key", alert)).To(Succeed())
+		Expect(path).To(Equal("/v2/alerts/virtsquad%2Fdefault%2Fsquad/close"))
+		Expect(query).To(Equal("identifierType=alias"))
+		Expect(authorization).To(Equal("GenieKey api-key"))
+	})
+})
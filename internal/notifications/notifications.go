@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package notifications delivers notable VirtSquad events to external chat and alerting systems.
+package notifications
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// EventType identifies what happened to a squad
+type EventType string
+
+const (
+	// EventDegraded is sent when a squad becomes Degraded
+	EventDegraded EventType = "Degraded"
+	// EventRolloutComplete is sent when a team member finishes rolling out a new revision
+	EventRolloutComplete EventType = "RolloutComplete"
+	// EventDeleted is sent once a deleted squad has been finalized
+	EventDeleted EventType = "Deleted"
+)
+
+// Notification describes a squad event to deliver
+type Notification struct {
+	Squad     string    `json:"squad"`
+	Namespace string    `json:"namespace"`
+	Type      EventType `json:"type"`
+	Message   string    `json:"message"`
+}
+
+// Summary returns a one-line human readable description of the notification
+func (n Notification) Summary() string {
+	return fmt.Sprintf("VirtSquad %s/%s %s: %s", n.Namespace, n.Squad, n.Type, n.Message)
+}
+
+// Client sends notifications to their sinks
+type Client struct {
+	HTTPClient *http.Client
+
+	// PagerDutyURL and OpsgenieURL override the alerting API endpoints when set
+	PagerDutyURL string
+	OpsgenieURL  string
+}
+
+// NewClient returns a Client using a dedicated HTTP client that gives up on slow sinks
+func NewClient() *Client {
+	return &Client{HTTPClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// redactURL strips the request URL from HTTP client errors, since sink URLs often embed secrets
+func redactURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
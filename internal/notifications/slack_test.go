@@ -0,0 +1,49 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Slack", func() {
+	notification := Notification{Squad: "squad", Namespace: "default", Type: EventDegraded, Message: "oksana: CrashLoopBackOff"}
+
+	It("should post the notification summary as the message text", func() {
+		var received slackMessage
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			Expect(json.NewDecoder(req.Body).Decode(&received)).To(Succeed())
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		Expect(NewClient().PostSlack(context.Background(), server.URL, notification)).To(Succeed())
+		Expect(received.Text).To(Equal("VirtSquad default/squad Degraded: oksana: CrashLoopBackOff"))
+	})
+
+	It("should keep the webhook URL out of errors", func() {
+		err := NewClient().PostSlack(context.Background(), "http://127.0.0.1:1/services/T000/B000/secret", notification)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).NotTo(ContainSubstring("secret"))
+	})
+})
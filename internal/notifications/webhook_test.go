@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Webhook", func() {
+	It("should post the transition with the configured headers", func() {
+		var received ConditionTransition
+		var authorization string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			authorization = req.Header.Get("Authorization")
+			Expect(json.NewDecoder(req.Body).Decode(&received)).To(Succeed())
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		transition := ConditionTransition{Squad: "squad", Namespace: "default", Condition: "Ready", Status: "False", PreviousStatus: "True"}
+		headers := map[string]string{"Authorization": "Token secret"}
+		Expect(NewClient().PostWebhook(context.Background(), server.URL, headers, transition)).To(Succeed())
+		Expect(authorization).To(Equal("Token secret"))
+		Expect(received.Condition).To(Equal("Ready"))
+		Expect(received.PreviousStatus).To(Equal("True"))
+	})
+
+	It("should fail when the endpoint does not return a 2xx status", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		err := NewClient().PostWebhook(context.Background(), server.URL, nil, ConditionTransition{})
+		Expect(err).To(MatchError(ContainSubstring("502")))
+	})
+
+	It("should keep the URL out of errors", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+		url := server.URL + "/hooks/secret-token"
+		server.Close()
+
+		err := NewClient().PostWebhook(context.Background(), url, nil, ConditionTransition{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).NotTo(ContainSubstring("secret-token"))
+	})
+})
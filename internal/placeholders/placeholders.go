@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package placeholders resolves the Go template placeholders, such as {{ .Squad.Name }}, that
+// team members may use in their env values, args and pod annotations.
+package placeholders
+
+import (
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Values are the data placeholders are resolved against
+type Values struct {
+	// Squad is the VirtSquad the pod belongs to
+	Squad Squad
+	// Member is the team member the pod belongs to
+	Member Member
+	// Ordinal is the ordinal suffix of the pod's name
+	Ordinal int
+}
+
+// Squad identifies the VirtSquad a pod belongs to
+type Squad struct {
+	Name      string
+	Namespace string
+}
+
+// Member identifies the team member a pod belongs to
+type Member struct {
+	// Name is the member's key in the squad's spec, such as oksana
+	Name string
+}
+
+// Expand resolves the placeholders in text. Text without placeholders is returned unchanged.
+func Expand(text string, values Values) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	tmpl, err := template.New("").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, values); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// Validate reports whether text has placeholders that do not parse or refer to unknown values
+func Validate(text string) error {
+	_, err := Expand(text, Values{})
+	return err
+}
+
+// ExpandPodSpec resolves the placeholders in the env values and args of the pod's containers
+func ExpandPodSpec(podSpec *corev1.PodSpec, values Values) error {
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+		for j := range container.Env {
+			if container.Env[j].ValueFrom != nil {
+				continue
+			}
+			value, err := Expand(container.Env[j].Value, values)
+			if err != nil {
+				return err
+			}
+			container.Env[j].Value = value
+		}
+		for j := range container.Args {
+			arg, err := Expand(container.Args[j], values)
+			if err != nil {
+				return err
+			}
+			container.Args[j] = arg
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placeholders
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Placeholders", func() {
+	values := Values{Squad: Squad{Name: "alpha", Namespace: "team"}, Member: Member{Name: "oksana"}, Ordinal: 2}
+
+	It("should resolve the squad, member and ordinal", func() {
+		Expect(Expand("{{ .Squad.Name }}-{{ .Member.Name }}-{{ .Ordinal }}", values)).To(Equal("alpha-oksana-2"))
+		Expect(Expand("plain", values)).To(Equal("plain"))
+	})
+
+	It("should reject placeholders that do not parse or refer to unknown values", func() {
+		Expect(Validate("{{ .Squad.Name")).NotTo(Succeed())
+		Expect(Validate("{{ .Replica }}")).NotTo(Succeed())
+		Expect(Validate("{{ .Squad.Namespace }}")).To(Succeed())
+	})
+
+	It("should resolve env values and args but leave env sources alone", func() {
+		podSpec := corev1.PodSpec{Containers: []corev1.Container{{
+			Args: []string{"--id={{ .Member.Name }}-{{ .Ordinal }}"},
+			Env: []corev1.EnvVar{
+				{Name: "PEER", Value: "{{ .Squad.Name }}-kurtis"},
+				{Name: "NODE", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"}}},
+			},
+		}}}
+		Expect(ExpandPodSpec(&podSpec, values)).To(Succeed())
+		Expect(podSpec.Containers[0].Args).To(ConsistOf("--id=oksana-2"))
+		Expect(podSpec.Containers[0].Env[0].Value).To(Equal("alpha-kurtis"))
+		Expect(podSpec.Containers[0].Env[1].ValueFrom).NotTo(BeNil())
+	})
+})
@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package quota evaluates the VirtSquadQuotas that limit the VirtSquads of a namespace, and
+// what the squads' pods request from the namespace's ResourceQuotas.
+package quota
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+// Limits are the strictest limits set by a namespace's VirtSquadQuotas; nil means no limit
+type Limits struct {
+	// MaxSquads is the most VirtSquads the namespace may hold
+	MaxSquads *int32
+	// MaxPods is the most pods the namespace's VirtSquads may run together
+	MaxPods *int32
+	// Quota names the quota whose pod limit applies, for messages
+	Quota string
+}
+
+// ForNamespace returns the limits set by the VirtSquadQuotas of a namespace
+func ForNamespace(ctx context.Context, c client.Reader, namespace string) (Limits, error) {
+	quotas := &appsv1.VirtSquadQuotaList{}
+	if err := c.List(ctx, quotas, client.InNamespace(namespace)); err != nil {
+		return Limits{}, err
+	}
+
+	var limits Limits
+	for i := range quotas.Items {
+		spec := quotas.Items[i].Spec
+		if spec.MaxSquads != nil && (limits.MaxSquads == nil || *spec.MaxSquads < *limits.MaxSquads) {
+			limits.MaxSquads = spec.MaxSquads
+		}
+		if spec.MaxPods != nil && (limits.MaxPods == nil || *spec.MaxPods < *limits.MaxPods) {
+			limits.MaxPods = spec.MaxPods
+			limits.Quota = quotas.Items[i].Name
+		}
+	}
+	return limits, nil
+}
+
+// RequestedPods returns the number of pods a squad's team members ask for in its spec,
+// standby pods included
+func RequestedPods(spec *appsv1.VirtSquadSpec) int32 {
+	var total int32
+	for _, member := range []*appsv1.TeamMemberSpec{spec.Oksana, spec.Kurtis, spec.Matt, spec.Kike} {
+		total += MemberPods(member)
+	}
+	return total
+}
+
+// MemberPods returns the number of pods a team member asks for in its spec, standby pods included
+func MemberPods(member *appsv1.TeamMemberSpec) int32 {
+	if member == nil {
+		return 0
+	}
+	replicas := int32(1)
+	if member.Replicas != nil {
+		replicas = *member.Replicas
+	}
+	if member.StandbyReplicas != nil {
+		replicas += *member.StandbyReplicas
+	}
+	return replicas
+}
+
+// PodOverhead returns the fixed overhead the member's RuntimeClass adds to each of its pods, as
+// charged by the scheduler and ResourceQuotas on top of the containers' requests. It returns nil
+// when the member sets no RuntimeClass or the RuntimeClass declares no overhead or does not exist.
+func PodOverhead(ctx context.Context, c client.Reader, member *appsv1.TeamMemberSpec) (corev1.ResourceList, error) {
+	if member == nil || member.RuntimeClassName == nil || *member.RuntimeClassName == "" {
+		return nil, nil
+	}
+	runtimeClass := &nodev1.RuntimeClass{}
+	if err := c.Get(ctx, types.NamespacedName{Name: *member.RuntimeClassName}, runtimeClass); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if runtimeClass.Overhead == nil {
+		return nil, nil
+	}
+	return runtimeClass.Overhead.PodFixed, nil
+}
+
+// PodRequests returns the CPU and memory one of the member's pods requests: what its container
+// requests, falling back to the limits as the API server does, plus the pod overhead
+func PodRequests(member *appsv1.TeamMemberSpec, overhead corev1.ResourceList) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		var quantity resource.Quantity
+		if member.Resources != nil {
+			if q, ok := member.Resources.Requests[name]; ok {
+				quantity = q.DeepCopy()
+			} else if q, ok := member.Resources.Limits[name]; ok {
+				quantity = q.DeepCopy()
+			}
+		}
+		if q, ok := overhead[name]; ok {
+			quantity.Add(q)
+		}
+		if !quantity.IsZero() {
+			requests[name] = quantity
+		}
+	}
+	return requests
+}
+
+// Admitted reports whether a squad is among the oldest maxSquads squads of its namespace, the
+// ones allowed to run. Squads are ordered by creation time and then by name.
+func Admitted(squads []appsv1.VirtSquad, name string, maxSquads int32) bool {
+	sorted := append([]appsv1.VirtSquad{}, squads...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].CreationTimestamp.Equal(&sorted[j].CreationTimestamp) {
+			return sorted[i].CreationTimestamp.Before(&sorted[j].CreationTimestamp)
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	for i := range sorted {
+		if sorted[i].Name == name {
+			return int32(i) < maxSquads
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+var _ = Describe("Quota", func() {
+	It("should apply the strictest limits of the namespace's quotas", func() {
+		scheme := runtime.NewScheme()
+		Expect(appsv1.AddToScheme(scheme)).To(Succeed())
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&appsv1.VirtSquadQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "loose", Namespace: "team"},
+				Spec:       appsv1.VirtSquadQuotaSpec{MaxSquads: ptr.To[int32](2), MaxPods: ptr.To[int32](50)},
+			},
+			&appsv1.VirtSquadQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "strict", Namespace: "team"},
+				Spec:       appsv1.VirtSquadQuotaSpec{MaxPods: ptr.To[int32](10)},
+			},
+			&appsv1.VirtSquadQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "elsewhere", Namespace: "other"},
+				Spec:       appsv1.VirtSquadQuotaSpec{MaxSquads: ptr.To[int32](1)},
+			},
+		).Build()
+
+		limits, err := ForNamespace(context.Background(), c, "team")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(limits.MaxSquads).To(HaveValue(BeEquivalentTo(2)))
+		Expect(limits.MaxPods).To(HaveValue(BeEquivalentTo(10)))
+		Expect(limits.Quota).To(Equal("strict"))
+	})
+
+	It("should count standby pods and default replicas", func() {
+		spec := appsv1.VirtSquadSpec{
+			Oksana: &appsv1.TeamMemberSpec{Replicas: ptr.To[int32](3), StandbyReplicas: ptr.To[int32](1)},
+			Kurtis: &appsv1.TeamMemberSpec{},
+		}
+		Expect(RequestedPods(&spec)).To(BeEquivalentTo(5))
+	})
+
+	It("should add the RuntimeClass overhead to the pods' requests", func() {
+		scheme := runtime.NewScheme()
+		Expect(nodev1.AddToScheme(scheme)).To(Succeed())
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&nodev1.RuntimeClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "kata"},
+			Handler:    "kata",
+			Overhead: &nodev1.Overhead{PodFixed: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("250m"),
+				corev1.ResourceMemory: resource.MustParse("160Mi"),
+			}},
+		}).Build()
+		member := &appsv1.TeamMemberSpec{
+			RuntimeClassName: ptr.To("kata"),
+			Resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+			},
+		}
+
+		overhead, err := PodOverhead(context.Background(), c, member)
+		Expect(err).NotTo(HaveOccurred())
+		requests := PodRequests(member, overhead)
+		Expect(requests.Cpu().String()).To(Equal("750m"))
+		Expect(requests.Memory().String()).To(Equal("416Mi"))
+
+		member.RuntimeClassName = ptr.To("missing")
+		Expect(PodOverhead(context.Background(), c, member)).To(BeNil())
+	})
+
+	It("should admit the oldest squads", func() {
+		now := time.Now()
+		squad := func(name string, age time.Duration) appsv1.VirtSquad {
+			return appsv1.VirtSquad{ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(now.Add(-age))}}
+		}
+		squads := []appsv1.VirtSquad{squad("newest", time.Minute), squad("oldest", time.Hour), squad("middle", 10*time.Minute)}
+		Expect(Admitted(squads, "oldest", 2)).To(BeTrue())
+		Expect(Admitted(squads, "middle", 2)).To(BeTrue())
+		Expect(Admitted(squads, "newest", 2)).To(BeFalse())
+	})
+})
@@ -0,0 +1,324 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package registry looks up the platforms container images are built for in their registries.
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultRegistry is the host images without a registry are pulled from
+	defaultRegistry = "registry-1.docker.io"
+
+	mediaTypeOCIIndex       = "application/vnd.oci.image.index.v1+json"
+	mediaTypeDockerList     = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeOCIManifest    = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+	manifestAccept          = mediaTypeOCIIndex + ", " + mediaTypeDockerList + ", " + mediaTypeOCIManifest + ", " + mediaTypeDockerManifest
+)
+
+// Reference is a parsed image reference
+type Reference struct {
+	// Registry is the registry host, such as registry-1.docker.io
+	Registry string
+	// Repository is the repository within the registry, such as library/nginx
+	Repository string
+	// Reference is the tag or digest of the image
+	Reference string
+}
+
+// ParseReference parses an image reference such as nginx, ghcr.io/org/app:v1 or
+// app@sha256:..., applying the same defaults as the container runtime
+func ParseReference(image string) (Reference, error) {
+	if image == "" {
+		return Reference{}, fmt.Errorf("empty image reference")
+	}
+	name, ref := image, "latest"
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, ref = name[:i], name[i+1:]
+	} else if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, ref = name[:i], name[i+1:]
+	}
+
+	registry := defaultRegistry
+	if first, rest, found := strings.Cut(name, "/"); found &&
+		(strings.ContainsAny(first, ".:") || first == "localhost") {
+		registry, name = first, rest
+	}
+	if registry == "docker.io" || registry == "index.docker.io" {
+		registry = defaultRegistry
+	}
+	if registry == defaultRegistry && !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+	if name == "" || ref == "" {
+		return Reference{}, fmt.Errorf("invalid image reference %q", image)
+	}
+	return Reference{Registry: registry, Repository: name, Reference: ref}, nil
+}
+
+// manifest holds the fields of an image manifest or index needed to tell its platforms
+type manifest struct {
+	MediaType string `json:"mediaType"`
+	Manifests []struct {
+		Platform *platform `json:"platform"`
+	} `json:"manifests"`
+	Config *struct {
+		Digest string `json:"digest"`
+	} `json:"config"`
+}
+
+// platform is the platform of an image, as found in indexes and image configs
+type platform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+}
+
+// maxCacheEntries bounds the number of images the client remembers
+const maxCacheEntries = 1000
+
+// cacheEntry holds the architectures of an image, or why they could not be looked up, at a
+// point in time
+type cacheEntry struct {
+	architectures []string
+	err           error
+	fetched       time.Time
+}
+
+// Client looks up images anonymously and caches what it found for TTL. Failed lookups are
+// cached for ErrorTTL, so an unreachable registry is not asked again on every reconcile.
+type Client struct {
+	HTTPClient *http.Client
+	TTL        time.Duration
+	ErrorTTL   time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+// NewClient returns a Client using a dedicated HTTP client that caches lookups for an hour,
+// and failed lookups for a minute
+func NewClient() *Client {
+	return &Client{HTTPClient: &http.Client{Timeout: 30 * time.Second}, TTL: time.Hour, ErrorTTL: time.Minute}
+}
+
+// Architectures returns the sorted CPU architectures the image is built for, such as amd64
+// and arm64. Only public images can be looked up, since no pull secrets are used.
+func (c *Client) Architectures(ctx context.Context, image string) ([]string, error) {
+	c.mu.Lock()
+	entry, ok := c.cache[image]
+	c.mu.Unlock()
+	if ok && entry.err == nil && time.Since(entry.fetched) < c.TTL {
+		return entry.architectures, nil
+	}
+	if ok && entry.err != nil && time.Since(entry.fetched) < c.ErrorTTL {
+		return nil, entry.err
+	}
+
+	ref, err := ParseReference(image)
+	if err != nil {
+		return nil, err
+	}
+	architectures, err := c.architectures(ctx, ref)
+	// A lookup cut short by the caller says nothing about the registry
+	if err != nil && ctx.Err() != nil {
+		return nil, err
+	}
+	c.remember(image, cacheEntry{architectures: architectures, err: err, fetched: time.Now()})
+	return architectures, err
+}
+
+// remember caches the lookup of an image. When the cache is full, expired entries are dropped
+// first, then the oldest one.
+func (c *Client) remember(image string, entry cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cache == nil {
+		c.cache = map[string]cacheEntry{}
+	}
+	if _, ok := c.cache[image]; !ok && len(c.cache) >= maxCacheEntries {
+		oldest := ""
+		for other, cached := range c.cache {
+			ttl := c.TTL
+			if cached.err != nil {
+				ttl = c.ErrorTTL
+			}
+			if time.Since(cached.fetched) >= ttl {
+				delete(c.cache, other)
+			} else if oldest == "" || cached.fetched.Before(c.cache[oldest].fetched) {
+				oldest = other
+			}
+		}
+		if len(c.cache) >= maxCacheEntries {
+			delete(c.cache, oldest)
+		}
+	}
+	c.cache[image] = entry
+}
+
+// architectures reads the image's index, or the config of a single-platform image
+func (c *Client) architectures(ctx context.Context, ref Reference) ([]string, error) {
+	var m manifest
+	if err := c.getJSON(ctx, ref, "manifests/"+ref.Reference, manifestAccept, &m); err != nil {
+		return nil, err
+	}
+
+	var architectures []string
+	switch {
+	case len(m.Manifests) > 0:
+		for _, entry := range m.Manifests {
+			// Attestation manifests are listed with an unknown platform
+			if entry.Platform != nil && entry.Platform.Architecture != "" && entry.Platform.Architecture != "unknown" {
+				architectures = append(architectures, entry.Platform.Architecture)
+			}
+		}
+	case m.Config != nil && m.Config.Digest != "":
+		var config platform
+		if err := c.getJSON(ctx, ref, "blobs/"+m.Config.Digest, "*/*", &config); err != nil {
+			return nil, err
+		}
+		if config.Architecture != "" {
+			architectures = append(architectures, config.Architecture)
+		}
+	default:
+		return nil, fmt.Errorf("image %s/%s:%s has an unsupported manifest type %q", ref.Registry, ref.Repository, ref.Reference, m.MediaType)
+	}
+	slices.Sort(architectures)
+	return slices.Compact(architectures), nil
+}
+
+// getJSON fetches and decodes a path below the repository, requesting an anonymous bearer
+// token when the registry asks for one
+func (c *Client) getJSON(ctx context.Context, ref Reference, path, accept string, into any) error {
+	endpoint := fmt.Sprintf("https://%s/v2/%s/%s", ref.Registry, ref.Repository, path)
+	resp, err := c.get(ctx, endpoint, accept, "")
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		_ = resp.Body.Close()
+		token, err := c.token(ctx, challenge)
+		if err != nil {
+			return err
+		}
+		if resp, err = c.get(ctx, endpoint, accept, token); err != nil {
+			return err
+		}
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return fmt.Errorf("registry %s returned status %d for %s", ref.Registry, resp.StatusCode, path)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(into); err != nil {
+		return fmt.Errorf("decoding %s from registry %s: %w", path, ref.Registry, err)
+	}
+	return nil
+}
+
+// get sends a GET request, authenticated with token when it is set
+func (c *Client) get(ctx context.Context, endpoint, accept, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building registry request: %w", err)
+	}
+	req.Header.Set("Accept", accept)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calling registry: %w", err)
+	}
+	return resp, nil
+}
+
+// token requests an anonymous token from the realm named by a Bearer challenge
+func (c *Client) token(ctx context.Context, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("registry requires unsupported authentication %q", scheme)
+	}
+
+	values := url.Values{}
+	realm := ""
+	for _, param := range splitChallenge(params) {
+		key, value, _ := strings.Cut(param, "=")
+		value = strings.Trim(value, `"`)
+		switch key = strings.TrimSpace(key); key {
+		case "realm":
+			realm = value
+		case "service", "scope":
+			values.Set(key, value)
+		}
+	}
+	if realm == "" {
+		return "", fmt.Errorf("registry authentication challenge names no realm")
+	}
+
+	resp, err := c.get(ctx, realm+"?"+values.Encode(), "application/json", "")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return "", fmt.Errorf("registry token endpoint returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decoding registry token: %w", err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+// splitChallenge splits challenge parameters on the commas outside of quoted values, since
+// scopes can contain commas themselves
+func splitChallenge(params string) []string {
+	var parts []string
+	quoted := false
+	start := 0
+	for i, ch := range params {
+		switch {
+		case ch == '"':
+			quoted = !quoted
+		case ch == ',' && !quoted:
+			parts = append(parts, params[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, params[start:])
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseReference", func() {
+	DescribeTable("should apply the runtime defaults",
+		func(image string, expected Reference) {
+			Expect(ParseReference(image)).To(Equal(expected))
+		},
+		Entry("official image", "nginx", Reference{Registry: defaultRegistry, Repository: "library/nginx", Reference: "latest"}),
+		Entry("Docker Hub user image", "org/app:v1", Reference{Registry: defaultRegistry, Repository: "org/app", Reference: "v1"}),
+		Entry("registry with port", "localhost:5000/app", Reference{Registry: "localhost:5000", Repository: "app", Reference: "latest"}),
+		Entry("digest", "ghcr.io/org/app@sha256:abc", Reference{Registry: "ghcr.io", Repository: "org/app", Reference: "sha256:abc"}),
+	)
+})
+
+var _ = Describe("Client", func() {
+	var server *httptest.Server
+	var client *Client
+	var image string
+
+	BeforeEach(func() {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/token", func(w http.ResponseWriter, req *http.Request) {
+			Expect(req.URL.Query().Get("scope")).To(Equal("repository:multi:pull"))
+			_, _ = w.Write([]byte(`{"token":"anonymous"}`))
+		})
+		mux.HandleFunc("/v2/multi/manifests/v1", func(w http.ResponseWriter, req *http.Request) {
+			if req.Header.Get("Authorization") != "Bearer anonymous" {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="`+server.URL+`/token",service="test",scope="repository:multi:pull"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"mediaType":"` + mediaTypeOCIIndex + `","manifests":[
+				{"platform":{"architecture":"arm64","os":"linux"}},
+				{"platform":{"architecture":"amd64","os":"linux"}},
+				{"platform":{"architecture":"unknown","os":"unknown"}}]}`))
+		})
+		mux.HandleFunc("/v2/single/manifests/v1", func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"mediaType":"` + mediaTypeDockerManifest + `","config":{"digest":"sha256:config"}}`))
+		})
+		mux.HandleFunc("/v2/single/blobs/sha256:config", func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"architecture":"amd64","os":"linux"}`))
+		})
+		server = httptest.NewTLSServer(mux)
+		client = &Client{HTTPClient: server.Client(), TTL: time.Hour, ErrorTTL: time.Minute}
+		image = strings.TrimPrefix(server.URL, "https://")
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should list the architectures of an image index after authenticating", func() {
+		Expect(client.Architectures(context.Background(), image+"/multi:v1")).To(Equal([]string{"amd64", "arm64"}))
+	})
+
+	It("should read the architecture of a single-platform image from its config", func() {
+		Expect(client.Architectures(context.Background(), image+"/single:v1")).To(Equal([]string{"amd64"}))
+	})
+
+	It("should cache lookups", func() {
+		Expect(client.Architectures(context.Background(), image+"/single:v1")).To(Equal([]string{"amd64"}))
+		server.Close()
+		Expect(client.Architectures(context.Background(), image+"/single:v1")).To(Equal([]string{"amd64"}))
+	})
+
+	It("should fail for missing images", func() {
+		_, err := client.Architectures(context.Background(), image+"/missing:v1")
+		Expect(err).To(MatchError(ContainSubstring("404")))
+	})
+
+	It("should cache failed lookups for a shorter time", func() {
+		_, err := client.Architectures(context.Background(), image+"/missing:v1")
+		Expect(err).To(MatchError(ContainSubstring("404")))
+		server.Close()
+		_, err = client.Architectures(context.Background(), image+"/missing:v1")
+		Expect(err).To(MatchError(ContainSubstring("404")))
+
+		client.ErrorTTL = 0
+		_, err = client.Architectures(context.Background(), image+"/missing:v1")
+		Expect(err).NotTo(MatchError(ContainSubstring("404")))
+	})
+
+	It("should not cache lookups the caller cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := client.Architectures(ctx, image+"/single:v1")
+		Expect(err).To(HaveOccurred())
+		Expect(client.Architectures(context.Background(), image+"/single:v1")).To(Equal([]string{"amd64"}))
+	})
+
+	It("should bound the cache", func() {
+		start := time.Now()
+		for i := range maxCacheEntries + 10 {
+			client.remember(fmt.Sprintf("image-%d", i), cacheEntry{fetched: start.Add(time.Duration(i) * time.Millisecond)})
+		}
+		Expect(client.cache).To(HaveLen(maxCacheEntries))
+		Expect(client.cache).NotTo(HaveKey("image-0"))
+		Expect(client.cache).To(HaveKey(fmt.Sprintf("image-%d", maxCacheEntries+9)))
+	})
+})
@@ -0,0 +1,179 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package smoketest runs the smoke tests configured on team members against their new pods.
+package smoketest
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// maxOutput bounds the command output quoted in errors
+const maxOutput = 512
+
+// Runner runs HTTP and exec smoke tests against pods
+type Runner struct {
+	HTTPClient *http.Client
+
+	// Config is used to exec into pods; exec smoke tests fail when it is nil
+	Config *rest.Config
+}
+
+// NewRunner returns a Runner that execs into pods through the API server at config. Like the
+// kubelet's HTTP probes, its HTTP tests do not verify the pod's certificate, which is not issued
+// for the pod's IP, and do not follow redirects, which could lead the operator to other hosts.
+func NewRunner(config *rest.Config) *Runner {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// nolint:gosec
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	return &Runner{
+		HTTPClient: &http.Client{
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		Config: config,
+	}
+}
+
+// HTTPGet sends a GET request to the pod's IP as described by action, ignoring its host. The test fails unless the
+// pod answers with a 2xx or 3xx status within timeout, as for an HTTP probe. Redirects are not followed.
+func (r *Runner) HTTPGet(ctx context.Context, pod *corev1.Pod, container string, action *corev1.HTTPGetAction, timeout time.Duration) error {
+	port, err := resolvePort(pod, container, action.Port)
+	if err != nil {
+		return err
+	}
+	// The test always targets the pod itself; the action's host is rejected at admission, as
+	// it would let squad authors send requests from the operator to any host
+	host := pod.Status.PodIP
+	if host == "" {
+		return fmt.Errorf("pod %s has no IP yet", pod.Name)
+	}
+	scheme := strings.ToLower(string(action.Scheme))
+	if scheme == "" {
+		scheme = "http"
+	}
+	path := action.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	target := &url.URL{Scheme: scheme, Host: net.JoinHostPort(host, strconv.Itoa(port)), Path: path}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return fmt.Errorf("building smoke test request: %w", err)
+	}
+	for _, header := range action.HTTPHeaders {
+		req.Header.Add(header.Name, header.Value)
+	}
+
+	resp, err := r.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("GET %s: %w", target, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 399 {
+		return fmt.Errorf("GET %s returned status %d", target, resp.StatusCode)
+	}
+	return nil
+}
+
+// Exec runs the action's command in the pod's container. The test fails unless the command
+// exits with status zero within timeout.
+func (r *Runner) Exec(ctx context.Context, pod *corev1.Pod, container string, action *corev1.ExecAction, timeout time.Duration) error {
+	if r.Config == nil {
+		return fmt.Errorf("exec smoke tests are not available without a connection to the API server")
+	}
+	clientset, err := kubernetes.NewForConfig(r.Config)
+	if err != nil {
+		return err
+	}
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   action.Command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	executor, err := remotecommand.NewSPDYExecutor(r.Config, http.MethodPost, req.URL())
+	if err != nil {
+		return fmt.Errorf("preparing exec: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var output bytes.Buffer
+	if err := executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: &output, Stderr: &output}); err != nil {
+		if output.Len() > 0 {
+			return fmt.Errorf("%s: %w: %s", strings.Join(action.Command, " "), err, truncate(output.String()))
+		}
+		return fmt.Errorf("%s: %w", strings.Join(action.Command, " "), err)
+	}
+	return nil
+}
+
+// resolvePort returns the number of a port, looking named ports up in the container's ports
+func resolvePort(pod *corev1.Pod, container string, port intstr.IntOrString) (int, error) {
+	if port.Type == intstr.Int {
+		return port.IntValue(), nil
+	}
+	for _, c := range pod.Spec.Containers {
+		if c.Name != container {
+			continue
+		}
+		for _, p := range c.Ports {
+			if p.Name == port.StrVal {
+				return int(p.ContainerPort), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("container %s has no port named %s", container, port.StrVal)
+}
+
+// truncate shortens command output quoted in errors
+func truncate(output string) string {
+	output = strings.TrimSpace(output)
+	if len(output) > maxOutput {
+		return output[:maxOutput] + "..."
+	}
+	return output
+}
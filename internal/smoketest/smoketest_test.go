@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package smoketest
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("Runner", func() {
+	var server *httptest.Server
+	var pod *corev1.Pod
+	var path string
+	var elsewhere *httptest.Server
+	var redirected bool
+
+	// podServing returns a pod whose IP and http port are the server's
+	podServing := func(server *httptest.Server) *corev1.Pod {
+		host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+		Expect(err).NotTo(HaveOccurred())
+		portNumber, err := strconv.Atoi(port)
+		Expect(err).NotTo(HaveOccurred())
+		return &corev1.Pod{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:  "oksana",
+				Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: int32(portNumber)}},
+			}}},
+			Status: corev1.PodStatus{PodIP: host},
+		}
+	}
+
+	BeforeEach(func() {
+		redirected = false
+		elsewhere = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			redirected = true
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			path = req.URL.Path
+			switch req.URL.Path {
+			case "/broken":
+				w.WriteHeader(http.StatusInternalServerError)
+			case "/moved":
+				http.Redirect(w, req, elsewhere.URL+"/metadata", http.StatusFound)
+			}
+		}))
+		pod = podServing(server)
+	})
+
+	AfterEach(func() {
+		server.Close()
+		elsewhere.Close()
+	})
+
+	It("should pass when the pod answers on its named port", func() {
+		action := &corev1.HTTPGetAction{Path: "healthz", Port: intstr.FromString("http")}
+		Expect(NewRunner(nil).HTTPGet(context.Background(), pod, "oksana", action, time.Second)).To(Succeed())
+		Expect(path).To(Equal("/healthz"))
+	})
+
+	It("should fail when the pod answers with an error status", func() {
+		action := &corev1.HTTPGetAction{Path: "/broken", Port: intstr.FromString("http")}
+		err := NewRunner(nil).HTTPGet(context.Background(), pod, "oksana", action, time.Second)
+		Expect(err).To(MatchError(ContainSubstring("500")))
+	})
+
+	It("should target the pod's IP whatever host the action names", func() {
+		action := &corev1.HTTPGetAction{Host: "169.254.169.254", Path: "/healthz", Port: intstr.FromString("http")}
+		Expect(NewRunner(nil).HTTPGet(context.Background(), pod, "oksana", action, time.Second)).To(Succeed())
+		Expect(path).To(Equal("/healthz"))
+	})
+
+	It("should pass on a redirect without following it", func() {
+		action := &corev1.HTTPGetAction{Path: "/moved", Port: intstr.FromString("http")}
+		Expect(NewRunner(nil).HTTPGet(context.Background(), pod, "oksana", action, time.Second)).To(Succeed())
+		Expect(redirected).To(BeFalse())
+	})
+
+	It("should not verify the certificate of pods serving HTTPS", func() {
+		secure := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			path = req.URL.Path
+		}))
+		defer secure.Close()
+		action := &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromString("http"), Scheme: corev1.URISchemeHTTPS}
+		Expect(NewRunner(nil).HTTPGet(context.Background(), podServing(secure), "oksana", action, time.Second)).To(Succeed())
+		Expect(path).To(Equal("/healthz"))
+	})
+
+	It("should fail for an unknown named port", func() {
+		action := &corev1.HTTPGetAction{Port: intstr.FromString("metrics")}
+		Expect(NewRunner(nil).HTTPGet(context.Background(), pod, "oksana", action, time.Second)).NotTo(Succeed())
+	})
+
+	It("should fail exec tests without a connection to the API server", func() {
+		action := &corev1.ExecAction{Command: []string{"true"}}
+		Expect(NewRunner(nil).Exec(context.Background(), pod, "oksana", action, time.Second)).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,426 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package statusapi serves a JSON view of VirtSquads for dashboards that should not need access
+// to the Kubernetes API, along with an endpoint external systems such as CI or chatops use to
+// request team member scale changes. The API is only served over TLS, and each token can be
+// limited to the namespaces its clients should see.
+package statusapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+// maxEvents is the number of recent events returned for a squad
+const maxEvents = 20
+
+// +kubebuilder:rbac:groups="",resources=events,verbs=list
+
+// Server serves the squad state API
+type Server struct {
+	// Addr is the address the server listens on
+	Addr string
+
+	// TLSOpts configure the server's TLS, and must provide its serving certificate
+	TLSOpts []func(*tls.Config)
+
+	// Token is the bearer token clients must present to read squads
+	Token string
+
+	// Namespaces limits the squads readable with Token; every namespace is readable when it is
+	// empty, so a leaked token exposes the state of every squad in the cluster
+	Namespaces []string
+
+	// ScaleToken is the bearer token clients must present to request scale changes; scale
+	// requests are disabled when it is empty
+	ScaleToken string
+
+	// ScaleNamespaces limits the squads ScaleToken may scale; squads in every namespace can be
+	// scaled when it is empty
+	ScaleNamespaces []string
+
+	// Reader reads VirtSquads, typically from the manager's cache
+	Reader client.Reader
+
+	// Writer records scale requests on VirtSquads
+	Writer client.Writer
+
+	// EventReader lists events by field selector, typically straight from the API server so
+	// the manager does not cache every event of the cluster
+	EventReader client.Reader
+}
+
+// Condition is a squad or member condition
+type Condition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// Squad summarizes the state of a squad
+type Squad struct {
+	Namespace   string      `json:"namespace"`
+	Name        string      `json:"name"`
+	Ready       bool        `json:"ready"`
+	ReadyPods   int32       `json:"readyPods"`
+	DesiredPods int32       `json:"desiredPods"`
+	TotalPods   int32       `json:"totalPods"`
+	Conditions  []Condition `json:"conditions,omitempty"`
+}
+
+// Member describes the health of one team member
+type Member struct {
+	Name       string      `json:"name"`
+	Pods       []string    `json:"pods"`
+	Degraded   bool        `json:"degraded"`
+	Failed     bool        `json:"failed"`
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// Event is a recent event about a squad
+type Event struct {
+	Type     string    `json:"type"`
+	Reason   string    `json:"reason"`
+	Message  string    `json:"message"`
+	Count    int32     `json:"count"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+// SquadDetail is the full state of a squad
+type SquadDetail struct {
+	Squad
+	Members []Member `json:"members"`
+	Events  []Event  `json:"events"`
+}
+
+// ScaleRequest is the body of a scale request
+type ScaleRequest struct {
+	Replicas  *int32 `json:"replicas"`
+	Requester string `json:"requester,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// Handler returns the API's HTTP handler
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /api/squads", authenticate(s.Token, s.listSquads))
+	mux.Handle("GET /api/squads/{namespace}/{name}", authenticate(s.Token, s.getSquad))
+	mux.Handle("POST /api/squads/{namespace}/{name}/members/{member}/scale", authenticate(s.ScaleToken, s.scaleMember))
+	return mux
+}
+
+// Start serves the API until ctx is canceled
+func (s *Server) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("status-api")
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	for _, opt := range s.TLSOpts {
+		opt(tlsConfig)
+	}
+	if tlsConfig.GetCertificate == nil && len(tlsConfig.Certificates) == 0 {
+		return errors.New("the status API requires a serving certificate")
+	}
+
+	server := &http.Server{
+		Addr:              s.Addr,
+		Handler:           s.Handler(),
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Error(err, "Failed to shut down the status API")
+		}
+	}()
+
+	log.Info("Serving the status API", "address", s.Addr)
+	if err := server.ListenAndServeTLS("", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// NeedLeaderElection lets every replica serve the API, not just the leader
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// authenticate rejects requests that do not carry the bearer token. An empty token rejects
+// every request.
+func authenticate(token string, next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		presented, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" || subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, "a valid bearer token is required")
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// allowed reports whether a namespace is among the given ones, all namespaces being allowed
+// when none are given
+func allowed(namespaces []string, namespace string) bool {
+	return len(namespaces) == 0 || slices.Contains(namespaces, namespace)
+}
+
+// listSquads returns the summary of every readable squad, optionally limited to one namespace
+func (s *Server) listSquads(w http.ResponseWriter, req *http.Request) {
+	// A single list covers every namespace unless the token is limited to some of them
+	namespaces := []string{""}
+	if namespace := req.URL.Query().Get("namespace"); namespace != "" {
+		namespaces = []string{namespace}
+		if !allowed(s.Namespaces, namespace) {
+			namespaces = nil
+		}
+	} else if len(s.Namespaces) > 0 {
+		namespaces = s.Namespaces
+	}
+
+	summaries := []Squad{}
+	for _, namespace := range namespaces {
+		squads := &appsv1.VirtSquadList{}
+		if err := s.Reader.List(req.Context(), squads, client.InNamespace(namespace)); err != nil {
+			logf.FromContext(req.Context()).Error(err, "Failed to list squads", "namespace", namespace)
+			writeError(w, http.StatusInternalServerError, "failed to list squads")
+			return
+		}
+		for i := range squads.Items {
+			summaries = append(summaries, summarize(&squads.Items[i]))
+		}
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Namespace != summaries[j].Namespace {
+			return summaries[i].Namespace < summaries[j].Namespace
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+	writeJSON(w, http.StatusOK, summaries)
+}
+
+// getSquad returns the full state of one squad
+func (s *Server) getSquad(w http.ResponseWriter, req *http.Request) {
+	key := client.ObjectKey{Namespace: req.PathValue("namespace"), Name: req.PathValue("name")}
+	// Squads outside the token's namespaces look missing, so their names do not leak either
+	if !allowed(s.Namespaces, key.Namespace) {
+		writeError(w, http.StatusNotFound, "squad not found")
+		return
+	}
+	virtSquad := &appsv1.VirtSquad{}
+	if err := s.Reader.Get(req.Context(), key, virtSquad); err != nil {
+		if apierrors.IsNotFound(err) {
+			writeError(w, http.StatusNotFound, "squad not found")
+			return
+		}
+		logf.FromContext(req.Context()).Error(err, "Failed to get squad", "squad", key)
+		writeError(w, http.StatusInternalServerError, "failed to get squad")
+		return
+	}
+
+	detail := SquadDetail{Squad: summarize(virtSquad), Members: members(virtSquad), Events: []Event{}}
+	events, err := s.recentEvents(req.Context(), virtSquad)
+	if err != nil {
+		// The squad's own state is still worth returning without its events
+		logf.FromContext(req.Context()).Error(err, "Failed to list squad events", "squad", key)
+	} else {
+		detail.Events = events
+	}
+	writeJSON(w, http.StatusOK, detail)
+}
+
+// scaleMember records a scale request on a squad for the controller to apply
+func (s *Server) scaleMember(w http.ResponseWriter, req *http.Request) {
+	member := req.PathValue("member")
+	if !slices.Contains(memberNames, member) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("unknown team member %q", member))
+		return
+	}
+	body := ScaleRequest{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, 1<<16)).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if body.Replicas == nil || *body.Replicas < 0 {
+		writeError(w, http.StatusBadRequest, "replicas must be set to a non-negative number")
+		return
+	}
+
+	key := client.ObjectKey{Namespace: req.PathValue("namespace"), Name: req.PathValue("name")}
+	if !allowed(s.ScaleNamespaces, key.Namespace) {
+		writeError(w, http.StatusNotFound, "squad not found")
+		return
+	}
+	virtSquad := &appsv1.VirtSquad{}
+	if err := s.Reader.Get(req.Context(), key, virtSquad); err != nil {
+		if apierrors.IsNotFound(err) {
+			writeError(w, http.StatusNotFound, "squad not found")
+			return
+		}
+		logf.FromContext(req.Context()).Error(err, "Failed to get squad", "squad", key)
+		writeError(w, http.StatusInternalServerError, "failed to get squad")
+		return
+	}
+	if _, pending := virtSquad.Annotations[appsv1.ScaleRequestAnnotation]; pending {
+		writeError(w, http.StatusConflict, "another scale request for the squad is still pending")
+		return
+	}
+
+	request := appsv1.ScaleRequest{Member: member, Replicas: *body.Replicas, Requester: body.Requester, Reason: body.Reason}
+	value, err := json.Marshal(request)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to encode scale request")
+		return
+	}
+	// The optimistic lock keeps a concurrent request from being overwritten
+	patch := client.MergeFromWithOptions(virtSquad.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	if virtSquad.Annotations == nil {
+		virtSquad.Annotations = map[string]string{}
+	}
+	virtSquad.Annotations[appsv1.ScaleRequestAnnotation] = string(value)
+	if err := s.Writer.Patch(req.Context(), virtSquad, patch); err != nil {
+		if apierrors.IsConflict(err) {
+			writeError(w, http.StatusConflict, "the squad changed while the request was recorded; retry")
+			return
+		}
+		logf.FromContext(req.Context()).Error(err, "Failed to record scale request", "squad", key)
+		writeError(w, http.StatusInternalServerError, "failed to record scale request")
+		return
+	}
+	logf.FromContext(req.Context()).Info("Recorded scale request", "squad", key, "member", member,
+		"replicas", request.Replicas, "requester", request.Requester)
+	// The outcome is reported in the squad's status.scaleRequests once the controller applies it
+	writeJSON(w, http.StatusAccepted, request)
+}
+
+// summarize returns the summary of a squad
+func summarize(virtSquad *appsv1.VirtSquad) Squad {
+	return Squad{
+		Namespace:   virtSquad.Namespace,
+		Name:        virtSquad.Name,
+		Ready:       meta.IsStatusConditionTrue(virtSquad.Status.Conditions, appsv1.ConditionReady),
+		ReadyPods:   virtSquad.Status.ReadyPods,
+		DesiredPods: virtSquad.Status.DesiredPods,
+		TotalPods:   virtSquad.Status.TotalPods,
+		Conditions:  conditions(virtSquad.Status.Conditions),
+	}
+}
+
+// memberNames lists the team members a squad can configure
+var memberNames = []string{"oksana", "kurtis", "matt", "kike"}
+
+// members returns the health of a squad's team members
+func members(virtSquad *appsv1.VirtSquad) []Member {
+	pods := map[string][]string{
+		"oksana": virtSquad.Status.OksanaPods,
+		"kurtis": virtSquad.Status.KurtisPods,
+		"matt":   virtSquad.Status.MattPods,
+		"kike":   virtSquad.Status.KikePods,
+	}
+	result := make([]Member, 0, len(virtSquad.Status.Members))
+	for _, member := range virtSquad.Status.Members {
+		result = append(result, Member{
+			Name:       member.Name,
+			Pods:       append([]string{}, pods[member.Name]...),
+			Degraded:   meta.IsStatusConditionTrue(member.Conditions, appsv1.ConditionDegraded),
+			Failed:     meta.IsStatusConditionTrue(member.Conditions, appsv1.ConditionFailed),
+			Conditions: conditions(member.Conditions),
+		})
+	}
+	return result
+}
+
+// conditions converts API conditions to their JSON representation
+func conditions(in []metav1.Condition) []Condition {
+	out := make([]Condition, 0, len(in))
+	for _, condition := range in {
+		out = append(out, Condition{
+			Type:    condition.Type,
+			Status:  string(condition.Status),
+			Reason:  condition.Reason,
+			Message: condition.Message,
+		})
+	}
+	return out
+}
+
+// recentEvents returns the squad's latest events, newest first
+func (s *Server) recentEvents(ctx context.Context, virtSquad *appsv1.VirtSquad) ([]Event, error) {
+	list := &corev1.EventList{}
+	if err := s.EventReader.List(ctx, list,
+		client.InNamespace(virtSquad.Namespace),
+		client.MatchingFields{"involvedObject.kind": "VirtSquad", "involvedObject.name": virtSquad.Name},
+	); err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, 0, len(list.Items))
+	for _, event := range list.Items {
+		lastSeen := event.LastTimestamp.Time
+		if lastSeen.IsZero() {
+			lastSeen = event.EventTime.Time
+		}
+		events = append(events, Event{
+			Type:     event.Type,
+			Reason:   event.Reason,
+			Message:  event.Message,
+			Count:    max(event.Count, 1),
+			LastSeen: lastSeen,
+		})
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].LastSeen.After(events[j].LastSeen) })
+	if len(events) > maxEvents {
+		events = events[:maxEvents]
+	}
+	return events, nil
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes a JSON error response
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
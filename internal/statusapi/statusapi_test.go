@@ -0,0 +1,200 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statusapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+)
+
+var _ = Describe("Server", func() {
+	var handler http.Handler
+	var c client.Client
+
+	event := func(name, reason string, age time.Duration) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Namespace: "default", Name: name},
+			InvolvedObject: corev1.ObjectReference{Kind: "VirtSquad", Namespace: "default", Name: "alpha"},
+			Type:           corev1.EventTypeNormal,
+			Reason:         reason,
+			LastTimestamp:  metav1.NewTime(time.Now().Add(-age)),
+		}
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(appsv1.AddToScheme(scheme)).To(Succeed())
+
+		alpha := &appsv1.VirtSquad{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "alpha"},
+			Status: appsv1.VirtSquadStatus{
+				ReadyPods:   1,
+				DesiredPods: 2,
+				TotalPods:   2,
+				OksanaPods:  []string{"alpha-oksana-0"},
+				Conditions: []metav1.Condition{{
+					Type: appsv1.ConditionReady, Status: metav1.ConditionFalse, Reason: "PodsNotReady",
+				}},
+				Members: []appsv1.MemberStatus{{
+					Name: "oksana",
+					Conditions: []metav1.Condition{{
+						Type: appsv1.ConditionDegraded, Status: metav1.ConditionTrue, Reason: "CrashLoopBackOff",
+					}},
+				}},
+			},
+		}
+		beta := &appsv1.VirtSquad{ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "beta"}}
+
+		c = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithStatusSubresource(&appsv1.VirtSquad{}).
+			WithObjects(alpha, beta,
+				event("alpha.1", "Created", 3*time.Minute),
+				event("alpha.2", "Scaled", time.Minute),
+			).
+			WithIndex(&corev1.Event{}, "involvedObject.kind", func(obj client.Object) []string {
+				return []string{obj.(*corev1.Event).InvolvedObject.Kind}
+			}).
+			WithIndex(&corev1.Event{}, "involvedObject.name", func(obj client.Object) []string {
+				return []string{obj.(*corev1.Event).InvolvedObject.Name}
+			}).
+			Build()
+		handler = (&Server{Token: "secret", ScaleToken: "scale", Reader: c, Writer: c, EventReader: c}).Handler()
+	})
+
+	do := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	get := func(path, token string) *httptest.ResponseRecorder {
+		return do(http.MethodGet, path, token, "")
+	}
+
+	It("should reject requests without a valid token", func() {
+		Expect(get("/api/squads", "").Code).To(Equal(http.StatusUnauthorized))
+		Expect(get("/api/squads", "wrong").Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should list squads sorted by namespace and name", func() {
+		rec := get("/api/squads", "secret")
+		Expect(rec.Code).To(Equal(http.StatusOK))
+
+		var squads []Squad
+		Expect(json.Unmarshal(rec.Body.Bytes(), &squads)).To(Succeed())
+		Expect(squads).To(HaveLen(2))
+		Expect(squads[0].Name).To(Equal("beta"))
+		Expect(squads[1].Name).To(Equal("alpha"))
+		Expect(squads[1].ReadyPods).To(Equal(int32(1)))
+		Expect(squads[1].Ready).To(BeFalse())
+
+		rec = get("/api/squads?namespace=default", "secret")
+		Expect(json.Unmarshal(rec.Body.Bytes(), &squads)).To(Succeed())
+		Expect(squads).To(HaveLen(1))
+	})
+
+	It("should return a squad's member health and recent events", func() {
+		rec := get("/api/squads/default/alpha", "secret")
+		Expect(rec.Code).To(Equal(http.StatusOK))
+
+		var detail SquadDetail
+		Expect(json.Unmarshal(rec.Body.Bytes(), &detail)).To(Succeed())
+		Expect(detail.Members).To(HaveLen(1))
+		Expect(detail.Members[0].Name).To(Equal("oksana"))
+		Expect(detail.Members[0].Degraded).To(BeTrue())
+		Expect(detail.Members[0].Pods).To(ConsistOf("alpha-oksana-0"))
+		Expect(detail.Events).To(HaveLen(2))
+		Expect(detail.Events[0].Reason).To(Equal("Scaled"))
+		Expect(detail.Events[1].Reason).To(Equal("Created"))
+	})
+
+	It("should report a missing squad", func() {
+		Expect(get("/api/squads/default/missing", "secret").Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should only serve squads in the token's namespaces", func() {
+		handler = (&Server{Token: "secret", Namespaces: []string{"apps"}, Reader: c, Writer: c, EventReader: c}).Handler()
+
+		var squads []Squad
+		rec := get("/api/squads", "secret")
+		Expect(json.Unmarshal(rec.Body.Bytes(), &squads)).To(Succeed())
+		Expect(squads).To(HaveLen(1))
+		Expect(squads[0].Name).To(Equal("beta"))
+
+		rec = get("/api/squads?namespace=default", "secret")
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(json.Unmarshal(rec.Body.Bytes(), &squads)).To(Succeed())
+		Expect(squads).To(BeEmpty())
+
+		Expect(get("/api/squads/apps/beta", "secret").Code).To(Equal(http.StatusOK))
+		Expect(get("/api/squads/default/alpha", "secret").Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should refuse to serve without a certificate", func() {
+		Expect((&Server{Addr: "127.0.0.1:0", Token: "secret"}).Start(context.Background())).
+			To(MatchError(ContainSubstring("serving certificate")))
+	})
+	It("should record scale requests for the controller to apply", func() {
+		path := "/api/squads/default/alpha/members/oksana/scale"
+		body := `{"replicas": 3, "requester": "ci"}`
+		Expect(do(http.MethodPost, path, "secret", body).Code).To(Equal(http.StatusUnauthorized))
+		Expect(do(http.MethodPost, "/api/squads/default/alpha/members/bob/scale", "scale", body).Code).To(Equal(http.StatusBadRequest))
+		Expect(do(http.MethodPost, path, "scale", `{"requester": "ci"}`).Code).To(Equal(http.StatusBadRequest))
+
+		Expect(do(http.MethodPost, path, "scale", body).Code).To(Equal(http.StatusAccepted))
+		virtSquad := &appsv1.VirtSquad{}
+		Expect(c.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "alpha"}, virtSquad)).To(Succeed())
+		Expect(virtSquad.Annotations).To(HaveKeyWithValue(appsv1.ScaleRequestAnnotation,
+			`{"member":"oksana","replicas":3,"requester":"ci"}`))
+
+		Expect(do(http.MethodPost, path, "scale", body).Code).To(Equal(http.StatusConflict))
+	})
+
+	It("should only scale squads in the scale token's namespaces", func() {
+		handler = (&Server{Token: "secret", ScaleToken: "scale", ScaleNamespaces: []string{"apps"}, Reader: c, Writer: c, EventReader: c}).Handler()
+		body := `{"replicas": 3}`
+		Expect(do(http.MethodPost, "/api/squads/default/alpha/members/oksana/scale", "scale", body).Code).To(Equal(http.StatusNotFound))
+		Expect(do(http.MethodPost, "/api/squads/apps/beta/members/oksana/scale", "scale", body).Code).To(Equal(http.StatusAccepted))
+	})
+
+	It("should reject scale requests when no scale token is configured", func() {
+		handler = (&Server{Token: "secret", Reader: c, Writer: c, EventReader: c}).Handler()
+		Expect(do(http.MethodPost, "/api/squads/default/alpha/members/oksana/scale", "", `{"replicas": 3}`).Code).
+			To(Equal(http.StatusUnauthorized))
+	})
+})
@@ -0,0 +1,857 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+	"github.com/mshort55/virtsquad-operator/internal/placeholders"
+	"github.com/mshort55/virtsquad-operator/internal/quota"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var virtsquadlog = logf.Log.WithName("virtsquad-resource")
+
+// SetupVirtSquadWebhookWithManager registers the webhook for VirtSquad in the manager.
+func SetupVirtSquadWebhookWithManager(mgr ctrl.Manager, validator *VirtSquadCustomValidator) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&appsv1.VirtSquad{}).
+		WithValidator(validator).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-apps-mshort55-io-v1-virtsquad,mutating=false,failurePolicy=fail,sideEffects=None,groups=apps.mshort55.io,resources=virtsquads,verbs=create;update,versions=v1,name=vvirtsquad-v1.kb.io,admissionReviewVersions=v1
+
+// The webhook checks that authors of replicated squads may create VirtSquads in the replica namespaces,
+// and that authors may read the Secrets their squads send to their own endpoints
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// VirtSquadCustomValidator struct is responsible for validating the VirtSquad resource
+// when it is created, updated, or deleted.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+// +kubebuilder:object:generate=false
+type VirtSquadCustomValidator struct {
+	// MaxReplicasPerMember is the highest replica count a team member may request; zero means no limit
+	MaxReplicasPerMember int32
+
+	// MaxPodsPerSquad is the highest total replica count across a squad's members; zero means no limit
+	MaxPodsPerSquad int32
+
+	// AllowDedicatedNodes admits squads claiming dedicated nodes
+	AllowDedicatedNodes bool
+
+	// Client reads the VirtSquadQuotas and VirtSquads of a namespace; quotas are not enforced when nil
+	Client client.Reader
+
+	// Reviewer creates the SubjectAccessReviews checking that the author of a replicated squad
+	// may create VirtSquads in its replica namespaces, and that authors may read the Secrets
+	// their squads send to their own endpoints; neither is checked when nil
+	Reviewer client.Client
+}
+
+var _ webhook.CustomValidator = &VirtSquadCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type VirtSquad.
+func (v *VirtSquadCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	virtsquad, ok := obj.(*appsv1.VirtSquad)
+	if !ok {
+		return nil, fmt.Errorf("expected a VirtSquad object but got %T", obj)
+	}
+	virtsquadlog.Info("Validation for VirtSquad upon creation", "name", virtsquad.GetName())
+
+	allErrs := v.validateReplicaCeilings(virtsquad)
+	allErrs = append(allErrs, v.validateSchedules(virtsquad)...)
+	allErrs = append(allErrs, validateReplication(virtsquad)...)
+	allErrs = append(allErrs, validateDependencies(virtsquad)...)
+	allErrs = append(allErrs, validatePlacement(virtsquad)...)
+	allErrs = append(allErrs, validatePerformance(virtsquad)...)
+	allErrs = append(allErrs, validateSmokeTests(virtsquad)...)
+	allErrs = append(allErrs, validatePlaceholders(virtsquad)...)
+	allErrs = append(allErrs, validateIsolation(virtsquad)...)
+	allErrs = append(allErrs, validateRestore(virtsquad)...)
+	allErrs = append(allErrs, v.validateDedicatedNodes(nil, virtsquad)...)
+	quotaErrs, err := v.validateQuota(ctx, virtsquad, true)
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, quotaErrs...)
+	accessErrs, err := v.validateReplicaAccess(ctx, virtsquad)
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, accessErrs...)
+	secretErrs, err := v.validateSecretAccess(ctx, virtsquad)
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, secretErrs...)
+	collisionErrs, err := v.validateCollisions(ctx, nil, virtsquad)
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, collisionErrs...)
+	return v.resourceQuotaWarnings(ctx, virtsquad), invalidVirtSquad(virtsquad, allErrs)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type VirtSquad.
+func (v *VirtSquadCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	virtsquad, ok := newObj.(*appsv1.VirtSquad)
+	if !ok {
+		return nil, fmt.Errorf("expected a VirtSquad object for the newObj but got %T", newObj)
+	}
+	oldVirtsquad, ok := oldObj.(*appsv1.VirtSquad)
+	if !ok {
+		return nil, fmt.Errorf("expected a VirtSquad object for the oldObj but got %T", oldObj)
+	}
+	virtsquadlog.Info("Validation for VirtSquad upon update", "name", virtsquad.GetName())
+
+	allErrs := v.validateReplicaCeilings(virtsquad)
+	allErrs = append(allErrs, v.validateSchedules(virtsquad)...)
+	allErrs = append(allErrs, validateMemberRenames(oldVirtsquad, virtsquad)...)
+	allErrs = append(allErrs, validateReplication(virtsquad)...)
+	allErrs = append(allErrs, validateDependencies(virtsquad)...)
+	allErrs = append(allErrs, validatePlacement(virtsquad)...)
+	allErrs = append(allErrs, validatePerformance(virtsquad)...)
+	allErrs = append(allErrs, validateSmokeTests(virtsquad)...)
+	allErrs = append(allErrs, validatePlaceholders(virtsquad)...)
+	allErrs = append(allErrs, validateIsolation(virtsquad)...)
+	allErrs = append(allErrs, v.validateDedicatedNodes(oldVirtsquad, virtsquad)...)
+	// Squads already over a lowered quota may still be updated as long as they do not grow
+	if quota.RequestedPods(&virtsquad.Spec) > quota.RequestedPods(&oldVirtsquad.Spec) {
+		quotaErrs, err := v.validateQuota(ctx, virtsquad, false)
+		if err != nil {
+			return nil, err
+		}
+		allErrs = append(allErrs, quotaErrs...)
+	}
+	collisionErrs, err := v.validateCollisions(ctx, oldVirtsquad, virtsquad)
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, collisionErrs...)
+	accessErrs, err := v.validateReplicaAccess(ctx, virtsquad)
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, accessErrs...)
+	secretErrs, err := v.validateSecretAccess(ctx, virtsquad)
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, secretErrs...)
+	return v.resourceQuotaWarnings(ctx, virtsquad), invalidVirtSquad(virtsquad, allErrs)
+}
+
+// ValidateMergedSpec runs the checks of the squad's own spec against a squad whose
+// VirtSquadTemplate was merged in, which the webhook never sees. The namespace-wide quota and
+// collision checks are left to the controller, which enforces them on every squad anyway.
+func (v *VirtSquadCustomValidator) ValidateMergedSpec(virtsquad *appsv1.VirtSquad) error {
+	// The template's members are merged in, so members the squad refers to must be configured
+	virtsquad = virtsquad.DeepCopy()
+	virtsquad.Spec.TemplateRef = nil
+
+	allErrs := v.validateReplicaCeilings(virtsquad)
+	allErrs = append(allErrs, v.validateSchedules(virtsquad)...)
+	allErrs = append(allErrs, validateDependencies(virtsquad)...)
+	allErrs = append(allErrs, validatePlacement(virtsquad)...)
+	allErrs = append(allErrs, validatePerformance(virtsquad)...)
+	allErrs = append(allErrs, validateSmokeTests(virtsquad)...)
+	allErrs = append(allErrs, validatePlaceholders(virtsquad)...)
+	allErrs = append(allErrs, validateIsolation(virtsquad)...)
+	allErrs = append(allErrs, v.validateDedicatedNodes(nil, virtsquad)...)
+	return invalidVirtSquad(virtsquad, allErrs)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type VirtSquad.
+func (v *VirtSquadCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	virtsquad, ok := obj.(*appsv1.VirtSquad)
+	if !ok {
+		return nil, fmt.Errorf("expected a VirtSquad object but got %T", obj)
+	}
+	virtsquadlog.Info("Validation for VirtSquad upon deletion", "name", virtsquad.GetName())
+
+	return nil, nil
+}
+
+// teamMember pairs a team member's spec with its field name in the VirtSquad spec
+type teamMember struct {
+	name string
+	spec *appsv1.TeamMemberSpec
+}
+
+// teamMembers lists the team members of a VirtSquad spec in a stable order
+func teamMembers(spec *appsv1.VirtSquadSpec) []teamMember {
+	return []teamMember{
+		{"oksana", spec.Oksana},
+		{"kurtis", spec.Kurtis},
+		{"matt", spec.Matt},
+		{"kike", spec.Kike},
+	}
+}
+
+// invalidVirtSquad turns validation errors into the Invalid error returned to the API server
+func invalidVirtSquad(virtsquad *appsv1.VirtSquad, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(appsv1.GroupVersion.WithKind("VirtSquad").GroupKind(), virtsquad.Name, allErrs)
+}
+
+// validateReplicaCeilings checks the squad against the operator's replica ceilings. Members
+// without a name run no pods, as in the controller, and do not count.
+func (v *VirtSquadCustomValidator) validateReplicaCeilings(virtsquad *appsv1.VirtSquad) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	var totalReplicas int32
+	for _, member := range teamMembers(&virtsquad.Spec) {
+		if member.spec == nil || member.spec.Name == nil {
+			continue
+		}
+		replicas := int32(1)
+		if member.spec.Replicas != nil {
+			replicas = *member.spec.Replicas
+		}
+		totalReplicas += replicas
+
+		if v.MaxReplicasPerMember > 0 && replicas > v.MaxReplicasPerMember {
+			allErrs = append(allErrs, field.Invalid(specPath.Child(member.name, "replicas"), replicas,
+				fmt.Sprintf("must not exceed the operator's limit of %d replicas per team member", v.MaxReplicasPerMember)))
+		}
+	}
+
+	if v.MaxPodsPerSquad > 0 && totalReplicas > v.MaxPodsPerSquad {
+		allErrs = append(allErrs, field.Invalid(specPath, totalReplicas,
+			fmt.Sprintf("team members request %d pods in total, more than the operator's limit of %d pods per squad",
+				totalReplicas, v.MaxPodsPerSquad)))
+	}
+
+	return allErrs
+}
+
+// validateQuota checks the squad against the namespace's VirtSquadQuotas: a new squad must fit
+// under the squad limit, and the pods requested across the namespace's squads under the pod limit
+func (v *VirtSquadCustomValidator) validateQuota(ctx context.Context, virtsquad *appsv1.VirtSquad, creating bool) (field.ErrorList, error) {
+	if v.Client == nil {
+		return nil, nil
+	}
+	limits, err := quota.ForNamespace(ctx, v.Client, virtsquad.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VirtSquadQuotas: %w", err)
+	}
+	if limits.MaxSquads == nil && limits.MaxPods == nil {
+		return nil, nil
+	}
+	squads := &appsv1.VirtSquadList{}
+	if err := v.Client.List(ctx, squads, client.InNamespace(virtsquad.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list VirtSquads: %w", err)
+	}
+
+	var allErrs field.ErrorList
+	others := int32(0)
+	requested := quota.RequestedPods(&virtsquad.Spec)
+	for i := range squads.Items {
+		if squads.Items[i].Name == virtsquad.Name {
+			continue
+		}
+		others++
+		requested += quota.RequestedPods(&squads.Items[i].Spec)
+	}
+
+	if creating && limits.MaxSquads != nil && others >= *limits.MaxSquads {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("metadata", "namespace"),
+			fmt.Sprintf("namespace %s already holds %d VirtSquads, the most its VirtSquadQuota allows",
+				virtsquad.Namespace, others)))
+	}
+	if limits.MaxPods != nil && requested > *limits.MaxPods {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"),
+			fmt.Sprintf("the namespace's squads would request %d pods, more than the limit of %d pods set by VirtSquadQuota %s",
+				requested, *limits.MaxPods, limits.Quota)))
+	}
+	return allErrs, nil
+}
+
+// validateCollisions rejects squads whose pods would collide with those of another squad in the
+// namespace, by sharing pod names or matching each other's pod labels. Updates are only rejected
+// when they add collisions, so squads that already collide can still be fixed step by step.
+func (v *VirtSquadCustomValidator) validateCollisions(ctx context.Context, oldVirtsquad, virtsquad *appsv1.VirtSquad) (field.ErrorList, error) {
+	if v.Client == nil {
+		return nil, nil
+	}
+	squads := &appsv1.VirtSquadList{}
+	if err := v.Client.List(ctx, squads, client.InNamespace(virtsquad.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list VirtSquads: %w", err)
+	}
+
+	var allErrs field.ErrorList
+	for i := range squads.Items {
+		other := &squads.Items[i]
+		if other.Name == virtsquad.Name || other.DeletionTimestamp != nil {
+			continue
+		}
+		collisions := virtsquad.Spec.Collisions(&other.Spec)
+		if oldVirtsquad != nil {
+			existing := oldVirtsquad.Spec.Collisions(&other.Spec)
+			collisions = slices.DeleteFunc(collisions, func(collision string) bool {
+				return slices.Contains(existing, collision)
+			})
+		}
+		if len(collisions) == 0 {
+			continue
+		}
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"),
+			fmt.Sprintf("the squad's pods would collide with those of VirtSquad %s: %s", other.Name, strings.Join(collisions, ", "))))
+	}
+	return allErrs, nil
+}
+
+// validatePerformance checks that members asking for dedicated CPUs request a whole number of
+// CPUs and some memory, as the static CPU manager requires to pin a Guaranteed pod
+func validatePerformance(virtsquad *appsv1.VirtSquad) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, member := range teamMembers(&virtsquad.Spec) {
+		if member.spec == nil || member.spec.Performance == nil || !ptr.Deref(member.spec.Performance.DedicatedCPUs, false) {
+			continue
+		}
+		path := field.NewPath("spec", member.name, "resources")
+		requests := quota.PodRequests(member.spec, nil)
+		cpu, hasCPU := requests[corev1.ResourceCPU]
+		switch {
+		case !hasCPU:
+			allErrs = append(allErrs, field.Required(path.Child("requests", "cpu"), "dedicatedCPUs requires a CPU request"))
+		case cpu.MilliValue()%1000 != 0:
+			allErrs = append(allErrs, field.Invalid(path.Child("requests", "cpu"), cpu.String(),
+				"dedicatedCPUs requires a whole number of CPUs"))
+		}
+		if _, ok := requests[corev1.ResourceMemory]; !ok {
+			allErrs = append(allErrs, field.Required(path.Child("requests", "memory"), "dedicatedCPUs requires a memory request"))
+		}
+	}
+	return allErrs
+}
+
+// quotaResources maps the resources pods request to the ResourceQuota keys that limit them
+var quotaResources = map[corev1.ResourceName][]corev1.ResourceName{
+	corev1.ResourceCPU:    {corev1.ResourceRequestsCPU, corev1.ResourceCPU},
+	corev1.ResourceMemory: {corev1.ResourceRequestsMemory, corev1.ResourceMemory},
+}
+
+// scaleQuantity returns the quantity multiplied by pods
+func scaleQuantity(quantity resource.Quantity, pods int32) resource.Quantity {
+	return *resource.NewMilliQuantity(quantity.MilliValue()*int64(pods), quantity.Format)
+}
+
+// resourceQuotaWarnings warns when the namespace's ResourceQuotas cannot fit the squad's pods
+// once the overhead of the members' RuntimeClasses is charged on top of their requests. Squads
+// without pod overhead are left to the API server's own quota checks, and lookups that fail only
+// skip the check.
+func (v *VirtSquadCustomValidator) resourceQuotaWarnings(ctx context.Context, virtsquad *appsv1.VirtSquad) admission.Warnings {
+	if v.Client == nil {
+		return nil
+	}
+
+	requested := corev1.ResourceList{}
+	overheads := corev1.ResourceList{}
+	for _, member := range teamMembers(&virtsquad.Spec) {
+		if member.spec == nil {
+			continue
+		}
+		overhead, err := quota.PodOverhead(ctx, v.Client, member.spec)
+		if err != nil {
+			virtsquadlog.Error(err, "Failed to get the pod overhead", "name", virtsquad.Name, "member", member.name)
+			return nil
+		}
+		pods := quota.MemberPods(member.spec)
+		for name, quantity := range quota.PodRequests(member.spec, overhead) {
+			total := requested[name]
+			total.Add(scaleQuantity(quantity, pods))
+			requested[name] = total
+		}
+		for name, quantity := range overhead {
+			total := overheads[name]
+			total.Add(scaleQuantity(quantity, pods))
+			overheads[name] = total
+		}
+	}
+	if len(overheads) == 0 {
+		return nil
+	}
+
+	quotas := &corev1.ResourceQuotaList{}
+	if err := v.Client.List(ctx, quotas, client.InNamespace(virtsquad.Namespace)); err != nil {
+		virtsquadlog.Error(err, "Failed to list ResourceQuotas", "namespace", virtsquad.Namespace)
+		return nil
+	}
+	var warnings admission.Warnings
+	for _, resourceQuota := range quotas.Items {
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			total, ok := requested[name]
+			if !ok {
+				continue
+			}
+			for _, key := range quotaResources[name] {
+				hard, ok := resourceQuota.Spec.Hard[key]
+				if !ok || total.Cmp(hard) <= 0 {
+					continue
+				}
+				overhead := overheads[name]
+				warnings = append(warnings, fmt.Sprintf(
+					"the squad's pods request %s of %s including %s of RuntimeClass overhead, more than the %s of %s allowed by ResourceQuota %s",
+					total.String(), name, overhead.String(), hard.String(), key, resourceQuota.Name))
+			}
+		}
+	}
+	return warnings
+}
+
+// validateReplication checks that a squad is not replicated into its own namespace and that
+// the copies of a replicated squad are not replicated any further
+func validateReplication(virtsquad *appsv1.VirtSquad) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if _, ok := virtsquad.Labels[appsv1.ReplicaOfLabel]; ok {
+		if len(virtsquad.Spec.TargetNamespaces) > 0 {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("targetNamespaces"),
+				"must not be set on a squad replicated from another namespace"))
+		}
+		if virtsquad.Spec.NamespaceSelector != nil {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("namespaceSelector"),
+				"must not be set on a squad replicated from another namespace"))
+		}
+	}
+	for i, namespace := range virtsquad.Spec.TargetNamespaces {
+		if namespace == virtsquad.Namespace {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("targetNamespaces").Index(i), namespace,
+				"must not be the squad's own namespace"))
+		}
+	}
+	if virtsquad.Spec.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(virtsquad.Spec.NamespaceSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("namespaceSelector"),
+				virtsquad.Spec.NamespaceSelector, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// validateReplicaAccess checks that the user creating or updating a replicated squad may create
+// VirtSquads in each namespace it is replicated into, since the operator creates the replicas
+// and their pods there on the user's behalf. Namespaces that start matching the namespace
+// selector later are trusted, as only cluster administrators can create or label namespaces.
+func (v *VirtSquadCustomValidator) validateReplicaAccess(ctx context.Context, virtsquad *appsv1.VirtSquad) (field.ErrorList, error) {
+	if v.Reviewer == nil || (len(virtsquad.Spec.TargetNamespaces) == 0 && virtsquad.Spec.NamespaceSelector == nil) {
+		return nil, nil
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	specPath := field.NewPath("spec")
+	targets := map[string]*field.Path{}
+	for i, namespace := range virtsquad.Spec.TargetNamespaces {
+		targets[namespace] = specPath.Child("targetNamespaces").Index(i)
+	}
+	if virtsquad.Spec.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(virtsquad.Spec.NamespaceSelector)
+		if err != nil {
+			// Reported by validateReplication
+			return nil, nil
+		}
+		namespaces := &corev1.NamespaceList{}
+		if err := v.Reviewer.List(ctx, namespaces, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, fmt.Errorf("failed to list namespaces: %w", err)
+		}
+		for _, namespace := range namespaces.Items {
+			if _, ok := targets[namespace.Name]; !ok {
+				targets[namespace.Name] = specPath.Child("namespaceSelector")
+			}
+		}
+	}
+	delete(targets, virtsquad.Namespace)
+
+	var allErrs field.ErrorList
+	for _, namespace := range slices.Sorted(maps.Keys(targets)) {
+		allowed, err := v.reviewAccess(ctx, req, &authorizationv1.ResourceAttributes{
+			Namespace: namespace,
+			Verb:      "create",
+			Group:     appsv1.GroupVersion.Group,
+			Resource:  "virtsquads",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to review access to namespace %s: %w", namespace, err)
+		}
+		if !allowed {
+			allErrs = append(allErrs, field.Forbidden(targets[namespace],
+				fmt.Sprintf("user %s may not create VirtSquads in namespace %s", req.UserInfo.Username, namespace)))
+		}
+	}
+	return allErrs, nil
+}
+
+// validateSecretAccess checks that the user creating or updating a squad may read the Secrets
+// the operator sends to endpoints the squad chooses, such as the bearer token of its preDelete
+// hook or the headers of its notification webhook, since anyone else could otherwise point the
+// endpoint at their own server to read them.
+func (v *VirtSquadCustomValidator) validateSecretAccess(ctx context.Context, virtsquad *appsv1.VirtSquad) (field.ErrorList, error) {
+	if v.Reviewer == nil {
+		return nil, nil
+	}
+	specPath := field.NewPath("spec")
+	secrets := map[string]*field.Path{}
+	if hooks := virtsquad.Spec.Hooks; hooks != nil && hooks.PreDelete != nil && hooks.PreDelete.AuthSecretRef != nil {
+		secrets[hooks.PreDelete.AuthSecretRef.Name] = specPath.Child("hooks", "preDelete", "authSecretRef", "name")
+	}
+	if notifications := virtsquad.Spec.Notifications; notifications != nil && notifications.Webhook != nil &&
+		notifications.Webhook.HeadersSecretRef != nil {
+		secrets[notifications.Webhook.HeadersSecretRef.Name] = specPath.Child("notifications", "webhook", "headersSecretRef", "name")
+	}
+	if len(secrets) == 0 {
+		return nil, nil
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var allErrs field.ErrorList
+	for _, name := range slices.Sorted(maps.Keys(secrets)) {
+		allowed, err := v.reviewAccess(ctx, req, &authorizationv1.ResourceAttributes{
+			Namespace: virtsquad.Namespace,
+			Verb:      "get",
+			Resource:  "secrets",
+			Name:      name,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to review access to secret %s: %w", name, err)
+		}
+		if !allowed {
+			allErrs = append(allErrs, field.Forbidden(secrets[name],
+				fmt.Sprintf("user %s may not get Secret %s in namespace %s", req.UserInfo.Username, name, virtsquad.Namespace)))
+		}
+	}
+	return allErrs, nil
+}
+
+// reviewAccess asks the API server whether the user making the admission request may act on
+// the given resource
+func (v *VirtSquadCustomValidator) reviewAccess(ctx context.Context, req admission.Request,
+	attributes *authorizationv1.ResourceAttributes) (bool, error) {
+	extra := map[string]authorizationv1.ExtraValue{}
+	for key, value := range req.UserInfo.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:               req.UserInfo.Username,
+			UID:                req.UserInfo.UID,
+			Groups:             req.UserInfo.Groups,
+			Extra:              extra,
+			ResourceAttributes: attributes,
+		},
+	}
+	if err := v.Reviewer.Create(ctx, review); err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
+
+// validateDedicatedNodes rejects squads claiming dedicated nodes unless the operator allows it.
+// Squads admitted earlier may still be updated, e.g. to remove their finalizer, as long as they
+// do not change their dedicated nodes.
+func (v *VirtSquadCustomValidator) validateDedicatedNodes(oldVirtsquad, virtsquad *appsv1.VirtSquad) field.ErrorList {
+	if v.AllowDedicatedNodes || virtsquad.Spec.DedicatedNodes == nil {
+		return nil
+	}
+	if oldVirtsquad != nil && equality.Semantic.DeepEqual(oldVirtsquad.Spec.DedicatedNodes, virtsquad.Spec.DedicatedNodes) {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(field.NewPath("spec", "dedicatedNodes"),
+		"the operator does not allow squads to claim dedicated nodes")}
+}
+
+// validateSmokeTests rejects HTTP smoke tests naming a host, which would let squad authors make
+// the operator send requests to any host it can reach instead of to their pods
+func validateSmokeTests(virtsquad *appsv1.VirtSquad) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, member := range teamMembers(&virtsquad.Spec) {
+		if member.spec == nil || member.spec.SmokeTest == nil || member.spec.SmokeTest.HTTPGet == nil {
+			continue
+		}
+		if host := member.spec.SmokeTest.HTTPGet.Host; host != "" {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", member.name, "smokeTest", "httpGet", "host"),
+				"smoke tests always target the pod's IP"))
+		}
+	}
+	return allErrs
+}
+
+// validateIsolation rejects strictly isolated squads whose Linux members run the default image,
+// which runs as root and binds port 80, so its pods could never start as a non-root user. Squads
+// stored before the CRD stopped defaulting the image name it explicitly. Members of templated
+// squads may get their image from the template and are not checked.
+func validateIsolation(virtsquad *appsv1.VirtSquad) field.ErrorList {
+	if virtsquad.Spec.Isolation != appsv1.IsolationStrict || virtsquad.Spec.TemplateRef != nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	for _, member := range teamMembers(&virtsquad.Spec) {
+		if member.spec == nil || member.spec.OS == string(corev1.Windows) {
+			continue
+		}
+		if image := member.spec.Image; image != "" && image != appsv1.DefaultMemberImage {
+			continue
+		}
+		allErrs = append(allErrs, field.Required(field.NewPath("spec", member.name, "image"),
+			"strict isolation runs pods as a non-root user, which the default image does not support; set an image that does"))
+	}
+	return allErrs
+}
+
+// validateRestore rejects new squads that configure a backup but no team members or template
+// unless they are created to be restored from the backup, which the CRD cannot tell from their
+// spec. Updates are not checked, since the restore-from annotation is removed once the restore
+// fails for good.
+func validateRestore(virtsquad *appsv1.VirtSquad) field.ErrorList {
+	if virtsquad.Spec.TemplateRef != nil {
+		return nil
+	}
+	if _, ok := virtsquad.Annotations[appsv1.RestoreFromAnnotation]; ok {
+		return nil
+	}
+	for _, member := range teamMembers(&virtsquad.Spec) {
+		if member.spec != nil {
+			return nil
+		}
+	}
+	return field.ErrorList{field.Required(field.NewPath("spec"), fmt.Sprintf(
+		"at least one team member must be defined, unless they come from a template or the squad is restored from a backup through the %s annotation",
+		appsv1.RestoreFromAnnotation))}
+}
+
+// validatePlaceholders checks that the placeholders in the members' env values, args and pod
+// annotations parse and refer to known values, so pods are not held back by them at render time
+func validatePlaceholders(virtsquad *appsv1.VirtSquad) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, member := range teamMembers(&virtsquad.Spec) {
+		if member.spec == nil {
+			continue
+		}
+		path := field.NewPath("spec", member.name)
+		for i, env := range member.spec.Env {
+			if err := placeholders.Validate(env.Value); err != nil {
+				allErrs = append(allErrs, field.Invalid(path.Child("env").Index(i).Child("value"), env.Value, err.Error()))
+			}
+		}
+		for i, arg := range member.spec.Args {
+			if err := placeholders.Validate(arg); err != nil {
+				allErrs = append(allErrs, field.Invalid(path.Child("args").Index(i), arg, err.Error()))
+			}
+		}
+		for key, value := range member.spec.PodAnnotations {
+			if err := placeholders.Validate(value); err != nil {
+				allErrs = append(allErrs, field.Invalid(path.Child("podAnnotations").Key(key), value, err.Error()))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validatePlacement checks that a squad does not ask to avoid its own pods
+func validatePlacement(virtsquad *appsv1.VirtSquad) field.ErrorList {
+	var allErrs field.ErrorList
+	if virtsquad.Spec.Placement == nil {
+		return allErrs
+	}
+	for i, name := range virtsquad.Spec.Placement.AvoidSquads {
+		if name == virtsquad.Name {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "placement", "avoidSquads").Index(i), name,
+				"must not be the squad's own name"))
+		}
+	}
+	return allErrs
+}
+
+// validateDependencies checks that members depend on other configured members without cycles.
+// Members may come from the squad's template, so unknown dependencies are only rejected without one.
+func validateDependencies(virtsquad *appsv1.VirtSquad) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	dependsOn := map[string][]string{}
+	for _, member := range teamMembers(&virtsquad.Spec) {
+		if member.spec != nil {
+			dependsOn[member.name] = member.spec.DependsOn
+		}
+	}
+	for _, member := range teamMembers(&virtsquad.Spec) {
+		if member.spec == nil {
+			continue
+		}
+		for i, dependency := range member.spec.DependsOn {
+			path := specPath.Child(member.name, "dependsOn").Index(i)
+			switch _, configured := dependsOn[dependency]; {
+			case dependency == member.name:
+				allErrs = append(allErrs, field.Invalid(path, dependency, "a team member cannot depend on itself"))
+			case !configured && virtsquad.Spec.TemplateRef == nil:
+				allErrs = append(allErrs, field.Invalid(path, dependency, "must name a configured team member"))
+			}
+		}
+	}
+
+	// Walk the dependencies depth first; reaching a member still on the path closes a cycle
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := map[string]int{}
+	var visit func(name string, path []string) []string
+	visit = func(name string, path []string) []string {
+		switch state[name] {
+		case visiting:
+			return append(path, name)
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		for _, dependency := range dependsOn[name] {
+			if dependency == name {
+				continue
+			}
+			if cycle := visit(dependency, append(path, name)); cycle != nil {
+				return cycle
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+	for _, member := range teamMembers(&virtsquad.Spec) {
+		if cycle := visit(member.name, nil); cycle != nil {
+			cycle = cycle[slices.Index(cycle, cycle[len(cycle)-1]):]
+			allErrs = append(allErrs, field.Forbidden(specPath.Child(cycle[0], "dependsOn"),
+				fmt.Sprintf("team members depend on each other in a cycle: %s", strings.Join(cycle, " -> "))))
+			break
+		}
+	}
+	return allErrs
+}
+
+// validateSchedules checks that schedules parse and stay within the per-member replica ceiling,
+// that the rotation schedule parses and rotates among configured members, and that the
+// hibernation windows parse
+func (v *VirtSquadCustomValidator) validateSchedules(virtsquad *appsv1.VirtSquad) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, schedule := range virtsquad.Spec.Schedules {
+		schedulePath := field.NewPath("spec", "schedules").Index(i)
+		if _, err := cron.ParseStandard(schedule.Cron); err != nil {
+			allErrs = append(allErrs, field.Invalid(schedulePath.Child("cron"), schedule.Cron, err.Error()))
+		}
+		if v.MaxReplicasPerMember > 0 && schedule.Replicas > v.MaxReplicasPerMember {
+			allErrs = append(allErrs, field.Invalid(schedulePath.Child("replicas"), schedule.Replicas,
+				fmt.Sprintf("must not exceed the operator's limit of %d replicas per team member", v.MaxReplicasPerMember)))
+		}
+	}
+
+	if rotation := virtsquad.Spec.Rotation; rotation != nil {
+		rotationPath := field.NewPath("spec", "rotation")
+		if _, err := cron.ParseStandard(rotation.Schedule); err != nil {
+			allErrs = append(allErrs, field.Invalid(rotationPath.Child("schedule"), rotation.Schedule, err.Error()))
+		}
+		// Members may come from the squad's template, so unknown members are only rejected without one
+		if virtsquad.Spec.TemplateRef == nil {
+			for i, name := range rotation.Members {
+				if !slices.ContainsFunc(teamMembers(&virtsquad.Spec), func(member teamMember) bool {
+					return member.name == name && member.spec != nil
+				}) {
+					allErrs = append(allErrs, field.Invalid(rotationPath.Child("members").Index(i), name, "must name a configured team member"))
+				}
+			}
+		}
+	}
+
+	if hibernation := virtsquad.Spec.Hibernation; hibernation != nil {
+		hibernationPath := field.NewPath("spec", "hibernation")
+		timeZone := hibernation.TimeZone
+		if timeZone == "" {
+			timeZone = "UTC"
+		}
+		if _, err := time.LoadLocation(timeZone); err != nil {
+			allErrs = append(allErrs, field.Invalid(hibernationPath.Child("timeZone"), hibernation.TimeZone, err.Error()))
+			return allErrs
+		}
+		// Windows are parsed as the controller does, in the squad's time zone, so expressions
+		// carrying their own CRON_TZ or TZ prefix are rejected
+		for i, window := range hibernation.Windows {
+			windowPath := hibernationPath.Child("windows").Index(i)
+			if _, err := cron.ParseStandard("CRON_TZ=" + timeZone + " " + window.Start); err != nil {
+				allErrs = append(allErrs, field.Invalid(windowPath.Child("start"), window.Start, err.Error()))
+			}
+			if _, err := cron.ParseStandard("CRON_TZ=" + timeZone + " " + window.End); err != nil {
+				allErrs = append(allErrs, field.Invalid(windowPath.Child("end"), window.End, err.Error()))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateMemberRenames rejects changes to the pod name of members that already have one,
+// unless the update opts in with the force-rename annotation
+func validateMemberRenames(oldVirtsquad, virtsquad *appsv1.VirtSquad) field.ErrorList {
+	if virtsquad.Annotations[appsv1.ForceRenameAnnotation] == "true" {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	oldMembers := teamMembers(&oldVirtsquad.Spec)
+	for i, member := range teamMembers(&virtsquad.Spec) {
+		oldSpec := oldMembers[i].spec
+		if member.spec == nil || member.spec.Name == nil || oldSpec == nil || oldSpec.Name == nil {
+			continue
+		}
+		if *member.spec.Name != *oldSpec.Name {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", member.name, "name"),
+				fmt.Sprintf("cannot rename %q to %q since existing pods would be stranded; set the %s annotation to \"true\" to force it",
+					*oldSpec.Name, *member.spec.Name, appsv1.ForceRenameAnnotation)))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,375 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	appsv1 "github.com/mshort55/virtsquad-operator/api/v1"
+	"github.com/mshort55/virtsquad-operator/test/crd"
+)
+
+var _ = Describe("VirtSquad Webhook", func() {
+	var (
+		obj       *appsv1.VirtSquad
+		oldObj    *appsv1.VirtSquad
+		validator VirtSquadCustomValidator
+	)
+
+	BeforeEach(func() {
+		obj = &appsv1.VirtSquad{
+			Spec: appsv1.VirtSquadSpec{
+				Oksana: &appsv1.TeamMemberSpec{Name: ptr.To("oksana-pod"), Replicas: ptr.To(int32(3))},
+				Kurtis: &appsv1.TeamMemberSpec{Name: ptr.To("kurtis-pod")},
+			},
+		}
+		oldObj = obj.DeepCopy()
+		validator = VirtSquadCustomValidator{MaxReplicasPerMember: 5, MaxPodsPerSquad: 8}
+		Expect(validator).NotTo(BeNil(), "Expected validator to be initialized")
+		Expect(oldObj).NotTo(BeNil(), "Expected oldObj to be initialized")
+		Expect(obj).NotTo(BeNil(), "Expected obj to be initialized")
+	})
+
+	Context("When creating or updating VirtSquad under Validating Webhook", func() {
+		It("Should admit creation within the replica ceilings", func() {
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
+		})
+
+		It("Should deny creation if a member exceeds the per-member ceiling", func() {
+			obj.Spec.Oksana.Replicas = ptr.To(int32(1000))
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.oksana.replicas"))
+		})
+
+		It("Should deny update if the squad exceeds the per-squad ceiling", func() {
+			obj.Spec.Matt = &appsv1.TeamMemberSpec{Name: ptr.To("matt-pod"), Replicas: ptr.To(int32(5))}
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("9 pods in total"))
+		})
+
+		It("Should deny update if an existing member is renamed", func() {
+			obj.Spec.Oksana.Name = ptr.To("oksana-renamed")
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.oksana.name"))
+		})
+
+		It("Should admit a rename that carries the force-rename annotation", func() {
+			obj.Spec.Oksana.Name = ptr.To("oksana-renamed")
+			obj.Annotations = map[string]string{appsv1.ForceRenameAnnotation: "true"}
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).To(BeNil())
+		})
+
+		It("Should admit naming a member that had no name before", func() {
+			oldObj.Spec.Matt = &appsv1.TeamMemberSpec{}
+			obj.Spec.Matt = &appsv1.TeamMemberSpec{Name: ptr.To("matt-pod")}
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).To(BeNil())
+		})
+
+		It("Should deny creation if a schedule cannot be parsed", func() {
+			obj.Spec.Schedules = []appsv1.ScheduleSpec{
+				{Cron: "0 8 * * 1-5", Member: "oksana", Replicas: 4},
+				{Cron: "every evening", Member: "oksana", Replicas: 1},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.schedules[1].cron"))
+		})
+
+		It("Should deny creation if the hibernation time zone is unknown", func() {
+			obj.Spec.Hibernation = &appsv1.HibernationSpec{
+				TimeZone: "Mars/Olympus_Mons",
+				Windows:  []appsv1.HibernationWindow{{Start: "0 20 * * 5", End: "0 6 * * 1"}},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.hibernation.timeZone"))
+		})
+
+		It("Should deny hibernation windows carrying their own time zone", func() {
+			obj.Spec.Hibernation = &appsv1.HibernationSpec{
+				TimeZone: "Europe/Berlin",
+				Windows: []appsv1.HibernationWindow{
+					{Start: "0 20 * * 5", End: "0 6 * * 1"},
+					{Start: "CRON_TZ=America/New_York 0 20 * * 5", End: "TZ=UTC 0 6 * * 1"},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).NotTo(ContainSubstring("spec.hibernation.windows[0]"))
+			Expect(err.Error()).To(ContainSubstring("spec.hibernation.windows[1].start"))
+			Expect(err.Error()).To(ContainSubstring("spec.hibernation.windows[1].end"))
+		})
+
+		It("Should deny rotating among members that are not configured", func() {
+			obj.Spec.Rotation = &appsv1.RotationSpec{Schedule: "0 9 * * 1", Members: []string{"oksana", "matt"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.rotation.members[1]"))
+
+			// The template may configure them
+			obj.Spec.TemplateRef = &appsv1.TemplateReference{Name: "web"}
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
+			Expect(validator.ValidateMergedSpec(obj)).To(MatchError(ContainSubstring("spec.rotation.members[1]")))
+		})
+
+		It("Should deny replicating a squad into its own namespace", func() {
+			obj.Namespace = "team-a"
+			obj.Spec.TargetNamespaces = []string{"team-b", "team-a"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.targetNamespaces[1]"))
+		})
+
+		It("Should deny replicating a squad that is itself a replica", func() {
+			obj.Labels = map[string]string{appsv1.ReplicaOfLabel: "team-a"}
+			obj.Spec.TargetNamespaces = []string{"team-c"}
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.targetNamespaces"))
+		})
+
+		It("Should deny dependencies on unconfigured members", func() {
+			obj.Spec.Kurtis.DependsOn = []string{"oksana", "kike"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.kurtis.dependsOn[1]"))
+		})
+
+		It("Should deny members that depend on each other in a cycle", func() {
+			obj.Spec.Oksana.DependsOn = []string{"kurtis"}
+			obj.Spec.Kurtis.DependsOn = []string{"oksana"}
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("oksana -> kurtis -> oksana"))
+		})
+
+		It("Should deny a squad avoiding its own pods", func() {
+			obj.Name = "squad-a"
+			obj.Spec.Placement = &appsv1.PlacementSpec{AvoidSquads: []string{"squad-b", "squad-a"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.placement.avoidSquads[1]"))
+		})
+
+		It("Should deny dedicated CPUs without a whole number of CPUs", func() {
+			obj.Spec.Oksana.Performance = &appsv1.PerformanceSpec{DedicatedCPUs: ptr.To(true)}
+			obj.Spec.Oksana.Resources = &corev1.ResourceRequirements{Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1500m"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.oksana.resources.requests.cpu"))
+		})
+
+		It("Should deny dedicated nodes unless the operator allows them", func() {
+			obj.Spec.DedicatedNodes = &appsv1.DedicatedNodesSpec{NodeSelector: map[string]string{"kubernetes.io/os": "linux"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.dedicatedNodes"))
+
+			// Squads admitted earlier can still be updated while they leave their nodes alone
+			oldObj.Spec.DedicatedNodes = obj.Spec.DedicatedNodes.DeepCopy()
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).To(BeNil())
+
+			validator.AllowDedicatedNodes = true
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
+		})
+
+		It("Should deny smoke tests sending requests to another host", func() {
+			obj.Spec.Oksana.SmokeTest = &appsv1.SmokeTestSpec{HTTPGet: &corev1.HTTPGetAction{Host: "169.254.169.254"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.oksana.smokeTest.httpGet.host"))
+		})
+
+		It("Should not count members without a name against the ceilings", func() {
+			obj.Spec.Matt = &appsv1.TeamMemberSpec{Replicas: ptr.To(int32(1000))}
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
+		})
+
+		It("Should deny strictly isolated members running the default image", func() {
+			obj.Spec.Isolation = appsv1.IsolationStrict
+			obj.Spec.Kurtis.Image = "nginxinc/nginx-unprivileged"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.oksana.image"))
+			Expect(err.Error()).NotTo(ContainSubstring("spec.kurtis.image"))
+		})
+
+		It("Should deny strictly isolated members of stored squads naming the default image", func() {
+			schema, err := crd.Load("virtsquads")
+			Expect(err).NotTo(HaveOccurred())
+			obj.Spec.Isolation = appsv1.IsolationStrict
+			// Squads stored while the CRD defaulted the image carry it explicitly
+			obj.Spec.Oksana.Image = appsv1.DefaultMemberImage
+			obj.Spec.Kurtis.Image = "nginxinc/nginx-unprivileged"
+			Expect(schema.Default(obj)).To(Succeed())
+			_, err = validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.oksana.image"))
+			Expect(err.Error()).NotTo(ContainSubstring("spec.kurtis.image"))
+		})
+
+		It("Should deny squads without team members unless they are restored from a backup", func() {
+			obj.Spec = appsv1.VirtSquadSpec{Backup: &appsv1.BackupSpec{
+				Bucket:               "squads",
+				CredentialsSecretRef: corev1.LocalObjectReference{Name: "backup-credentials"},
+			}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("at least one team member must be defined"))
+
+			obj.Annotations = map[string]string{appsv1.RestoreFromAnnotation: "default/web/20260101T000000Z.json"}
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
+		})
+
+		It("Should deny placeholders that refer to unknown values", func() {
+			obj.Spec.Oksana.Args = []string{"--squad={{ .Squad.Name }}", "--replica={{ .Replica }}"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.oksana.args[1]"))
+		})
+
+		It("Should deny a squad whose pods collide with another squad's", func() {
+			scheme := runtime.NewScheme()
+			Expect(appsv1.AddToScheme(scheme)).To(Succeed())
+			other := &appsv1.VirtSquad{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+				Spec: appsv1.VirtSquadSpec{
+					Kike: &appsv1.TeamMemberSpec{Name: ptr.To("oksana-pod")},
+					Matt: &appsv1.TeamMemberSpec{Name: ptr.To("matt-pod"), PodLabels: map[string]string{"app": "web", "tier": "front"}},
+				},
+			}
+			validator.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(other).Build()
+			obj.Name, obj.Namespace = "squad", "default"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("oksana and its kike both name their pods oksana-pod"))
+
+			obj.Spec.Oksana.Name = ptr.To("oksana-renamed")
+			obj.Annotations = map[string]string{appsv1.ForceRenameAnnotation: "true"}
+			obj.Spec.Kurtis.PodLabels = map[string]string{"app": "web"}
+			_, err = validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("the podLabels of kurtis also select the pods of its matt"))
+		})
+
+		It("Should deny replicating into namespaces the author may not create squads in", func() {
+			scheme := runtime.NewScheme()
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			Expect(authorizationv1.AddToScheme(scheme)).To(Succeed())
+			system := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system", Labels: map[string]string{"tier": "any"}}}
+			validator.Reviewer = fake.NewClientBuilder().WithScheme(scheme).WithObjects(system).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+						review := obj.(*authorizationv1.SubjectAccessReview)
+						review.Status.Allowed = review.Spec.User == "alice" && review.Spec.ResourceAttributes.Namespace == "team-b"
+						return nil
+					},
+				}).Build()
+			reviewCtx := admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo: authenticationv1.UserInfo{Username: "alice"},
+			}})
+			obj.Namespace = "team-a"
+			obj.Spec.TargetNamespaces = []string{"team-b"}
+			Expect(validator.ValidateCreate(reviewCtx, obj)).To(BeNil())
+
+			obj.Spec.NamespaceSelector = &metav1.LabelSelector{}
+			_, err := validator.ValidateCreate(reviewCtx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("user alice may not create VirtSquads in namespace kube-system"))
+		})
+
+		It("Should deny sending Secrets the author may not read to the squad's endpoints", func() {
+			scheme := runtime.NewScheme()
+			Expect(authorizationv1.AddToScheme(scheme)).To(Succeed())
+			validator.Reviewer = fake.NewClientBuilder().WithScheme(scheme).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+						review := obj.(*authorizationv1.SubjectAccessReview)
+						attributes := review.Spec.ResourceAttributes
+						review.Status.Allowed = review.Spec.User == "alice" && attributes.Verb == "get" &&
+							attributes.Resource == "secrets" && attributes.Namespace == "team-a" && attributes.Name == "hook-token"
+						return nil
+					},
+				}).Build()
+			reviewCtx := admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo: authenticationv1.UserInfo{Username: "alice"},
+			}})
+			obj.Namespace = "team-a"
+			obj.Spec.Hooks = &appsv1.HooksSpec{PreDelete: &appsv1.HTTPHookSpec{
+				URL: "https://hooks.example.com/deregister",
+				AuthSecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "hook-token"}, Key: "token",
+				},
+			}}
+			Expect(validator.ValidateCreate(reviewCtx, obj)).To(BeNil())
+
+			oldObj.Namespace = "team-a"
+			obj.Spec.Hooks.PreDelete.AuthSecretRef.Name = "operator-credentials"
+			_, err := validator.ValidateUpdate(reviewCtx, oldObj, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.hooks.preDelete.authSecretRef.name"))
+			Expect(err.Error()).To(ContainSubstring("user alice may not get Secret operator-credentials in namespace team-a"))
+
+			obj.Spec.Hooks.PreDelete.AuthSecretRef.Name = "hook-token"
+			obj.Spec.Notifications = &appsv1.NotificationsSpec{Webhook: &appsv1.WebhookNotificationSpec{
+				URL:              "https://events.example.com/squads",
+				HeadersSecretRef: &corev1.LocalObjectReference{Name: "operator-credentials"},
+			}}
+			_, err = validator.ValidateUpdate(reviewCtx, oldObj, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.notifications.webhook.headersSecretRef.name"))
+			Expect(err.Error()).NotTo(ContainSubstring("spec.hooks"))
+		})
+
+		It("Should check squads merged with their template as it checks their own spec", func() {
+			Expect(validator.ValidateMergedSpec(obj)).To(Succeed())
+			obj.Spec.Matt = &appsv1.TeamMemberSpec{Name: ptr.To("matt-pod"), Replicas: ptr.To(int32(100))}
+			obj.Spec.Kurtis.DependsOn = []string{"kike"}
+			err := validator.ValidateMergedSpec(obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.matt.replicas"))
+			Expect(err.Error()).To(ContainSubstring("spec.kurtis.dependsOn[0]"))
+		})
+
+		It("Should not enforce ceilings that are unset", func() {
+			validator = VirtSquadCustomValidator{}
+			obj.Spec.Oksana.Replicas = ptr.To(int32(1000))
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
+		})
+	})
+})
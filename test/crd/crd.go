@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package crd applies the defaults and validation of the generated CRDs to objects, as the API
+// server does, so that tests can check schema defaults and CEL rules without an API server.
+package crd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	structuraldefaulting "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+	"sigs.k8s.io/yaml"
+)
+
+// Schema is the schema of one version of a generated CRD
+type Schema struct {
+	structural *structuralschema.Structural
+	validator  validation.SchemaValidator
+	cel        *cel.Validator
+}
+
+// Load reads the schema of the CRD with the given plural name from config/crd/bases, e.g.
+// "virtsquads"
+func Load(plural string) (*Schema, error) {
+	_, file, _, _ := runtime.Caller(0)
+	path := filepath.Join(filepath.Dir(file), "..", "..", "config", "crd", "bases", "apps.mshort55.io_"+plural+".yaml")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	definition := &apiextensionsv1.CustomResourceDefinition{}
+	if err := yaml.Unmarshal(data, definition); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	if len(definition.Spec.Versions) != 1 {
+		return nil, fmt.Errorf("%s has %d versions, expected one", path, len(definition.Spec.Versions))
+	}
+
+	props := &apiextensions.JSONSchemaProps{}
+	if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(
+		definition.Spec.Versions[0].Schema.OpenAPIV3Schema, props, nil); err != nil {
+		return nil, err
+	}
+	structural, err := structuralschema.NewStructural(props)
+	if err != nil {
+		return nil, err
+	}
+	validator, _, err := validation.NewSchemaValidator(props)
+	if err != nil {
+		return nil, err
+	}
+	return &Schema{
+		structural: structural,
+		validator:  validator,
+		cel:        cel.NewValidator(structural, true, celconfig.PerCallLimit),
+	}, nil
+}
+
+// Default applies the schema's defaults to the object in place
+func (s *Schema) Default(obj k8sruntime.Object) error {
+	content, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	structuraldefaulting.Default(content, s.structural)
+	return k8sruntime.DefaultUnstructuredConverter.FromUnstructured(content, obj)
+}
+
+// Validate checks a new object against the schema and its CEL rules
+func (s *Schema) Validate(obj k8sruntime.Object) (field.ErrorList, error) {
+	return s.ValidateUpdate(obj, nil)
+}
+
+// ValidateUpdate checks an updated object against the schema and its CEL rules, including
+// transition rules comparing it to the old object. A nil old object validates a creation.
+func (s *Schema) ValidateUpdate(obj, old k8sruntime.Object) (field.ErrorList, error) {
+	content, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	var oldContent map[string]interface{}
+	if old != nil {
+		if oldContent, err = k8sruntime.DefaultUnstructuredConverter.ToUnstructured(old); err != nil {
+			return nil, err
+		}
+	}
+
+	errs := validation.ValidateCustomResource(nil, content, s.validator)
+	if oldContent != nil {
+		errs = validation.ValidateCustomResourceUpdate(nil, content, oldContent, s.validator)
+	}
+	var oldSelf interface{}
+	if oldContent != nil {
+		oldSelf = oldContent
+	}
+	celErrs, _ := s.cel.Validate(context.Background(), nil, s.structural, content, oldSelf, celconfig.RuntimeCELCostBudget)
+	return append(errs, celErrs...), nil
+}